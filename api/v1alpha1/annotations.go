@@ -0,0 +1,68 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// Annotations of objects (e.g. Ingresses) Checks are generated from
+const (
+	// CheckAnnotation opts an object in to generating Checks when set to
+	// "true"
+	CheckAnnotation = "pingdom.mig4.gitlab.io/check"
+
+	// ResolutionAnnotation sets `resolutionMinutes` of generated Checks
+	ResolutionAnnotation = "pingdom.mig4.gitlab.io/resolution"
+
+	// ContactsAnnotation sets `contacts` of generated Checks, a comma
+	// separated list of PingdomContact names
+	ContactsAnnotation = "pingdom.mig4.gitlab.io/contacts"
+
+	// TeamsAnnotation sets `teams` of generated Checks, a comma separated
+	// list of PingdomTeam names
+	TeamsAnnotation = "pingdom.mig4.gitlab.io/teams"
+
+	// ShouldContainAnnotation sets `shouldContain` of generated HTTP Checks
+	ShouldContainAnnotation = "pingdom.mig4.gitlab.io/should-contain"
+
+	// ShouldNotContainAnnotation sets `shouldNotContain` of generated HTTP
+	// Checks
+	ShouldNotContainAnnotation = "pingdom.mig4.gitlab.io/should-not-contain"
+
+	// CredentialsSecretAnnotation sets `credentialsSecret` of generated
+	// Checks
+	CredentialsSecretAnnotation = "pingdom.mig4.gitlab.io/credentials-secret"
+
+	// AccountAnnotation sets `accountRef` of generated Checks, either the
+	// name of a PingdomAccount or `ClusterPingdomAccount/<name>`
+	AccountAnnotation = "pingdom.mig4.gitlab.io/account"
+
+	// TypeAnnotation sets the type of Checks generated from Services, one of
+	// tcp (the default), http
+	TypeAnnotation = "pingdom.mig4.gitlab.io/type"
+
+	// PortsAnnotation limits Checks generated from Services to given ports,
+	// a comma separated list of port names or numbers
+	PortsAnnotation = "pingdom.mig4.gitlab.io/ports"
+
+	// URLAnnotation sets `url` of HTTP Checks generated from Services
+	URLAnnotation = "pingdom.mig4.gitlab.io/url"
+
+	// EncryptionAnnotation sets `encryption` of HTTP Checks generated from
+	// Services when set to "true"
+	EncryptionAnnotation = "pingdom.mig4.gitlab.io/encryption"
+)
+
+// GeneratedByLabel is set on generated Checks to the kind of the object they
+// are generated from
+const GeneratedByLabel = "pingdom.mig4.gitlab.io/generated-by"
@@ -17,6 +17,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -73,6 +74,10 @@ func (cs *CheckSpec) PostParams() map[string]string {
 		params["userids"] = intSliceToCommaSep(*cs.UserIds)
 	}
 
+	if cs.TeamIds != nil {
+		params["teamids"] = intSliceToCommaSep(*cs.TeamIds)
+	}
+
 	if cs.URL != nil {
 		params["url"] = *cs.URL
 	}
@@ -81,6 +86,22 @@ func (cs *CheckSpec) PostParams() map[string]string {
 		params["encryption"] = strconv.FormatBool(*cs.Encryption)
 	}
 
+	if cs.ShouldContain != nil {
+		params["shouldcontain"] = *cs.ShouldContain
+	}
+
+	if cs.ShouldNotContain != nil {
+		params["shouldnotcontain"] = *cs.ShouldNotContain
+	}
+
+	if cs.PostData != nil {
+		params["postdata"] = *cs.PostData
+	}
+
+	for i, name := range sortedKeys(cs.RequestHeaders) {
+		params["requestheader"+strconv.Itoa(i)] = name + ":" + cs.RequestHeaders[name]
+	}
+
 	return params
 }
 
@@ -115,9 +136,27 @@ func (cs *CheckSpec) Valid() error {
 		return fmt.Errorf("check `ResolutionMinutes` must be one of 1, 5, 15, 30 or 60")
 	}
 
+	if cs.ShouldContain != nil && cs.ShouldNotContain != nil {
+		return fmt.Errorf(
+			"check `ShouldContain` and `ShouldNotContain` cannot be set at the same time",
+		)
+	}
+
+	if cs.Type != HTTP && cs.hasHTTPOnlyParameters() {
+		return fmt.Errorf(
+			"check `ShouldContain`, `ShouldNotContain`, `PostData`, `RequestHeaders` " +
+				"and `Auth` are only supported for http checks",
+		)
+	}
+
 	return nil
 }
 
+func (cs *CheckSpec) hasHTTPOnlyParameters() bool {
+	return cs.ShouldContain != nil || cs.ShouldNotContain != nil ||
+		cs.PostData != nil || len(cs.RequestHeaders) > 0 || cs.Auth != nil
+}
+
 func intSliceToCommaSep(intSlice []int) string {
 	return strings.Join(intSliceToStrSlice(intSlice), ",")
 }
@@ -129,6 +168,15 @@ func intSliceToStrSlice(intSlice []int) (result []string) {
 	return
 }
 
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func isValidResolution(res int32) bool {
 	return res == 1 || res == 5 || res == 15 || res == 30 || res == 60
 }
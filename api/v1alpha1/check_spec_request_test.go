@@ -73,6 +73,7 @@ var _ = Describe("CheckSpecRequest Adapter", func() {
 					Port:              ptrI32(443),
 					ResolutionMinutes: ptrI32(15),
 					UserIds:           &[]int{10, 20, 40},
+					TeamIds:           &[]int{7},
 					URL:               ptrS("/text"),
 					Encryption:        ptrB(true),
 				},
@@ -86,6 +87,7 @@ var _ = Describe("CheckSpecRequest Adapter", func() {
 				"port":       "443",
 				"resolution": "15",
 				"userids":    "10,20,40",
+				"teamids":    "7",
 				"url":        "/text",
 				"encryption": "true",
 			}
@@ -133,4 +135,96 @@ var _ = Describe("CheckSpecRequest Adapter", func() {
 
 		AssertFailure("check `Type` must be one of")
 	})
+
+	Context("When the Spec contains content assertions", func() {
+		BeforeEach(func() {
+			spec = CheckSpec{CheckParameters: CheckParameters{
+				Name:          ptrS("assert"),
+				Host:          "assert.example.com",
+				Type:          HTTP,
+				URL:           ptrS("/login"),
+				ShouldContain: ptrS("Welcome"),
+				PostData:      ptrS("user=foo&pass=bar"),
+			}}
+			params = map[string]string{
+				"name":          "assert",
+				"host":          "assert.example.com",
+				"type":          "http",
+				"url":           "/login",
+				"shouldcontain": "Welcome",
+				"postdata":      "user=foo&pass=bar",
+			}
+		})
+
+		AssertSuccess("map with content assertion properties")
+	})
+
+	Context("When the Spec contains request headers", func() {
+		BeforeEach(func() {
+			spec = CheckSpec{CheckParameters: CheckParameters{
+				Name: ptrS("headers"),
+				Host: "headers.example.com",
+				Type: HTTP,
+				RequestHeaders: map[string]string{
+					"X-Api-Key": "secret",
+					"Host":      "internal.example.com",
+				},
+			}}
+			params = map[string]string{
+				"name":           "headers",
+				"host":           "headers.example.com",
+				"type":           "http",
+				"requestheader0": "Host:internal.example.com",
+				"requestheader1": "X-Api-Key:secret",
+			}
+		})
+
+		AssertSuccess("map with headers sorted by name")
+	})
+
+	Context("When the Spec contains conflicting content assertions", func() {
+		BeforeEach(func() {
+			spec = CheckSpec{CheckParameters: CheckParameters{
+				Name:             ptrS("conflict"),
+				Host:             "conflict.example.com",
+				Type:             HTTP,
+				ShouldContain:    ptrS("OK"),
+				ShouldNotContain: ptrS("Error"),
+			}}
+			params = map[string]string{}
+		})
+
+		AssertFailure("cannot be set at the same time")
+	})
+
+	Context("When the Spec contains basic auth for a non-http check", func() {
+		BeforeEach(func() {
+			spec = CheckSpec{
+				CheckParameters: CheckParameters{
+					Name: ptrS("ping-auth"),
+					Host: "ping.example.com",
+					Type: Ping,
+				},
+				Auth: &HTTPAuth{},
+			}
+			params = map[string]string{}
+		})
+
+		AssertFailure("only supported for http checks")
+	})
+
+	Context("When the Spec contains content assertions for a non-http check", func() {
+		BeforeEach(func() {
+			spec = CheckSpec{CheckParameters: CheckParameters{
+				Name:          ptrS("tcp-assert"),
+				Host:          "tcp.example.com",
+				Type:          TCP,
+				Port:          ptrI32(22),
+				ShouldContain: ptrS("SSH"),
+			}}
+			params = map[string]string{}
+		})
+
+		AssertFailure("only supported for http checks")
+	})
 })
@@ -44,7 +44,7 @@ func (c *Check) NeedsUpdate() bool {
 	}
 
 	opts := make(cmp.Options, 0)
-	unspecifiedFields := make([]string, 0)
+	unspecifiedFields := []string{"RequestHeaders"}
 
 	if spec.Name == nil {
 		unspecifiedFields = append(unspecifiedFields, "Name")
@@ -58,19 +58,35 @@ func (c *Check) NeedsUpdate() bool {
 	if spec.UserIds == nil {
 		unspecifiedFields = append(unspecifiedFields, "UserIds")
 	}
+	if spec.TeamIds == nil {
+		unspecifiedFields = append(unspecifiedFields, "TeamIds")
+	}
+	if spec.Tags == nil {
+		unspecifiedFields = append(unspecifiedFields, "Tags")
+	}
 	if spec.URL == nil {
 		unspecifiedFields = append(unspecifiedFields, "URL")
 	}
 	if spec.Encryption == nil {
 		unspecifiedFields = append(unspecifiedFields, "Encryption")
 	}
-
-	if len(unspecifiedFields) > 0 {
-		opts = append(
-			opts,
-			cmpopts.IgnoreFields(CheckParameters{}, unspecifiedFields...),
-		)
+	if spec.ShouldContain == nil {
+		unspecifiedFields = append(unspecifiedFields, "ShouldContain")
 	}
+	if spec.ShouldNotContain == nil {
+		unspecifiedFields = append(unspecifiedFields, "ShouldNotContain")
+	}
+	if spec.PostData == nil {
+		unspecifiedFields = append(unspecifiedFields, "PostData")
+	}
+
+	opts = append(
+		opts,
+		cmpopts.IgnoreFields(CheckParameters{}, unspecifiedFields...),
+		// the order of tags doesn't matter
+		cmpopts.SortSlices(func(a, b string) bool { return a < b }),
+		cmpopts.EquateEmpty(),
+	)
 
 	return !cmp.Equal(spec.CheckParameters, status.CheckParameters, opts)
 }
@@ -103,6 +103,14 @@ var _ = Describe("CheckStatusComparator", func() {
 				Name: ptrS("grault"), Host: "grault", Type: Ping, UserIds: &[]int{2},
 			}},
 		}, BeTrue()),
+		Entry("with different Team IDs", &Check{
+			Spec: CheckSpec{CheckParameters: CheckParameters{
+				Name: ptrS("grault"), Host: "grault", Type: Ping, TeamIds: &[]int{3},
+			}},
+			Status: CheckStatus{ID: 7, CheckParameters: CheckParameters{
+				Name: ptrS("grault"), Host: "grault", Type: Ping, TeamIds: &[]int{},
+			}},
+		}, BeTrue()),
 		Entry("with different URL", &Check{
 			Spec: CheckSpec{CheckParameters: CheckParameters{
 				Name: ptrS("garply"), Host: "garply", Type: HTTP, URL: ptrS("/text"),
@@ -119,6 +127,54 @@ var _ = Describe("CheckStatusComparator", func() {
 				Name: ptrS("waldo"), Host: "waldo", Type: HTTP, Encryption: ptrB(false),
 			}},
 		}, BeTrue()),
+		Entry("with different content assertion", &Check{
+			Spec: CheckSpec{CheckParameters: CheckParameters{
+				Name: ptrS("plugh"), Host: "plugh", Type: HTTP, ShouldContain: ptrS("OK"),
+			}},
+			Status: CheckStatus{ID: 10, CheckParameters: CheckParameters{
+				Name: ptrS("plugh"), Host: "plugh", Type: HTTP, ShouldContain: ptrS("Error"),
+			}},
+		}, BeTrue()),
+		Entry("with content assertion only in spec", &Check{
+			Spec: CheckSpec{CheckParameters: CheckParameters{
+				Name: ptrS("xyzzy"), Host: "xyzzy", Type: HTTP, ShouldNotContain: ptrS("Error"),
+			}},
+			Status: CheckStatus{ID: 11, CheckParameters: CheckParameters{
+				Name: ptrS("xyzzy"), Host: "xyzzy", Type: HTTP,
+			}},
+		}, BeTrue()),
+		Entry("with different post data", &Check{
+			Spec: CheckSpec{CheckParameters: CheckParameters{
+				Name: ptrS("thud"), Host: "thud", Type: HTTP, PostData: ptrS("a=1"),
+			}},
+			Status: CheckStatus{ID: 12, CheckParameters: CheckParameters{
+				Name: ptrS("thud"), Host: "thud", Type: HTTP, PostData: ptrS("a=2"),
+			}},
+		}, BeTrue()),
+		Entry("with the same tags in a different order", &Check{
+			Spec: CheckSpec{CheckParameters: CheckParameters{
+				Name: ptrS("tag1"), Host: "tag1", Type: Ping, Tags: []string{"b", "a"},
+			}},
+			Status: CheckStatus{ID: 16, CheckParameters: CheckParameters{
+				Name: ptrS("tag1"), Host: "tag1", Type: Ping, Tags: []string{"a", "b"},
+			}},
+		}, BeFalse()),
+		Entry("with different tags", &Check{
+			Spec: CheckSpec{CheckParameters: CheckParameters{
+				Name: ptrS("tag2"), Host: "tag2", Type: Ping, Tags: []string{},
+			}},
+			Status: CheckStatus{ID: 17, CheckParameters: CheckParameters{
+				Name: ptrS("tag2"), Host: "tag2", Type: Ping, Tags: []string{"a"},
+			}},
+		}, BeTrue()),
+		Entry("with tags not set in spec", &Check{
+			Spec: CheckSpec{CheckParameters: CheckParameters{
+				Name: ptrS("tag3"), Host: "tag3", Type: Ping,
+			}},
+			Status: CheckStatus{ID: 18, CheckParameters: CheckParameters{
+				Name: ptrS("tag3"), Host: "tag3", Type: Ping, Tags: []string{"a"},
+			}},
+		}, BeFalse()),
 		Entry("with no difference with all parameters", &Check{
 			Spec: CheckSpec{CheckParameters: CheckParameters{
 				Name: ptrS("fred"), Host: "fred", Type: HTTP, Port: ptrI32(443),
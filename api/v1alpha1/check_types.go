@@ -16,6 +16,9 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -40,7 +43,8 @@ const (
 	// DNS is a check type that tries to resolve host using specified DNS server
 	DNS CheckType = "dns"
 
-	// UDP is a check type that sends a packet to a UDP port
+	// UDP is a check type that sends a packet to a UDP port; not supported
+	// by Checks yet, as they can't set the strings to send and expect
 	UDP CheckType = "udp"
 
 	// SMTP is a check type that opens a connection to SMTP server
@@ -66,6 +70,46 @@ const (
 	Paused          CheckResult = "paused"
 )
 
+// AdoptionPolicy determines how an existing Pingdom check is looked up in order
+// to adopt it, instead of creating a new one, when the Check has no ID yet.
+// +kubebuilder:validation:Enum=None;ByTag;ByName
+type AdoptionPolicy string
+
+// Possible values of AdoptionPolicy, used in CheckSpec.AdoptionPolicy
+const (
+	// AdoptNone never adopts existing checks, always creates a new one
+	AdoptNone AdoptionPolicy = "None"
+
+	// AdoptByTag adopts an existing check tagged with the ownership tag the
+	// operator adds to all checks it creates or adopts (see
+	// Check.OwnershipTag)
+	AdoptByTag AdoptionPolicy = "ByTag"
+
+	// AdoptByName adopts an existing check with the same name and type, or
+	// the one with the ownership tag if there is one; checks tagged with the
+	// ownership tag of a different Check are never adopted
+	AdoptByName AdoptionPolicy = "ByName"
+)
+
+// DeletionPolicy determines what happens to the Pingdom check when the Check
+// object is deleted.
+// +kubebuilder:validation:Enum=Delete;Retain;Pause
+type DeletionPolicy string
+
+// Possible values of DeletionPolicy, used in CheckSpec.DeletionPolicy
+const (
+	// DeletionPolicyDelete deletes the Pingdom check
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+
+	// DeletionPolicyRetain leaves the Pingdom check, only removing the
+	// ownership tag
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+
+	// DeletionPolicyPause leaves the Pingdom check but pauses it and removes
+	// the ownership tag
+	DeletionPolicyPause DeletionPolicy = "Pause"
+)
+
 // CheckParameters are parameters of a Check in Pingdom
 type CheckParameters struct {
 	// Check name; defaults to name of the object in Kubernetes
@@ -80,7 +124,7 @@ type CheckParameters struct {
 	Type CheckType `json:"type"`
 
 	// Target port
-	// Required for check types: tcp, udp
+	// Required for check types: tcp
 	// Optional for: http(80), httpcustom(80), smtp(25), pop3(110), imap(143)
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:Maximum=65535
@@ -95,6 +139,16 @@ type CheckParameters struct {
 	// +optional
 	UserIds *[]int `json:"userids,omitempty"`
 
+	// Team identifiers of teams which should receive alerts
+	// +optional
+	TeamIds *[]int `json:"teamids,omitempty"`
+
+	// Tags of the check, besides the ownership tag the operator adds (see
+	// `adoptionPolicy`); tags set on the check otherwise are replaced when
+	// set.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
 	// HTTP Checks
 
 	// Target path on server
@@ -105,6 +159,55 @@ type CheckParameters struct {
 	// Connection encryption; defaults to false
 	// +optional
 	Encryption *bool `json:"encryption,omitempty"`
+
+	// Target site should contain this string.
+	// Note Pingdom only does a plain substring match, regular expressions are
+	// not supported. Cannot be set together with `shouldNotContain`.
+	// +optional
+	ShouldContain *string `json:"shouldContain,omitempty"`
+
+	// Target site should NOT contain this string.
+	// Cannot be set together with `shouldContain`.
+	// +optional
+	ShouldNotContain *string `json:"shouldNotContain,omitempty"`
+
+	// Data that should be posted to the web page, for example submission data
+	// for a sign-up or login form. The data needs to be formatted in the same
+	// way as a web browser would send it to the web server.
+	// +optional
+	PostData *string `json:"postData,omitempty"`
+
+	// Custom HTTP headers to send with the request, e.g. `Host` or an API key
+	// +optional
+	RequestHeaders map[string]string `json:"requestHeaders,omitempty"`
+}
+
+// HTTPAuth references basic authentication credentials for HTTP checks
+// stored in a Secret
+type HTTPAuth struct {
+	// Secret storing the credentials, must be in the same namespace as the
+	// Check
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+
+	// Key in the Secret holding the username; defaults to `username`
+	// +optional
+	UsernameKey *string `json:"usernameKey,omitempty"`
+
+	// Key in the Secret holding the password; defaults to `password`
+	// +optional
+	PasswordKey *string `json:"passwordKey,omitempty"`
+}
+
+// AccountReference refers to a PingdomAccount or a ClusterPingdomAccount
+type AccountReference struct {
+	// Kind of the account, one of: PingdomAccount (in the same namespace as
+	// the Check), ClusterPingdomAccount. Defaults to PingdomAccount.
+	// +kubebuilder:validation:Enum=PingdomAccount;ClusterPingdomAccount
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Name of the account
+	Name string `json:"name"`
 }
 
 // CheckSpec defines the desired state of Check
@@ -118,8 +221,115 @@ type CheckSpec struct {
 	// +optional
 	Paused *bool `json:"paused,omitempty"`
 
-	// Secret storing Pingdom API credentials
-	CredentialsSecret corev1.LocalObjectReference `json:"credentialsSecret"`
+	// Secret storing Pingdom API credentials.
+	// At most one of `credentialsSecret` and `accountRef` may be set, if
+	// neither is, credentials are taken from CheckDefaults.
+	// +optional
+	CredentialsSecret corev1.LocalObjectReference `json:"credentialsSecret,omitempty"`
+
+	// PingdomContacts in the same namespace which should receive alerts,
+	// in addition to `userids`
+	// +optional
+	Contacts []corev1.LocalObjectReference `json:"contacts,omitempty"`
+
+	// PingdomTeams in the same namespace which should receive alerts, in
+	// addition to `teamids`
+	// +optional
+	Teams []corev1.LocalObjectReference `json:"teams,omitempty"`
+
+	// Pingdom account whose credentials (and defaults) to use.
+	// At most one of `credentialsSecret` and `accountRef` may be set, if
+	// neither is, credentials are taken from CheckDefaults.
+	// +optional
+	AccountRef *AccountReference `json:"accountRef,omitempty"`
+
+	// Identifier of an existing Pingdom check to adopt instead of creating a
+	// new one, even if it's tagged as managed by a different Check. Cannot
+	// be changed once set.
+	// +optional
+	CheckID *int32 `json:"checkID,omitempty"`
+
+	// How to look up an existing Pingdom check to adopt when `checkID` is not
+	// set and the Check has no ID in its status yet, e.g. after re-installing
+	// the operator or restoring the cluster from a backup; one of:
+	// None, ByTag, ByName. Defaults to ByTag.
+	// +optional
+	AdoptionPolicy *AdoptionPolicy `json:"adoptionPolicy,omitempty"`
+
+	// What to do with the Pingdom check when this Check is deleted; one of:
+	// Delete, Retain, Pause. Defaults to Delete.
+	// Retain or Pause keep the check along with its uptime history, e.g. when
+	// moving it to another namespace or cluster, where it can be adopted by
+	// setting `checkID`.
+	// +optional
+	DeletionPolicy *DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// How often to refresh the Check's status from Pingdom (and correct any
+	// drift from the spec), e.g. `5m`. Defaults to the check's resolution, or
+	// the operator's default requeue interval if that's longer.
+	// When the operator polls the list of checks, drift in details it doesn't
+	// include (e.g. HTTP parameters, contacts) is only detected when the
+	// check is read individually, at least every `--pingdom-details-interval`.
+	// +optional
+	SyncInterval *metav1.Duration `json:"syncInterval,omitempty"`
+
+	// Basic authentication credentials to use for HTTP checks.
+	// Note the values are resolved from the Secret at reconcile time and
+	// never stored in the Status.
+	// +optional
+	Auth *HTTPAuth `json:"auth,omitempty"`
+}
+
+// CheckSummary summarises availability and performance of a check over
+// recent periods, as reported by Pingdom
+type CheckSummary struct {
+	// Percentage of time the check was up in the last 24 hours, excluding
+	// time its status was unknown, e.g. "99.95"
+	// +optional
+	Uptime24h *string `json:"uptime24h,omitempty"`
+
+	// Percentage of time the check was up in the last 7 days
+	// +optional
+	Uptime7d *string `json:"uptime7d,omitempty"`
+
+	// Percentage of time the check was up in the last 30 days
+	// +optional
+	Uptime30d *string `json:"uptime30d,omitempty"`
+
+	// Average response time (in milliseconds) in the last 24 hours
+	// +optional
+	AvgResponseTimeMilis *int64 `json:"avgresponsetime,omitempty"`
+
+	// The most recent outage in the last 30 days (if any)
+	// +optional
+	LastOutage *Outage `json:"lastOutage,omitempty"`
+
+	// Time the summary was last refreshed
+	UpdatedTime metav1.Time `json:"updated"`
+}
+
+// Outage is a period during which a check was down
+type Outage struct {
+	// Start of the outage
+	Start metav1.Time `json:"start"`
+
+	// End of the outage, not set while the check is still down
+	// +optional
+	End *metav1.Time `json:"end,omitempty"`
+}
+
+// DefaultedField records where a parameter the Check doesn't set was taken
+// from
+type DefaultedField struct {
+	// Field of the spec, e.g. `resolutionMinutes`
+	Field string `json:"field"`
+
+	// Kind of the object the value was taken from: CheckDefaults or
+	// ClusterCheckDefaults
+	Kind string `json:"kind"`
+
+	// Name of the object the value was taken from
+	Name string `json:"name"`
 }
 
 // CheckStatus defines the observed state of Check
@@ -147,6 +357,59 @@ type CheckStatus struct {
 
 	// Check creation time.
 	CreatedTime metav1.Time `json:"created"`
+
+	// Summary of uptime, response time and outages of the check over recent
+	// periods, refreshed periodically.
+	// +optional
+	Summary *CheckSummary `json:"summary,omitempty"`
+
+	// Checksum of basic authentication credentials configured on the check
+	// (if any), used to detect changes without exposing the credentials;
+	// it's an HMAC keyed with the operator's secret key.
+	// +optional
+	AuthChecksum string `json:"authChecksum,omitempty"`
+
+	// Checksums of values of custom HTTP headers sent by the check, keyed by
+	// header name, used to detect changes without exposing the values (like
+	// API keys); they're HMACs keyed with the operator's secret key.
+	// +optional
+	RequestHeaderChecksums map[string]string `json:"requestHeaderChecksums,omitempty"`
+
+	// The generation of the Check spec that was last successfully applied to
+	// the Pingdom check
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Parameters not set in the spec which were taken from CheckDefaults or
+	// ClusterCheckDefaults, along with where they were taken from
+	// +optional
+	DefaultedFields []DefaultedField `json:"defaultedFields,omitempty"`
+
+	// Current conditions of the Check, at most one of each type:
+	// Ready, Synced, CredentialsValid, Deleting (only set when deleting)
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// SetCondition adds or updates a condition of given type on the Check.
+func (cs *CheckStatus) SetCondition(
+	condType ConditionType,
+	status corev1.ConditionStatus,
+	reason, message string,
+) {
+	cs.Conditions = setCondition(cs.Conditions, condType, status, reason, message)
+}
+
+// GetCondition returns a condition of given type or nil if it's not set.
+func (cs *CheckStatus) GetCondition(condType ConditionType) *Condition {
+	return getCondition(cs.Conditions, condType)
+}
+
+// IsConditionTrue returns true if the condition of given type is set and
+// its status is True.
+func (cs *CheckStatus) IsConditionTrue(condType ConditionType) bool {
+	cond := cs.GetCondition(condType)
+	return cond != nil && cond.Status == corev1.ConditionTrue
 }
 
 // +kubebuilder:object:root=true
@@ -155,6 +418,8 @@ type CheckStatus struct {
 // +kubebuilder:printcolumn:name="type",type=string,JSONPath=`.status.type`,description="Check type"
 // +kubebuilder:printcolumn:name="status",type=string,JSONPath=`.status.status`,description="Check status"
 // +kubebuilder:printcolumn:name="host",type=string,JSONPath=`.status.host`,description="Target host"
+// +kubebuilder:printcolumn:name="ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Check is in sync with Pingdom"
+// +kubebuilder:printcolumn:name="uptime",type=string,JSONPath=`.status.summary.uptime30d`,description="Uptime percentage in the last 30 days"
 
 // Check is the Schema for the checks API
 type Check struct {
@@ -165,6 +430,20 @@ type Check struct {
 	Status CheckStatus `json:"status,omitempty"`
 }
 
+// OwnershipTagPrefix is the prefix of ownership tags of all Checks (see
+// Check.OwnershipTag)
+const OwnershipTagPrefix = "pingdom-operator-"
+
+/*
+OwnershipTag returns the Pingdom tag added to the check created for this Check
+object, derived from its namespace and name, so the check can be found and
+adopted when the Check's status is lost.
+*/
+func (c *Check) OwnershipTag() string {
+	sum := sha256.Sum256([]byte(c.GetNamespace() + "/" + c.GetName()))
+	return OwnershipTagPrefix + hex.EncodeToString(sum[:8])
+}
+
 // +kubebuilder:object:root=true
 
 // CheckList contains a list of Check
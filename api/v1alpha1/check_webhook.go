@@ -0,0 +1,198 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var checklog = logf.Log.WithName("check-webhook")
+
+// Default ports for HTTP checks, used if `port` is not set
+const (
+	DefaultHTTPPort  int32 = 80
+	DefaultHTTPSPort int32 = 443
+)
+
+// SetupWebhookWithManager registers the defaulting and validating webhooks
+// for Check with the manager.
+func (c *Check) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(c).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-observability-pingdom-mig4-gitlab-io-v1alpha1-check,mutating=true,failurePolicy=fail,groups=observability.pingdom.mig4.gitlab.io,resources=checks,verbs=create;update,versions=v1alpha1,name=mcheck.kb.io
+
+var _ webhook.Defaulter = &Check{}
+
+/*
+Default implements webhook.Defaulter so a webhook will be registered for the
+type.
+
+It's also called by the controller on every reconcile so the defaults are
+there even if webhooks are not deployed.
+
+The port is not defaulted here, as a stored default wouldn't follow later
+changes to `encryption`, see DefaultPort.
+*/
+func (c *Check) Default() {
+	log := checklog.WithValues("name", c.GetName(), "namespace", c.GetNamespace())
+	spec := &c.Spec
+	status := &c.Status
+
+	if spec.Name == nil && c.GetName() != "" {
+		name := c.GetName()
+		spec.Name = &name
+		log.V(1).Info("using default Name", "name", name)
+	}
+	if spec.AdoptionPolicy == nil {
+		policy := AdoptByTag
+		spec.AdoptionPolicy = &policy
+		log.V(1).Info("using default AdoptionPolicy", "adoptionPolicy", policy)
+	}
+	if spec.DeletionPolicy == nil {
+		policy := DeletionPolicyDelete
+		spec.DeletionPolicy = &policy
+		log.V(1).Info("using default DeletionPolicy", "deletionPolicy", policy)
+	}
+	if spec.AccountRef != nil && spec.AccountRef.Kind == "" {
+		spec.AccountRef.Kind = PingdomAccountKind
+		log.V(1).Info("using default AccountRef.Kind", "kind", spec.AccountRef.Kind)
+	}
+	if status.CreatedTime.IsZero() && !c.CreationTimestamp.IsZero() {
+		status.CreatedTime = c.CreationTimestamp
+		log.V(1).Info("using default CreatedTime", "created", status.CreatedTime)
+	}
+	if status.Name == nil {
+		status.Name = spec.Name
+	}
+	if string(status.Status) == "" {
+		status.Status = Unknown
+		log.V(1).Info("using default Status", "status", status.Status)
+	}
+	if string(status.Type) == "" {
+		// Technically there should be no default type; this only needs to be
+		// set in order to avoid failing validation of the Status object
+		// before the resource is created and type is set correctly.
+		status.Type = Ping
+		log.V(1).Info("using default Type", "type", status.Type)
+	}
+}
+
+/*
+DefaultPort sets the port of HTTP checks which don't set it, depending on
+whether they use encryption. It's only applied by the controller in memory
+when reconciling, so the port follows changes to `encryption`.
+
+Only the port of HTTP checks is defaulted, as Pingdom API doesn't report the
+port of other check types so a defaulted value could never match the status.
+*/
+func (c *Check) DefaultPort() {
+	spec := &c.Spec
+	if spec.Port == nil && spec.Type == HTTP {
+		port := DefaultHTTPPort
+		if spec.Encryption != nil && *spec.Encryption {
+			port = DefaultHTTPSPort
+		}
+		spec.Port = &port
+		checklog.V(1).Info(
+			"using default Port", "name", c.GetName(), "namespace", c.GetNamespace(), "port", port,
+		)
+	}
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-observability-pingdom-mig4-gitlab-io-v1alpha1-check,mutating=false,failurePolicy=fail,groups=observability.pingdom.mig4.gitlab.io,resources=checks,versions=v1alpha1,name=vcheck.kb.io
+
+var _ webhook.Validator = &Check{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered
+// for the type.
+func (c *Check) ValidateCreate() error {
+	checklog.V(1).Info("validate create", "name", c.GetName())
+	return c.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered
+// for the type.
+func (c *Check) ValidateUpdate(old runtime.Object) error {
+	checklog.V(1).Info("validate update", "name", c.GetName())
+	oldCheck, ok := old.(*Check)
+	if !ok {
+		return fmt.Errorf("expected a Check but got a %T", old)
+	}
+	// Pingdom doesn't allow changing type of an existing check
+	if oldCheck.Spec.Type != c.Spec.Type {
+		return fmt.Errorf(
+			"check `Type` cannot be changed (from %s to %s)",
+			oldCheck.Spec.Type, c.Spec.Type,
+		)
+	}
+	if oldCheck.Spec.CheckID != nil &&
+		(c.Spec.CheckID == nil || *oldCheck.Spec.CheckID != *c.Spec.CheckID) {
+		return fmt.Errorf("check `CheckID` cannot be changed once set")
+	}
+	return c.validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered
+// for the type.
+func (c *Check) ValidateDelete() error {
+	return nil
+}
+
+/*
+validate checks the spec is valid in terms of what Pingdom API accepts as well
+as rules specific to check types which the API would only reject when the
+check is created.
+*/
+func (c *Check) validate() error {
+	spec := &c.Spec
+	if err := spec.Valid(); err != nil {
+		return err
+	}
+
+	// Pingdom requires udp checks to set strings to send and expect, which
+	// aren't supported yet
+	if spec.Type == UDP {
+		return fmt.Errorf("udp checks are not supported")
+	}
+
+	if spec.Type == TCP && spec.Port == nil {
+		return fmt.Errorf("check `Port` is required for %s checks", spec.Type)
+	}
+
+	if spec.SyncInterval != nil && spec.SyncInterval.Duration <= 0 {
+		return fmt.Errorf("check `SyncInterval` must be positive")
+	}
+
+	// neither may be set when credentials come from CheckDefaults
+	hasSecret := spec.CredentialsSecret.Name != ""
+	hasAccount := spec.AccountRef != nil && spec.AccountRef.Name != ""
+	if hasSecret && hasAccount {
+		return fmt.Errorf(
+			"at most one of check `CredentialsSecret` and `AccountRef` may be set",
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+)
+
+var _ = Describe("CheckWebhook", func() {
+	var check *Check
+
+	BeforeEach(func() {
+		check = &Check{
+			ObjectMeta: metav1.ObjectMeta{Name: "webhook"},
+			Spec: CheckSpec{
+				CheckParameters: CheckParameters{
+					Host: "webhook.example.com",
+					Type: HTTP,
+				},
+				CredentialsSecret: corev1.LocalObjectReference{Name: "pd-creds"},
+			},
+		}
+	})
+
+	Describe("DefaultPort", func() {
+		It("defaults port of http checks", func() {
+			check.DefaultPort()
+			Expect(check.Spec.Port).To(Equal(ptrI32(80)))
+		})
+
+		It("defaults port of encrypted http checks", func() {
+			check.Spec.Encryption = ptrB(true)
+			check.DefaultPort()
+			Expect(check.Spec.Port).To(Equal(ptrI32(443)))
+		})
+
+		It("doesn't default port of other checks", func() {
+			check.Spec.Type = SMTP
+			check.DefaultPort()
+			Expect(check.Spec.Port).To(BeNil())
+		})
+
+		It("keeps the port if set", func() {
+			check.Spec.Port = ptrI32(8080)
+			check.Spec.Encryption = ptrB(true)
+			check.DefaultPort()
+			Expect(check.Spec.Port).To(Equal(ptrI32(8080)))
+		})
+	})
+
+	Describe("Default", func() {
+		It("uses object name as check name", func() {
+			check.Default()
+			Expect(check.Spec.Name).To(Equal(ptrS("webhook")))
+			Expect(check.Status.Name).To(Equal(ptrS("webhook")))
+		})
+
+		It("keeps check name if set", func() {
+			check.Spec.Name = ptrS("custom")
+			check.Default()
+			Expect(check.Spec.Name).To(Equal(ptrS("custom")))
+		})
+
+		It("doesn't store a default port", func() {
+			check.Default()
+			Expect(check.Spec.Port).To(BeNil())
+		})
+
+		It("defaults adoption policy", func() {
+			check.Default()
+			Expect(*check.Spec.AdoptionPolicy).To(Equal(AdoptByTag))
+		})
+
+		It("defaults deletion policy", func() {
+			check.Default()
+			Expect(*check.Spec.DeletionPolicy).To(Equal(DeletionPolicyDelete))
+		})
+
+		It("defaults account kind", func() {
+			check.Spec.AccountRef = &AccountReference{Name: "account"}
+			check.Default()
+			Expect(check.Spec.AccountRef.Kind).To(Equal(PingdomAccountKind))
+		})
+
+		It("defaults status", func() {
+			check.Default()
+			Expect(check.Status.Status).To(Equal(Unknown))
+			Expect(check.Status.Type).To(Equal(Ping))
+		})
+	})
+
+	Describe("ValidateCreate", func() {
+		BeforeEach(func() {
+			check.Default()
+		})
+
+		It("accepts a valid check", func() {
+			Expect(check.ValidateCreate()).To(Succeed())
+		})
+
+		It("rejects an invalid spec", func() {
+			check.Spec.Host = ""
+			Expect(check.ValidateCreate()).To(MatchError(ContainSubstring("`Host`")))
+		})
+
+		It("requires port for tcp checks", func() {
+			check.Spec.Type = TCP
+			check.Spec.Port = nil
+			Expect(check.ValidateCreate()).To(MatchError(ContainSubstring("`Port` is required")))
+		})
+
+		It("rejects udp checks", func() {
+			check.Spec.Type = UDP
+			check.Spec.Port = ptrI32(53)
+			Expect(check.ValidateCreate()).To(MatchError(ContainSubstring("udp checks are not supported")))
+		})
+
+		It("rejects a non-positive sync interval", func() {
+			check.Spec.SyncInterval = &metav1.Duration{}
+			Expect(check.ValidateCreate()).To(MatchError(ContainSubstring("`SyncInterval`")))
+		})
+
+		It("accepts no credentials, which may come from CheckDefaults", func() {
+			check.Spec.CredentialsSecret.Name = ""
+			Expect(check.ValidateCreate()).To(Succeed())
+		})
+
+		It("accepts an account instead of credentials secret", func() {
+			check.Spec.CredentialsSecret.Name = ""
+			check.Spec.AccountRef = &AccountReference{
+				Kind: ClusterPingdomAccountKind, Name: "account",
+			}
+			Expect(check.ValidateCreate()).To(Succeed())
+		})
+
+		It("rejects both credentials secret and account", func() {
+			check.Spec.AccountRef = &AccountReference{
+				Kind: PingdomAccountKind, Name: "account",
+			}
+			Expect(check.ValidateCreate()).To(MatchError(ContainSubstring("at most one")))
+		})
+	})
+
+	Describe("ValidateUpdate", func() {
+		BeforeEach(func() {
+			check.Default()
+		})
+
+		It("accepts changes to mutable fields", func() {
+			old := check.DeepCopy()
+			check.Spec.Host = "other.example.com"
+			Expect(check.ValidateUpdate(old)).To(Succeed())
+		})
+
+		It("rejects a change of type", func() {
+			old := check.DeepCopy()
+			check.Spec.Type = Ping
+			check.Spec.Port = nil
+			Expect(check.ValidateUpdate(old)).To(MatchError(ContainSubstring("cannot be changed")))
+		})
+
+		It("accepts setting check ID", func() {
+			old := check.DeepCopy()
+			check.Spec.CheckID = ptrI32(1234)
+			Expect(check.ValidateUpdate(old)).To(Succeed())
+		})
+
+		It("rejects a change of check ID", func() {
+			check.Spec.CheckID = ptrI32(1234)
+			old := check.DeepCopy()
+			check.Spec.CheckID = ptrI32(4321)
+			Expect(check.ValidateUpdate(old)).To(MatchError(ContainSubstring("`CheckID` cannot be changed")))
+		})
+	})
+})
@@ -0,0 +1,110 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Kinds of defaults a Check can take parameters from
+const (
+	CheckDefaultsKind        = "CheckDefaults"
+	ClusterCheckDefaultsKind = "ClusterCheckDefaults"
+)
+
+/*
+CheckDefaultsSpec defines parameters applied to Checks which don't set them,
+either to Checks in the same namespace (CheckDefaults) or in all namespaces
+(ClusterCheckDefaults).
+*/
+type CheckDefaultsSpec struct {
+	// How often should the check be tested? (minutes)
+	// +optional
+	ResolutionMinutes *int32 `json:"resolutionMinutes,omitempty"`
+
+	// PingdomContacts in the Check's namespace which should receive alerts
+	// +optional
+	Contacts []corev1.LocalObjectReference `json:"contacts,omitempty"`
+
+	// PingdomTeams in the Check's namespace which should receive alerts
+	// +optional
+	Teams []corev1.LocalObjectReference `json:"teams,omitempty"`
+
+	// Tags of the check, besides the ownership tag the operator adds
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
+	// Secret in the Check's namespace storing Pingdom API credentials.
+	// Credentials are applied to Checks which set neither
+	// `credentialsSecret` nor `accountRef`; at most one of them should be
+	// set.
+	// +optional
+	CredentialsSecret *corev1.LocalObjectReference `json:"credentialsSecret,omitempty"`
+
+	// Pingdom account whose credentials (and defaults) to use
+	// +optional
+	AccountRef *AccountReference `json:"accountRef,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CheckDefaults is the Schema for the checkdefaults API, it holds parameters
+// applied to Checks in its namespace which don't set them
+type CheckDefaults struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CheckDefaultsSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CheckDefaultsList contains a list of CheckDefaults
+type CheckDefaultsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CheckDefaults `json:"items"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+
+// ClusterCheckDefaults is the Schema for the clustercheckdefaults API, it
+// holds parameters applied to Checks in any namespace which don't set them
+// (nor does a CheckDefaults in their namespace)
+type ClusterCheckDefaults struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CheckDefaultsSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterCheckDefaultsList contains a list of ClusterCheckDefaults
+type ClusterCheckDefaultsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterCheckDefaults `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&CheckDefaults{}, &CheckDefaultsList{},
+		&ClusterCheckDefaults{}, &ClusterCheckDefaultsList{},
+	)
+}
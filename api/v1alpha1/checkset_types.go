@@ -0,0 +1,136 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CheckSetTemplate describes the Checks a CheckSet generates
+type CheckSetTemplate struct {
+	// Labels of generated Checks
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Spec of generated Checks; `host` is replaced by each host of the
+	// CheckSet so can be set to "". `name`, if set, is prefixed to the name
+	// of each host.
+	Spec CheckSpec `json:"spec"`
+}
+
+// CheckSetHost is a host a CheckSet generates a Check for
+type CheckSetHost struct {
+	// Name of the host, the generated Check is named
+	// `<CheckSet name>-<name>`, or `<CheckSet name>-<hash>` if that's not a
+	// valid name
+	Name string `json:"name"`
+
+	// Target host
+	Host string `json:"host"`
+}
+
+/*
+HostsSource is a source of hosts of a CheckSet: ConfigMaps whose data maps
+names of hosts to the hosts. Exactly one of `configMapRef` and
+`configMapSelector` must be set.
+*/
+type HostsSource struct {
+	// ConfigMap in the same namespace
+	// +optional
+	ConfigMapRef *corev1.LocalObjectReference `json:"configMapRef,omitempty"`
+
+	// ConfigMaps in the same namespace selected by labels
+	// +optional
+	ConfigMapSelector *metav1.LabelSelector `json:"configMapSelector,omitempty"`
+}
+
+// CheckSetSpec defines the desired state of CheckSet
+type CheckSetSpec struct {
+	// Template of the Checks
+	Template CheckSetTemplate `json:"template"`
+
+	// Hosts to generate Checks for
+	// +optional
+	Hosts []CheckSetHost `json:"hosts,omitempty"`
+
+	// Sources of more hosts to generate Checks for
+	// +optional
+	HostsFrom []HostsSource `json:"hostsFrom,omitempty"`
+}
+
+// CheckSetStatus defines the observed state of CheckSet
+type CheckSetStatus struct {
+	// Number of Checks generated
+	// +optional
+	Checks int32 `json:"checks"`
+
+	// Number of generated Checks which are Ready
+	// +optional
+	ReadyChecks int32 `json:"readyChecks"`
+
+	// The generation of the spec that was last applied to the generated
+	// Checks
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current conditions of the CheckSet: Synced (generated Checks match
+	// the spec), Ready (Synced and all generated Checks are Ready)
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// SetCondition adds or updates a condition of given type on the set.
+func (cs *CheckSetStatus) SetCondition(
+	condType ConditionType,
+	status corev1.ConditionStatus,
+	reason, message string,
+) {
+	cs.Conditions = setCondition(cs.Conditions, condType, status, reason, message)
+}
+
+// GetCondition returns a condition of given type or nil if it's not set.
+func (cs *CheckSetStatus) GetCondition(condType ConditionType) *Condition {
+	return getCondition(cs.Conditions, condType)
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="checks",type=integer,JSONPath=`.status.checks`,description="Number of generated Checks"
+// +kubebuilder:printcolumn:name="ready",type=integer,JSONPath=`.status.readyChecks`,description="Number of generated Checks which are Ready"
+
+// CheckSet is the Schema for the checksets API, it generates a Check for
+// each of many hosts from a template
+type CheckSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CheckSetSpec   `json:"spec,omitempty"`
+	Status CheckSetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CheckSetList contains a list of CheckSet
+type CheckSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CheckSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CheckSet{}, &CheckSetList{})
+}
@@ -0,0 +1,118 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConditionType is a type of a condition of a resource.
+type ConditionType string
+
+// Possible values of ConditionType
+const (
+	// Ready indicates the resource is fully reconciled and in sync with
+	// Pingdom, i.e. credentials are valid and the external resource matches
+	// the spec
+	Ready ConditionType = "Ready"
+
+	// Synced indicates the external resource in Pingdom matches the spec
+	Synced ConditionType = "Synced"
+
+	// CredentialsValid indicates the Pingdom API credentials could be read and
+	// were accepted by the Pingdom API
+	CredentialsValid ConditionType = "CredentialsValid"
+
+	// Deleting indicates the resource is being deleted and the external
+	// resource is being removed from Pingdom
+	Deleting ConditionType = "Deleting"
+
+	// Accepted indicates a policy is valid and applies to its target
+	Accepted ConditionType = "Accepted"
+
+	// ChecksMatchAccount indicates all Checks a MaintenanceWindow selects use
+	// its Pingdom account; the window doesn't apply to the others
+	ChecksMatchAccount ConditionType = "ChecksMatchAccount"
+)
+
+// Condition describes the state of a resource at a certain point.
+type Condition struct {
+	// Type of the condition
+	Type ConditionType `json:"type"`
+
+	// Status of the condition, one of True, False, Unknown
+	Status corev1.ConditionStatus `json:"status"`
+
+	// Last time the condition transitioned from one status to another
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Machine readable, CamelCase reason for the last transition
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Human readable message with details about the last transition
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+/*
+setCondition adds a condition of given type to the list of conditions or
+updates it if it's already there, returning the updated list.
+
+LastTransitionTime is only updated if the status of the condition changes.
+*/
+func setCondition(
+	conditions []Condition,
+	condType ConditionType,
+	status corev1.ConditionStatus,
+	reason, message string,
+) []Condition {
+	for i := range conditions {
+		cond := &conditions[i]
+		if cond.Type != condType {
+			continue
+		}
+		if cond.Status != status {
+			cond.LastTransitionTime = metav1.Now()
+		}
+		cond.Status = status
+		cond.Reason = reason
+		cond.Message = message
+		return conditions
+	}
+	return append(conditions, Condition{
+		Type:               condType,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+/*
+getCondition returns the condition of given type from the list of conditions
+or nil if it's not there.
+*/
+func getCondition(conditions []Condition, condType ConditionType) *Condition {
+	for i := range conditions {
+		if conditions[i].Type == condType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+)
+
+var _ = Describe("Conditions", func() {
+	var status CheckStatus
+
+	BeforeEach(func() {
+		status = CheckStatus{}
+	})
+
+	It("returns nil for a condition that's not set", func() {
+		Expect(status.GetCondition(Ready)).To(BeNil())
+		Expect(status.IsConditionTrue(Ready)).To(BeFalse())
+	})
+
+	It("adds a new condition", func() {
+		status.SetCondition(Synced, corev1.ConditionTrue, "Created", "created")
+
+		Expect(status.Conditions).To(HaveLen(1))
+		cond := status.GetCondition(Synced)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(corev1.ConditionTrue))
+		Expect(cond.Reason).To(Equal("Created"))
+		Expect(cond.Message).To(Equal("created"))
+		Expect(cond.LastTransitionTime.IsZero()).To(BeFalse())
+		Expect(status.IsConditionTrue(Synced)).To(BeTrue())
+	})
+
+	It("updates an existing condition in place", func() {
+		status.SetCondition(Synced, corev1.ConditionTrue, "Created", "")
+		status.SetCondition(Ready, corev1.ConditionTrue, "Synced", "")
+		status.SetCondition(Synced, corev1.ConditionFalse, "UpdateFailed", "boom")
+
+		Expect(status.Conditions).To(HaveLen(2))
+		Expect(status.GetCondition(Synced).Reason).To(Equal("UpdateFailed"))
+		Expect(status.IsConditionTrue(Synced)).To(BeFalse())
+	})
+
+	It("only changes transition time when status changes", func() {
+		past := metav1.Unix(1000, 0)
+		status.Conditions = []Condition{{
+			Type: Synced, Status: corev1.ConditionTrue, LastTransitionTime: past,
+		}}
+
+		status.SetCondition(Synced, corev1.ConditionTrue, "UpToDate", "")
+		Expect(status.GetCondition(Synced).LastTransitionTime).To(Equal(past))
+
+		status.SetCondition(Synced, corev1.ConditionFalse, "UpdateFailed", "")
+		Expect(status.GetCondition(Synced).LastTransitionTime).NotTo(Equal(past))
+	})
+})
@@ -0,0 +1,176 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RecurrenceType is how often a maintenance window repeats
+// +kubebuilder:validation:Enum=day;week;month
+type RecurrenceType string
+
+// Types of maintenance window recurrence
+const (
+	RecurrenceDaily   RecurrenceType = "day"
+	RecurrenceWeekly  RecurrenceType = "week"
+	RecurrenceMonthly RecurrenceType = "month"
+)
+
+// Recurrence defines how a maintenance window repeats
+type Recurrence struct {
+	// How often the window repeats, one of: day, week, month
+	Type RecurrenceType `json:"type"`
+
+	// Number of days, weeks or months between repetitions; defaults to 1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RepeatEvery *int32 `json:"repeatEvery,omitempty"`
+
+	// Time after which the window no longer repeats
+	Until metav1.Time `json:"until"`
+}
+
+// MaintenanceWindowSpec defines the desired state of MaintenanceWindow
+type MaintenanceWindowSpec struct {
+	// Description of the maintenance window; defaults to the namespace and
+	// name of the MaintenanceWindow
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Start of the (first) window
+	Start metav1.Time `json:"start"`
+
+	// End of the (first) window
+	End metav1.Time `json:"end"`
+
+	// Repeat the window; it only happens once if not set
+	// +optional
+	Recurrence *Recurrence `json:"recurrence,omitempty"`
+
+	// Checks in the same namespace to which the window applies, selected by
+	// labels; combined with `checks`
+	// +optional
+	CheckSelector *metav1.LabelSelector `json:"checkSelector,omitempty"`
+
+	// Checks in the same namespace to which the window applies, by name;
+	// combined with `checkSelector`
+	// +optional
+	Checks []corev1.LocalObjectReference `json:"checks,omitempty"`
+
+	// Secret storing Pingdom API credentials; selected Checks which use
+	// different credentials are skipped (see the ChecksMatchAccount
+	// condition).
+	// Exactly one of `credentialsSecret` and `accountRef` must be set.
+	// +optional
+	CredentialsSecret corev1.LocalObjectReference `json:"credentialsSecret,omitempty"`
+
+	// Pingdom account whose credentials to use.
+	// Exactly one of `credentialsSecret` and `accountRef` must be set.
+	// +optional
+	AccountRef *AccountReference `json:"accountRef,omitempty"`
+}
+
+// MaintenanceWindowStatus defines the observed state of MaintenanceWindow
+type MaintenanceWindowStatus struct {
+	// Maintenance window identifier in Pingdom
+	// +optional
+	ID int32 `json:"id,omitempty"`
+
+	// Identifiers of Pingdom checks the window applies to, of the selected
+	// Checks which have been created in Pingdom
+	// +optional
+	CheckIDs []int32 `json:"checkIDs,omitempty"`
+
+	// The generation of the spec that was last successfully applied to the
+	// Pingdom maintenance window
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current conditions of the MaintenanceWindow, at most one of each type:
+	// Ready, Synced, CredentialsValid, ChecksMatchAccount, Deleting (only
+	// set when deleting)
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// SetCondition adds or updates a condition of given type on the
+// MaintenanceWindow.
+func (ms *MaintenanceWindowStatus) SetCondition(
+	condType ConditionType,
+	status corev1.ConditionStatus,
+	reason, message string,
+) {
+	ms.Conditions = setCondition(ms.Conditions, condType, status, reason, message)
+}
+
+// GetCondition returns a condition of given type or nil if it's not set.
+func (ms *MaintenanceWindowStatus) GetCondition(condType ConditionType) *Condition {
+	return getCondition(ms.Conditions, condType)
+}
+
+/*
+Valid determines whether the MaintenanceWindowSpec is valid: the window ends
+after it starts, stops repeating after the first window ends and exactly one
+source of credentials is set.
+*/
+func (ms *MaintenanceWindowSpec) Valid() error {
+	if !ms.Start.Before(&ms.End) {
+		return fmt.Errorf("invalid value for `End`, must be after `Start`")
+	}
+	if ms.Recurrence != nil && !ms.End.Before(&ms.Recurrence.Until) {
+		return fmt.Errorf("invalid value for `Recurrence.Until`, must be after `End`")
+	}
+	if (ms.CredentialsSecret.Name == "") == (ms.AccountRef == nil) {
+		return fmt.Errorf(
+			"exactly one of `CredentialsSecret` and `AccountRef` must be set",
+		)
+	}
+	return nil
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="ID",type=string,JSONPath=`.status.id`,description="Maintenance window ID"
+// +kubebuilder:printcolumn:name="start",type=string,format=date-time,JSONPath=`.spec.start`,description="Start of the (first) window"
+// +kubebuilder:printcolumn:name="end",type=string,format=date-time,JSONPath=`.spec.end`,description="End of the (first) window"
+// +kubebuilder:printcolumn:name="ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Window is in sync with Pingdom"
+
+// MaintenanceWindow is the Schema for the maintenancewindows API, it
+// schedules a Pingdom maintenance window for a set of Checks
+type MaintenanceWindow struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MaintenanceWindowSpec   `json:"spec,omitempty"`
+	Status MaintenanceWindowStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MaintenanceWindowList contains a list of MaintenanceWindow
+type MaintenanceWindowList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MaintenanceWindow `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MaintenanceWindow{}, &MaintenanceWindowList{})
+}
@@ -0,0 +1,59 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+)
+
+var _ = Describe("MaintenanceWindowSpec", func() {
+	var spec *MaintenanceWindowSpec
+	start := time.Date(2020, 1, 1, 2, 0, 0, 0, time.UTC)
+
+	BeforeEach(func() {
+		spec = &MaintenanceWindowSpec{
+			Start:             metav1.NewTime(start),
+			End:               metav1.NewTime(start.Add(time.Hour)),
+			CredentialsSecret: corev1.LocalObjectReference{Name: "creds"},
+		}
+	})
+
+	It("accepts a valid spec", func() {
+		Expect(spec.Valid()).To(Succeed())
+	})
+
+	It("rejects a window ending before it starts", func() {
+		spec.End = metav1.NewTime(start.Add(-time.Hour))
+		Expect(spec.Valid()).To(MatchError(ContainSubstring("`End`")))
+	})
+
+	It("rejects a recurrence ending before the window", func() {
+		spec.Recurrence = &Recurrence{Type: RecurrenceDaily, Until: metav1.NewTime(start)}
+		Expect(spec.Valid()).To(MatchError(ContainSubstring("`Recurrence.Until`")))
+	})
+
+	It("requires exactly one source of credentials", func() {
+		spec.AccountRef = &AccountReference{Name: "account"}
+		Expect(spec.Valid()).To(MatchError(ContainSubstring("exactly one")))
+	})
+})
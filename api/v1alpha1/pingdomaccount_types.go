@@ -0,0 +1,152 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Kinds of accounts a Check can reference in its AccountReference
+const (
+	PingdomAccountKind        = "PingdomAccount"
+	ClusterPingdomAccountKind = "ClusterPingdomAccount"
+)
+
+// AccountDefaults are check parameters applied to Checks using an account
+// which don't set them explicitly
+type AccountDefaults struct {
+	// How often should the check be tested? (minutes)
+	// +optional
+	ResolutionMinutes *int32 `json:"resolutionMinutes,omitempty"`
+
+	// User identifiers of users who should receive alerts
+	// +optional
+	UserIds *[]int `json:"userids,omitempty"`
+}
+
+// PingdomAccountSpec defines the desired state of PingdomAccount and
+// ClusterPingdomAccount
+type PingdomAccountSpec struct {
+	// Secret storing Pingdom API credentials, in the same format as the
+	// `credentialsSecret` of a Check.
+	// For a PingdomAccount the Secret must be in the account's namespace and
+	// `namespace` can be omitted, for a ClusterPingdomAccount it's required.
+	SecretRef corev1.SecretReference `json:"secretRef"`
+
+	// Version of Pingdom API to use, one of: 2.1, 3.1.
+	// Defaults to `apiVersion` in the Secret or, if that's not set either, to
+	// 3.1 if the Secret contains an `apiToken` and 2.1 otherwise.
+	// +kubebuilder:validation:Enum="2.1";"3.1"
+	// +optional
+	APIVersion *string `json:"apiVersion,omitempty"`
+
+	// Pingdom application key used with API 2.1, unless the Secret contains
+	// an `appKey`; defaults to the operator-wide application key
+	// +optional
+	AppKey *string `json:"appKey,omitempty"`
+
+	// Parameters applied to Checks using this account which don't set them
+	// +optional
+	Defaults *AccountDefaults `json:"defaults,omitempty"`
+}
+
+// PingdomAccountStatus defines the observed state of PingdomAccount and
+// ClusterPingdomAccount
+type PingdomAccountStatus struct {
+	// Version of Pingdom API in use
+	// +optional
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// The generation of the account spec that was last validated
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current conditions of the account, at most one of each type:
+	// Ready, CredentialsValid
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// SetCondition adds or updates a condition of given type on the account.
+func (as *PingdomAccountStatus) SetCondition(
+	condType ConditionType,
+	status corev1.ConditionStatus,
+	reason, message string,
+) {
+	as.Conditions = setCondition(as.Conditions, condType, status, reason, message)
+}
+
+// GetCondition returns a condition of given type or nil if it's not set.
+func (as *PingdomAccountStatus) GetCondition(condType ConditionType) *Condition {
+	return getCondition(as.Conditions, condType)
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="API",type=string,JSONPath=`.status.apiVersion`,description="Pingdom API version"
+// +kubebuilder:printcolumn:name="ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Credentials are accepted by Pingdom"
+
+// PingdomAccount is the Schema for the pingdomaccounts API, it holds Pingdom
+// API credentials for Checks in its namespace
+type PingdomAccount struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PingdomAccountSpec   `json:"spec,omitempty"`
+	Status PingdomAccountStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PingdomAccountList contains a list of PingdomAccount
+type PingdomAccountList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PingdomAccount `json:"items"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="API",type=string,JSONPath=`.status.apiVersion`,description="Pingdom API version"
+// +kubebuilder:printcolumn:name="ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Credentials are accepted by Pingdom"
+
+// ClusterPingdomAccount is the Schema for the clusterpingdomaccounts API, it
+// holds Pingdom API credentials for Checks in any namespace
+type ClusterPingdomAccount struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PingdomAccountSpec   `json:"spec,omitempty"`
+	Status PingdomAccountStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterPingdomAccountList contains a list of ClusterPingdomAccount
+type ClusterPingdomAccountList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterPingdomAccount `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&PingdomAccount{}, &PingdomAccountList{},
+		&ClusterPingdomAccount{}, &ClusterPingdomAccountList{},
+	)
+}
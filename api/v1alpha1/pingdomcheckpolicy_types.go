@@ -0,0 +1,131 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GatewayAPIGroup is the API group of Gateway API resources
+const GatewayAPIGroup = "gateway.networking.k8s.io"
+
+// PolicyTargetReference identifies the object a policy applies to, in the
+// namespace of the policy
+type PolicyTargetReference struct {
+	// API group of the target; defaults to gateway.networking.k8s.io
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Kind of the target, only HTTPRoute is supported
+	// +kubebuilder:validation:Enum=HTTPRoute
+	Kind string `json:"kind"`
+
+	// Name of the target
+	Name string `json:"name"`
+}
+
+// PingdomCheckPolicySpec defines the desired state of PingdomCheckPolicy
+type PingdomCheckPolicySpec struct {
+	// The HTTPRoute Checks are generated from
+	TargetRef PolicyTargetReference `json:"targetRef"`
+
+	// How often should the check be tested? (minutes)
+	// +optional
+	ResolutionMinutes *int32 `json:"resolutionMinutes,omitempty"`
+
+	// PingdomContacts which should receive alerts of generated Checks
+	// +optional
+	Contacts []corev1.LocalObjectReference `json:"contacts,omitempty"`
+
+	// PingdomTeams which should receive alerts of generated Checks
+	// +optional
+	Teams []corev1.LocalObjectReference `json:"teams,omitempty"`
+
+	// Target site should contain this string
+	// +optional
+	ShouldContain *string `json:"shouldContain,omitempty"`
+
+	// Target site should NOT contain this string
+	// +optional
+	ShouldNotContain *string `json:"shouldNotContain,omitempty"`
+
+	// Secret storing Pingdom API credentials of generated Checks.
+	// At most one of `credentialsSecret` and `accountRef` may be set, if
+	// neither is, credentials are taken from CheckDefaults.
+	// +optional
+	CredentialsSecret corev1.LocalObjectReference `json:"credentialsSecret,omitempty"`
+
+	// Pingdom account whose credentials generated Checks use.
+	// At most one of `credentialsSecret` and `accountRef` may be set, if
+	// neither is, credentials are taken from CheckDefaults.
+	// +optional
+	AccountRef *AccountReference `json:"accountRef,omitempty"`
+}
+
+// PingdomCheckPolicyStatus defines the observed state of PingdomCheckPolicy
+type PingdomCheckPolicyStatus struct {
+	// The generation of the spec that was last applied to the target
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current conditions of the PingdomCheckPolicy: Accepted
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// SetCondition adds or updates a condition of given type on the policy.
+func (ps *PingdomCheckPolicyStatus) SetCondition(
+	condType ConditionType,
+	status corev1.ConditionStatus,
+	reason, message string,
+) {
+	ps.Conditions = setCondition(ps.Conditions, condType, status, reason, message)
+}
+
+// GetCondition returns a condition of given type or nil if it's not set.
+func (ps *PingdomCheckPolicyStatus) GetCondition(condType ConditionType) *Condition {
+	return getCondition(ps.Conditions, condType)
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="target",type=string,JSONPath=`.spec.targetRef.name`,description="Name of the target HTTPRoute"
+// +kubebuilder:printcolumn:name="accepted",type=string,JSONPath=`.status.conditions[?(@.type=="Accepted")].status`,description="Policy applies to the target"
+
+// PingdomCheckPolicy is the Schema for the pingdomcheckpolicies API, it
+// generates Checks from a Gateway API HTTPRoute, in the style of Gateway API
+// policy attachment
+type PingdomCheckPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PingdomCheckPolicySpec   `json:"spec,omitempty"`
+	Status PingdomCheckPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PingdomCheckPolicyList contains a list of PingdomCheckPolicy
+type PingdomCheckPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PingdomCheckPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PingdomCheckPolicy{}, &PingdomCheckPolicyList{})
+}
@@ -0,0 +1,149 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NotificationSeverity is the severity of alerts a notification target
+// receives
+// +kubebuilder:validation:Enum=HIGH;LOW
+type NotificationSeverity string
+
+// Notification severities
+const (
+	SeverityHigh NotificationSeverity = "HIGH"
+	SeverityLow  NotificationSeverity = "LOW"
+)
+
+// EmailTarget is an email address a contact is notified at
+type EmailTarget struct {
+	// Email address
+	Address string `json:"address"`
+
+	// Severity of alerts sent to the address, HIGH or LOW; defaults to HIGH
+	// +optional
+	Severity *NotificationSeverity `json:"severity,omitempty"`
+}
+
+// SMSTarget is a phone number a contact is notified at with text messages
+type SMSTarget struct {
+	// Phone number, without the country code
+	Number string `json:"number"`
+
+	// Country code of the phone number, e.g. "44"
+	CountryCode string `json:"countryCode"`
+
+	// SMS provider, one of the providers supported by Pingdom; defaults to
+	// Pingdom's default provider
+	// +optional
+	Provider *string `json:"provider,omitempty"`
+
+	// Severity of alerts sent to the number, HIGH or LOW; defaults to HIGH
+	// +optional
+	Severity *NotificationSeverity `json:"severity,omitempty"`
+}
+
+// PingdomContactSpec defines the desired state of PingdomContact
+type PingdomContactSpec struct {
+	// Name of the contact in Pingdom; defaults to the name of the object
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// Pause notifications to the contact
+	// +optional
+	Paused *bool `json:"paused,omitempty"`
+
+	// Email addresses the contact is notified at
+	// +optional
+	Email []EmailTarget `json:"email,omitempty"`
+
+	// Phone numbers the contact is notified at
+	// +optional
+	SMS []SMSTarget `json:"sms,omitempty"`
+
+	// Secret storing Pingdom API credentials, Checks alerting the contact
+	// must use the same account.
+	// Exactly one of `credentialsSecret` and `accountRef` must be set.
+	// +optional
+	CredentialsSecret corev1.LocalObjectReference `json:"credentialsSecret,omitempty"`
+
+	// Pingdom account whose credentials to use.
+	// Exactly one of `credentialsSecret` and `accountRef` must be set.
+	// +optional
+	AccountRef *AccountReference `json:"accountRef,omitempty"`
+}
+
+// PingdomContactStatus defines the observed state of PingdomContact
+type PingdomContactStatus struct {
+	// Alerting contact identifier in Pingdom
+	// +optional
+	ID int32 `json:"id,omitempty"`
+
+	// The generation of the spec that was last successfully applied to the
+	// Pingdom contact
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current conditions of the PingdomContact, at most one of each type:
+	// Ready, Synced, CredentialsValid, Deleting (only set when deleting)
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// SetCondition adds or updates a condition of given type on the contact.
+func (cs *PingdomContactStatus) SetCondition(
+	condType ConditionType,
+	status corev1.ConditionStatus,
+	reason, message string,
+) {
+	cs.Conditions = setCondition(cs.Conditions, condType, status, reason, message)
+}
+
+// GetCondition returns a condition of given type or nil if it's not set.
+func (cs *PingdomContactStatus) GetCondition(condType ConditionType) *Condition {
+	return getCondition(cs.Conditions, condType)
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="ID",type=string,JSONPath=`.status.id`,description="Alerting contact ID"
+// +kubebuilder:printcolumn:name="ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Contact is in sync with Pingdom"
+
+// PingdomContact is the Schema for the pingdomcontacts API, it maintains a
+// Pingdom alerting contact which Checks can alert by name
+type PingdomContact struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PingdomContactSpec   `json:"spec,omitempty"`
+	Status PingdomContactStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PingdomContactList contains a list of PingdomContact
+type PingdomContactList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PingdomContact `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PingdomContact{}, &PingdomContactList{})
+}
@@ -0,0 +1,106 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PingdomTeamSpec defines the desired state of PingdomTeam
+type PingdomTeamSpec struct {
+	// Name of the team in Pingdom; defaults to the name of the object
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// PingdomContacts in the same namespace which are members of the team
+	// +optional
+	Members []corev1.LocalObjectReference `json:"members,omitempty"`
+
+	// Secret storing Pingdom API credentials, the members and Checks
+	// alerting the team must use the same account.
+	// Exactly one of `credentialsSecret` and `accountRef` must be set.
+	// +optional
+	CredentialsSecret corev1.LocalObjectReference `json:"credentialsSecret,omitempty"`
+
+	// Pingdom account whose credentials to use.
+	// Exactly one of `credentialsSecret` and `accountRef` must be set.
+	// +optional
+	AccountRef *AccountReference `json:"accountRef,omitempty"`
+}
+
+// PingdomTeamStatus defines the observed state of PingdomTeam
+type PingdomTeamStatus struct {
+	// Alerting team identifier in Pingdom
+	// +optional
+	ID int32 `json:"id,omitempty"`
+
+	// Identifiers of Pingdom contacts of the members of the team
+	// +optional
+	MemberIDs []int32 `json:"memberIDs,omitempty"`
+
+	// The generation of the spec that was last successfully applied to the
+	// Pingdom team
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current conditions of the PingdomTeam, at most one of each type:
+	// Ready, Synced, CredentialsValid, Deleting (only set when deleting)
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// SetCondition adds or updates a condition of given type on the team.
+func (ts *PingdomTeamStatus) SetCondition(
+	condType ConditionType,
+	status corev1.ConditionStatus,
+	reason, message string,
+) {
+	ts.Conditions = setCondition(ts.Conditions, condType, status, reason, message)
+}
+
+// GetCondition returns a condition of given type or nil if it's not set.
+func (ts *PingdomTeamStatus) GetCondition(condType ConditionType) *Condition {
+	return getCondition(ts.Conditions, condType)
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="ID",type=string,JSONPath=`.status.id`,description="Alerting team ID"
+// +kubebuilder:printcolumn:name="ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Team is in sync with Pingdom"
+
+// PingdomTeam is the Schema for the pingdomteams API, it maintains a Pingdom
+// alerting team which Checks can alert by name
+type PingdomTeam struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PingdomTeamSpec   `json:"spec,omitempty"`
+	Status PingdomTeamStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PingdomTeamList contains a list of PingdomTeam
+type PingdomTeamList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PingdomTeam `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PingdomTeam{}, &PingdomTeamList{})
+}
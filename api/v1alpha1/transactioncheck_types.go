@@ -0,0 +1,280 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TransactionAction is the action of a step of a transaction check
+// +kubebuilder:validation:Enum=go_to;click;fill;assert
+type TransactionAction string
+
+// Actions of steps of transaction checks
+const (
+	// TransactionGoTo navigates to `url`
+	TransactionGoTo TransactionAction = "go_to"
+
+	// TransactionClick clicks `element`
+	TransactionClick TransactionAction = "click"
+
+	// TransactionFill fills `element` (an input) with `value`
+	TransactionFill TransactionAction = "fill"
+
+	// TransactionAssert asserts `assertion` holds for `element`
+	TransactionAssert TransactionAction = "assert"
+)
+
+// TransactionAssertion is what a step of a transaction check asserts about
+// an element
+// +kubebuilder:validation:Enum=exists;not_exists;contains_text;not_contains_text
+type TransactionAssertion string
+
+// Assertions of steps of transaction checks
+const (
+	AssertExists          TransactionAssertion = "exists"
+	AssertNotExists       TransactionAssertion = "not_exists"
+	AssertContainsText    TransactionAssertion = "contains_text"
+	AssertNotContainsText TransactionAssertion = "not_contains_text"
+)
+
+// TransactionStep is a step of the script of a transaction check
+type TransactionStep struct {
+	// Action of the step, one of: go_to, click, fill, assert
+	Action TransactionAction `json:"action"`
+
+	// URL to navigate to, required for go_to
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// CSS selector of the element to click, fill or assert about, required
+	// for click, fill and assert
+	// +optional
+	Element string `json:"element,omitempty"`
+
+	// Value to fill the element with (fill) or text the element should (not)
+	// contain (assert with contains_text, not_contains_text)
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// What to assert about the element, one of: exists, not_exists,
+	// contains_text, not_contains_text. Defaults to contains_text if `value`
+	// is set and exists otherwise.
+	// +optional
+	Assertion *TransactionAssertion `json:"assertion,omitempty"`
+}
+
+// TransactionCheckSpec defines the desired state of TransactionCheck
+type TransactionCheckSpec struct {
+	// Name of the transaction check in Pingdom; defaults to the name of the
+	// object
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// Steps of the script, run in order
+	// +kubebuilder:validation:MinItems=1
+	Steps []TransactionStep `json:"steps"`
+
+	// How often should the check be tested? (minutes), one of: 5, 10, 20,
+	// 60, 720, 1440. Defaults to 10.
+	// +kubebuilder:validation:Enum=5;10;20;60;720;1440
+	// +optional
+	IntervalMinutes *int32 `json:"intervalMinutes,omitempty"`
+
+	// Region the check is run from, one of: us-east, us-west, eu, au.
+	// Defaults to us-east.
+	// +kubebuilder:validation:Enum=us-east;us-west;eu;au
+	// +optional
+	Region *string `json:"region,omitempty"`
+
+	// Paused; defaults to false
+	// +optional
+	Paused *bool `json:"paused,omitempty"`
+
+	// PingdomContacts in the same namespace which should receive alerts
+	// +optional
+	Contacts []corev1.LocalObjectReference `json:"contacts,omitempty"`
+
+	// PingdomTeams in the same namespace which should receive alerts
+	// +optional
+	Teams []corev1.LocalObjectReference `json:"teams,omitempty"`
+
+	// Secret storing Pingdom API credentials, which must be for API 3.1.
+	// Exactly one of `credentialsSecret` and `accountRef` must be set.
+	// +optional
+	CredentialsSecret corev1.LocalObjectReference `json:"credentialsSecret,omitempty"`
+
+	// Pingdom account whose credentials to use, which must be for API 3.1.
+	// Exactly one of `credentialsSecret` and `accountRef` must be set.
+	// +optional
+	AccountRef *AccountReference `json:"accountRef,omitempty"`
+
+	// What to do with the Pingdom transaction check when this object is
+	// deleted; one of: Delete, Retain, Pause. Defaults to Delete.
+	// +optional
+	DeletionPolicy *DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// TransactionCheckStatus defines the observed state of TransactionCheck
+type TransactionCheckStatus struct {
+	// Transaction check identifier in Pingdom
+	// +optional
+	ID int32 `json:"id,omitempty"`
+
+	// Name of the check in Pingdom
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Whether the check is active, i.e. not paused
+	// +optional
+	Active bool `json:"active,omitempty"`
+
+	// Current status of the check as reported by Pingdom, e.g.
+	// successful, failing, unknown
+	// +optional
+	Status string `json:"status,omitempty"`
+
+	// How often the check is tested (minutes)
+	// +optional
+	IntervalMinutes int32 `json:"intervalMinutes,omitempty"`
+
+	// Region the check is run from
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Identifiers of contacts and teams receiving alerts
+	// +optional
+	ContactIDs []int32 `json:"contactIDs,omitempty"`
+	// +optional
+	TeamIDs []int32 `json:"teamIDs,omitempty"`
+
+	// Start and end of the last downtime (if any)
+	// +optional
+	LastDowntimeStart *metav1.Time `json:"lastDowntimeStart,omitempty"`
+	// +optional
+	LastDowntimeEnd *metav1.Time `json:"lastDowntimeEnd,omitempty"`
+
+	// Check creation and last modification time in Pingdom
+	// +optional
+	CreatedTime *metav1.Time `json:"created,omitempty"`
+	// +optional
+	ModifiedTime *metav1.Time `json:"modified,omitempty"`
+
+	// The generation of the spec that was last successfully applied to the
+	// Pingdom transaction check
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current conditions of the TransactionCheck, at most one of each type:
+	// Ready, Synced, CredentialsValid, Deleting (only set when deleting)
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// SetCondition adds or updates a condition of given type on the
+// TransactionCheck.
+func (ts *TransactionCheckStatus) SetCondition(
+	condType ConditionType,
+	status corev1.ConditionStatus,
+	reason, message string,
+) {
+	ts.Conditions = setCondition(ts.Conditions, condType, status, reason, message)
+}
+
+// GetCondition returns a condition of given type or nil if it's not set.
+func (ts *TransactionCheckStatus) GetCondition(condType ConditionType) *Condition {
+	return getCondition(ts.Conditions, condType)
+}
+
+// AssertionOrDefault returns the assertion of an assert step, defaulted
+// based on whether the step has a value.
+func (s *TransactionStep) AssertionOrDefault() TransactionAssertion {
+	switch {
+	case s.Assertion != nil:
+		return *s.Assertion
+	case s.Value != "":
+		return AssertContainsText
+	default:
+		return AssertExists
+	}
+}
+
+/*
+Valid determines whether the TransactionCheckSpec is valid: each step sets
+the fields its action requires and exactly one source of credentials is set.
+*/
+func (ts *TransactionCheckSpec) Valid() error {
+	if len(ts.Steps) == 0 {
+		return fmt.Errorf("at least one step is required")
+	}
+	for i, step := range ts.Steps {
+		switch step.Action {
+		case TransactionGoTo:
+			if step.URL == "" {
+				return fmt.Errorf("step %d: `URL` is required for %s", i+1, step.Action)
+			}
+		case TransactionClick, TransactionFill, TransactionAssert:
+			if step.Element == "" {
+				return fmt.Errorf("step %d: `Element` is required for %s", i+1, step.Action)
+			}
+		default:
+			return fmt.Errorf("step %d: unknown action %q", i+1, step.Action)
+		}
+		assertion := step.AssertionOrDefault()
+		if step.Action == TransactionAssert && step.Value == "" &&
+			(assertion == AssertContainsText || assertion == AssertNotContainsText) {
+			return fmt.Errorf("step %d: `Value` is required for %s", i+1, assertion)
+		}
+	}
+	if (ts.CredentialsSecret.Name == "") == (ts.AccountRef == nil) {
+		return fmt.Errorf(
+			"exactly one of `CredentialsSecret` and `AccountRef` must be set",
+		)
+	}
+	return nil
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="ID",type=string,JSONPath=`.status.id`,description="Transaction check ID"
+// +kubebuilder:printcolumn:name="status",type=string,JSONPath=`.status.status`,description="Transaction check status"
+// +kubebuilder:printcolumn:name="ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Check is in sync with Pingdom"
+
+// TransactionCheck is the Schema for the transactionchecks API, it maintains
+// a Pingdom transaction check (TMS) running a multi-step browser script
+type TransactionCheck struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TransactionCheckSpec   `json:"spec,omitempty"`
+	Status TransactionCheckStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TransactionCheckList contains a list of TransactionCheck
+type TransactionCheckList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TransactionCheck `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TransactionCheck{}, &TransactionCheckList{})
+}
@@ -20,9 +20,55 @@ limitations under the License.
 package v1alpha1
 
 import (
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountDefaults) DeepCopyInto(out *AccountDefaults) {
+	*out = *in
+	if in.ResolutionMinutes != nil {
+		in, out := &in.ResolutionMinutes, &out.ResolutionMinutes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.UserIds != nil {
+		in, out := &in.UserIds, &out.UserIds
+		*out = new([]int)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]int, len(*in))
+			copy(*out, *in)
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountDefaults.
+func (in *AccountDefaults) DeepCopy() *AccountDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountReference) DeepCopyInto(out *AccountReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountReference.
+func (in *AccountReference) DeepCopy() *AccountReference {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Check) DeepCopyInto(out *Check) {
 	*out = *in
@@ -50,6 +96,109 @@ func (in *Check) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckDefaults) DeepCopyInto(out *CheckDefaults) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckDefaults.
+func (in *CheckDefaults) DeepCopy() *CheckDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CheckDefaults) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckDefaultsList) DeepCopyInto(out *CheckDefaultsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CheckDefaults, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckDefaultsList.
+func (in *CheckDefaultsList) DeepCopy() *CheckDefaultsList {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckDefaultsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CheckDefaultsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckDefaultsSpec) DeepCopyInto(out *CheckDefaultsSpec) {
+	*out = *in
+	if in.ResolutionMinutes != nil {
+		in, out := &in.ResolutionMinutes, &out.ResolutionMinutes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Contacts != nil {
+		in, out := &in.Contacts, &out.Contacts
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CredentialsSecret != nil {
+		in, out := &in.CredentialsSecret, &out.CredentialsSecret
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.AccountRef != nil {
+		in, out := &in.AccountRef, &out.AccountRef
+		*out = new(AccountReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckDefaultsSpec.
+func (in *CheckDefaultsSpec) DeepCopy() *CheckDefaultsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckDefaultsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CheckList) DeepCopyInto(out *CheckList) {
 	*out = *in
@@ -109,6 +258,20 @@ func (in *CheckParameters) DeepCopyInto(out *CheckParameters) {
 			copy(*out, *in)
 		}
 	}
+	if in.TeamIds != nil {
+		in, out := &in.TeamIds, &out.TeamIds
+		*out = new([]int)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]int, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.URL != nil {
 		in, out := &in.URL, &out.URL
 		*out = new(string)
@@ -119,6 +282,28 @@ func (in *CheckParameters) DeepCopyInto(out *CheckParameters) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ShouldContain != nil {
+		in, out := &in.ShouldContain, &out.ShouldContain
+		*out = new(string)
+		**out = **in
+	}
+	if in.ShouldNotContain != nil {
+		in, out := &in.ShouldNotContain, &out.ShouldNotContain
+		*out = new(string)
+		**out = **in
+	}
+	if in.PostData != nil {
+		in, out := &in.PostData, &out.PostData
+		*out = new(string)
+		**out = **in
+	}
+	if in.RequestHeaders != nil {
+		in, out := &in.RequestHeaders, &out.RequestHeaders
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckParameters.
@@ -131,6 +316,153 @@ func (in *CheckParameters) DeepCopy() *CheckParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckSet) DeepCopyInto(out *CheckSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckSet.
+func (in *CheckSet) DeepCopy() *CheckSet {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CheckSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckSetHost) DeepCopyInto(out *CheckSetHost) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckSetHost.
+func (in *CheckSetHost) DeepCopy() *CheckSetHost {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckSetHost)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckSetList) DeepCopyInto(out *CheckSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CheckSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckSetList.
+func (in *CheckSetList) DeepCopy() *CheckSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CheckSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckSetSpec) DeepCopyInto(out *CheckSetSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]CheckSetHost, len(*in))
+		copy(*out, *in)
+	}
+	if in.HostsFrom != nil {
+		in, out := &in.HostsFrom, &out.HostsFrom
+		*out = make([]HostsSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckSetSpec.
+func (in *CheckSetSpec) DeepCopy() *CheckSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckSetStatus) DeepCopyInto(out *CheckSetStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckSetStatus.
+func (in *CheckSetStatus) DeepCopy() *CheckSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckSetTemplate) DeepCopyInto(out *CheckSetTemplate) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckSetTemplate.
+func (in *CheckSetTemplate) DeepCopy() *CheckSetTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckSetTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CheckSpec) DeepCopyInto(out *CheckSpec) {
 	*out = *in
@@ -141,6 +473,46 @@ func (in *CheckSpec) DeepCopyInto(out *CheckSpec) {
 		**out = **in
 	}
 	out.CredentialsSecret = in.CredentialsSecret
+	if in.Contacts != nil {
+		in, out := &in.Contacts, &out.Contacts
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.AccountRef != nil {
+		in, out := &in.AccountRef, &out.AccountRef
+		*out = new(AccountReference)
+		**out = **in
+	}
+	if in.CheckID != nil {
+		in, out := &in.CheckID, &out.CheckID
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AdoptionPolicy != nil {
+		in, out := &in.AdoptionPolicy, &out.AdoptionPolicy
+		*out = new(AdoptionPolicy)
+		**out = **in
+	}
+	if in.DeletionPolicy != nil {
+		in, out := &in.DeletionPolicy, &out.DeletionPolicy
+		*out = new(DeletionPolicy)
+		**out = **in
+	}
+	if in.SyncInterval != nil {
+		in, out := &in.SyncInterval, &out.SyncInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(HTTPAuth)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckSpec.
@@ -171,6 +543,30 @@ func (in *CheckStatus) DeepCopyInto(out *CheckStatus) {
 		**out = **in
 	}
 	in.CreatedTime.DeepCopyInto(&out.CreatedTime)
+	if in.Summary != nil {
+		in, out := &in.Summary, &out.Summary
+		*out = new(CheckSummary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequestHeaderChecksums != nil {
+		in, out := &in.RequestHeaderChecksums, &out.RequestHeaderChecksums
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DefaultedFields != nil {
+		in, out := &in.DefaultedFields, &out.DefaultedFields
+		*out = make([]DefaultedField, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckStatus.
@@ -182,3 +578,1146 @@ func (in *CheckStatus) DeepCopy() *CheckStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckSummary) DeepCopyInto(out *CheckSummary) {
+	*out = *in
+	if in.Uptime24h != nil {
+		in, out := &in.Uptime24h, &out.Uptime24h
+		*out = new(string)
+		**out = **in
+	}
+	if in.Uptime7d != nil {
+		in, out := &in.Uptime7d, &out.Uptime7d
+		*out = new(string)
+		**out = **in
+	}
+	if in.Uptime30d != nil {
+		in, out := &in.Uptime30d, &out.Uptime30d
+		*out = new(string)
+		**out = **in
+	}
+	if in.AvgResponseTimeMilis != nil {
+		in, out := &in.AvgResponseTimeMilis, &out.AvgResponseTimeMilis
+		*out = new(int64)
+		**out = **in
+	}
+	if in.LastOutage != nil {
+		in, out := &in.LastOutage, &out.LastOutage
+		*out = new(Outage)
+		(*in).DeepCopyInto(*out)
+	}
+	in.UpdatedTime.DeepCopyInto(&out.UpdatedTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckSummary.
+func (in *CheckSummary) DeepCopy() *CheckSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCheckDefaults) DeepCopyInto(out *ClusterCheckDefaults) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCheckDefaults.
+func (in *ClusterCheckDefaults) DeepCopy() *ClusterCheckDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCheckDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterCheckDefaults) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCheckDefaultsList) DeepCopyInto(out *ClusterCheckDefaultsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterCheckDefaults, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCheckDefaultsList.
+func (in *ClusterCheckDefaultsList) DeepCopy() *ClusterCheckDefaultsList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCheckDefaultsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterCheckDefaultsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPingdomAccount) DeepCopyInto(out *ClusterPingdomAccount) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPingdomAccount.
+func (in *ClusterPingdomAccount) DeepCopy() *ClusterPingdomAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPingdomAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterPingdomAccount) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPingdomAccountList) DeepCopyInto(out *ClusterPingdomAccountList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterPingdomAccount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPingdomAccountList.
+func (in *ClusterPingdomAccountList) DeepCopy() *ClusterPingdomAccountList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPingdomAccountList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterPingdomAccountList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Condition) DeepCopyInto(out *Condition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
+func (in *Condition) DeepCopy() *Condition {
+	if in == nil {
+		return nil
+	}
+	out := new(Condition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultedField) DeepCopyInto(out *DefaultedField) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultedField.
+func (in *DefaultedField) DeepCopy() *DefaultedField {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultedField)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmailTarget) DeepCopyInto(out *EmailTarget) {
+	*out = *in
+	if in.Severity != nil {
+		in, out := &in.Severity, &out.Severity
+		*out = new(NotificationSeverity)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailTarget.
+func (in *EmailTarget) DeepCopy() *EmailTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(EmailTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPAuth) DeepCopyInto(out *HTTPAuth) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	if in.UsernameKey != nil {
+		in, out := &in.UsernameKey, &out.UsernameKey
+		*out = new(string)
+		**out = **in
+	}
+	if in.PasswordKey != nil {
+		in, out := &in.PasswordKey, &out.PasswordKey
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPAuth.
+func (in *HTTPAuth) DeepCopy() *HTTPAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostsSource) DeepCopyInto(out *HostsSource) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.ConfigMapSelector != nil {
+		in, out := &in.ConfigMapSelector, &out.ConfigMapSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostsSource.
+func (in *HostsSource) DeepCopy() *HostsSource {
+	if in == nil {
+		return nil
+	}
+	out := new(HostsSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MaintenanceWindow) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindowList) DeepCopyInto(out *MaintenanceWindowList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindowList.
+func (in *MaintenanceWindowList) DeepCopy() *MaintenanceWindowList {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindowList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MaintenanceWindowList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindowSpec) DeepCopyInto(out *MaintenanceWindowSpec) {
+	*out = *in
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	in.Start.DeepCopyInto(&out.Start)
+	in.End.DeepCopyInto(&out.End)
+	if in.Recurrence != nil {
+		in, out := &in.Recurrence, &out.Recurrence
+		*out = new(Recurrence)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CheckSelector != nil {
+		in, out := &in.CheckSelector, &out.CheckSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	out.CredentialsSecret = in.CredentialsSecret
+	if in.AccountRef != nil {
+		in, out := &in.AccountRef, &out.AccountRef
+		*out = new(AccountReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindowSpec.
+func (in *MaintenanceWindowSpec) DeepCopy() *MaintenanceWindowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindowStatus) DeepCopyInto(out *MaintenanceWindowStatus) {
+	*out = *in
+	if in.CheckIDs != nil {
+		in, out := &in.CheckIDs, &out.CheckIDs
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindowStatus.
+func (in *MaintenanceWindowStatus) DeepCopy() *MaintenanceWindowStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindowStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Outage) DeepCopyInto(out *Outage) {
+	*out = *in
+	in.Start.DeepCopyInto(&out.Start)
+	if in.End != nil {
+		in, out := &in.End, &out.End
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Outage.
+func (in *Outage) DeepCopy() *Outage {
+	if in == nil {
+		return nil
+	}
+	out := new(Outage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingdomAccount) DeepCopyInto(out *PingdomAccount) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingdomAccount.
+func (in *PingdomAccount) DeepCopy() *PingdomAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(PingdomAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingdomAccount) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingdomAccountList) DeepCopyInto(out *PingdomAccountList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PingdomAccount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingdomAccountList.
+func (in *PingdomAccountList) DeepCopy() *PingdomAccountList {
+	if in == nil {
+		return nil
+	}
+	out := new(PingdomAccountList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingdomAccountList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingdomAccountSpec) DeepCopyInto(out *PingdomAccountSpec) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	if in.APIVersion != nil {
+		in, out := &in.APIVersion, &out.APIVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.AppKey != nil {
+		in, out := &in.AppKey, &out.AppKey
+		*out = new(string)
+		**out = **in
+	}
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = new(AccountDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingdomAccountSpec.
+func (in *PingdomAccountSpec) DeepCopy() *PingdomAccountSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PingdomAccountSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingdomAccountStatus) DeepCopyInto(out *PingdomAccountStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingdomAccountStatus.
+func (in *PingdomAccountStatus) DeepCopy() *PingdomAccountStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PingdomAccountStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingdomCheckPolicy) DeepCopyInto(out *PingdomCheckPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingdomCheckPolicy.
+func (in *PingdomCheckPolicy) DeepCopy() *PingdomCheckPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PingdomCheckPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingdomCheckPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingdomCheckPolicyList) DeepCopyInto(out *PingdomCheckPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PingdomCheckPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingdomCheckPolicyList.
+func (in *PingdomCheckPolicyList) DeepCopy() *PingdomCheckPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(PingdomCheckPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingdomCheckPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingdomCheckPolicySpec) DeepCopyInto(out *PingdomCheckPolicySpec) {
+	*out = *in
+	out.TargetRef = in.TargetRef
+	if in.ResolutionMinutes != nil {
+		in, out := &in.ResolutionMinutes, &out.ResolutionMinutes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Contacts != nil {
+		in, out := &in.Contacts, &out.Contacts
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.ShouldContain != nil {
+		in, out := &in.ShouldContain, &out.ShouldContain
+		*out = new(string)
+		**out = **in
+	}
+	if in.ShouldNotContain != nil {
+		in, out := &in.ShouldNotContain, &out.ShouldNotContain
+		*out = new(string)
+		**out = **in
+	}
+	out.CredentialsSecret = in.CredentialsSecret
+	if in.AccountRef != nil {
+		in, out := &in.AccountRef, &out.AccountRef
+		*out = new(AccountReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingdomCheckPolicySpec.
+func (in *PingdomCheckPolicySpec) DeepCopy() *PingdomCheckPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PingdomCheckPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingdomCheckPolicyStatus) DeepCopyInto(out *PingdomCheckPolicyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingdomCheckPolicyStatus.
+func (in *PingdomCheckPolicyStatus) DeepCopy() *PingdomCheckPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PingdomCheckPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingdomContact) DeepCopyInto(out *PingdomContact) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingdomContact.
+func (in *PingdomContact) DeepCopy() *PingdomContact {
+	if in == nil {
+		return nil
+	}
+	out := new(PingdomContact)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingdomContact) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingdomContactList) DeepCopyInto(out *PingdomContactList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PingdomContact, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingdomContactList.
+func (in *PingdomContactList) DeepCopy() *PingdomContactList {
+	if in == nil {
+		return nil
+	}
+	out := new(PingdomContactList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingdomContactList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingdomContactSpec) DeepCopyInto(out *PingdomContactSpec) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Paused != nil {
+		in, out := &in.Paused, &out.Paused
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = make([]EmailTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SMS != nil {
+		in, out := &in.SMS, &out.SMS
+		*out = make([]SMSTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.CredentialsSecret = in.CredentialsSecret
+	if in.AccountRef != nil {
+		in, out := &in.AccountRef, &out.AccountRef
+		*out = new(AccountReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingdomContactSpec.
+func (in *PingdomContactSpec) DeepCopy() *PingdomContactSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PingdomContactSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingdomContactStatus) DeepCopyInto(out *PingdomContactStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingdomContactStatus.
+func (in *PingdomContactStatus) DeepCopy() *PingdomContactStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PingdomContactStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingdomTeam) DeepCopyInto(out *PingdomTeam) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingdomTeam.
+func (in *PingdomTeam) DeepCopy() *PingdomTeam {
+	if in == nil {
+		return nil
+	}
+	out := new(PingdomTeam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingdomTeam) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingdomTeamList) DeepCopyInto(out *PingdomTeamList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PingdomTeam, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingdomTeamList.
+func (in *PingdomTeamList) DeepCopy() *PingdomTeamList {
+	if in == nil {
+		return nil
+	}
+	out := new(PingdomTeamList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingdomTeamList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingdomTeamSpec) DeepCopyInto(out *PingdomTeamSpec) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	out.CredentialsSecret = in.CredentialsSecret
+	if in.AccountRef != nil {
+		in, out := &in.AccountRef, &out.AccountRef
+		*out = new(AccountReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingdomTeamSpec.
+func (in *PingdomTeamSpec) DeepCopy() *PingdomTeamSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PingdomTeamSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingdomTeamStatus) DeepCopyInto(out *PingdomTeamStatus) {
+	*out = *in
+	if in.MemberIDs != nil {
+		in, out := &in.MemberIDs, &out.MemberIDs
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingdomTeamStatus.
+func (in *PingdomTeamStatus) DeepCopy() *PingdomTeamStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PingdomTeamStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyTargetReference) DeepCopyInto(out *PolicyTargetReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyTargetReference.
+func (in *PolicyTargetReference) DeepCopy() *PolicyTargetReference {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyTargetReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Recurrence) DeepCopyInto(out *Recurrence) {
+	*out = *in
+	if in.RepeatEvery != nil {
+		in, out := &in.RepeatEvery, &out.RepeatEvery
+		*out = new(int32)
+		**out = **in
+	}
+	in.Until.DeepCopyInto(&out.Until)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Recurrence.
+func (in *Recurrence) DeepCopy() *Recurrence {
+	if in == nil {
+		return nil
+	}
+	out := new(Recurrence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SMSTarget) DeepCopyInto(out *SMSTarget) {
+	*out = *in
+	if in.Provider != nil {
+		in, out := &in.Provider, &out.Provider
+		*out = new(string)
+		**out = **in
+	}
+	if in.Severity != nil {
+		in, out := &in.Severity, &out.Severity
+		*out = new(NotificationSeverity)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SMSTarget.
+func (in *SMSTarget) DeepCopy() *SMSTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(SMSTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransactionCheck) DeepCopyInto(out *TransactionCheck) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransactionCheck.
+func (in *TransactionCheck) DeepCopy() *TransactionCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(TransactionCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TransactionCheck) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransactionCheckList) DeepCopyInto(out *TransactionCheckList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TransactionCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransactionCheckList.
+func (in *TransactionCheckList) DeepCopy() *TransactionCheckList {
+	if in == nil {
+		return nil
+	}
+	out := new(TransactionCheckList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TransactionCheckList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransactionCheckSpec) DeepCopyInto(out *TransactionCheckSpec) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]TransactionStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IntervalMinutes != nil {
+		in, out := &in.IntervalMinutes, &out.IntervalMinutes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Region != nil {
+		in, out := &in.Region, &out.Region
+		*out = new(string)
+		**out = **in
+	}
+	if in.Paused != nil {
+		in, out := &in.Paused, &out.Paused
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Contacts != nil {
+		in, out := &in.Contacts, &out.Contacts
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	out.CredentialsSecret = in.CredentialsSecret
+	if in.AccountRef != nil {
+		in, out := &in.AccountRef, &out.AccountRef
+		*out = new(AccountReference)
+		**out = **in
+	}
+	if in.DeletionPolicy != nil {
+		in, out := &in.DeletionPolicy, &out.DeletionPolicy
+		*out = new(DeletionPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransactionCheckSpec.
+func (in *TransactionCheckSpec) DeepCopy() *TransactionCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TransactionCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransactionCheckStatus) DeepCopyInto(out *TransactionCheckStatus) {
+	*out = *in
+	if in.ContactIDs != nil {
+		in, out := &in.ContactIDs, &out.ContactIDs
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.TeamIDs != nil {
+		in, out := &in.TeamIDs, &out.TeamIDs
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastDowntimeStart != nil {
+		in, out := &in.LastDowntimeStart, &out.LastDowntimeStart
+		*out = (*in).DeepCopy()
+	}
+	if in.LastDowntimeEnd != nil {
+		in, out := &in.LastDowntimeEnd, &out.LastDowntimeEnd
+		*out = (*in).DeepCopy()
+	}
+	if in.CreatedTime != nil {
+		in, out := &in.CreatedTime, &out.CreatedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ModifiedTime != nil {
+		in, out := &in.ModifiedTime, &out.ModifiedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransactionCheckStatus.
+func (in *TransactionCheckStatus) DeepCopy() *TransactionCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TransactionCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransactionStep) DeepCopyInto(out *TransactionStep) {
+	*out = *in
+	if in.Assertion != nil {
+		in, out := &in.Assertion, &out.Assertion
+		*out = new(TransactionAssertion)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransactionStep.
+func (in *TransactionStep) DeepCopy() *TransactionStep {
+	if in == nil {
+		return nil
+	}
+	out := new(TransactionStep)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,216 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+	checkreconciler "gitlab.com/mig4/pingdom-operator/controllers/resources/check"
+)
+
+// How often accounts are re-validated, e.g. to notice a revoked API token
+const accountRevalidateInterval = 10 * time.Minute
+
+/*
+accountClient returns a (cached) Pingdom API client for an account, along
+with the credentials read from the Secret it references.
+
+secretNamespace is the namespace of the Secret, for namespaced accounts that's
+the namespace of the account itself. defaultAppKey is the operator-wide
+application key, used if neither the account nor the Secret specify one.
+*/
+func accountClient(
+	ctx context.Context,
+	c client.Client,
+	clients *pdclient.Cache,
+	spec *observabilityv1alpha1.PingdomAccountSpec,
+	secretNamespace, defaultAppKey string,
+) (*pdclient.Client, *pdclient.Credentials, error) {
+	if secretNamespace == "" {
+		return nil, nil, fmt.Errorf("account `SecretRef` must specify a namespace")
+	}
+	secretNsName := types.NamespacedName{
+		Namespace: secretNamespace,
+		Name:      spec.SecretRef.Name,
+	}
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, secretNsName, secret); err != nil {
+		return nil, nil, err
+	}
+
+	apiVersion := ""
+	if spec.APIVersion != nil {
+		apiVersion = *spec.APIVersion
+	}
+	if spec.AppKey != nil {
+		defaultAppKey = *spec.AppKey
+	}
+	creds, err := pdclient.FromSecret(secret, apiVersion, defaultAppKey)
+	if err != nil {
+		return nil, nil, microerror.Maskf(err, "invalid credentials in secret %v", secretNsName)
+	}
+	pdClient, err := clients.Get(secret, creds)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pdClient, creds, nil
+}
+
+/*
+credentialsClient returns a (cached) Pingdom API client for the credentials
+used by an object in given namespace: either the Secret with given name in the
+same namespace, or the account referenced by ref if it's set, in which case
+the account's defaults are returned as well.
+
+defaultAppKey is the operator-wide application key.
+*/
+func credentialsClient(
+	ctx context.Context,
+	c client.Client,
+	clients *pdclient.Cache,
+	namespace, secretName string,
+	ref *observabilityv1alpha1.AccountReference,
+	defaultAppKey string,
+) (*pdclient.Client, *observabilityv1alpha1.AccountDefaults, error) {
+	if ref == nil {
+		if secretName == "" {
+			return nil, nil, microerror.Maskf(
+				credentialsMissingError,
+				"set `credentialsSecret` or `accountRef` in the Check or in CheckDefaults",
+			)
+		}
+		secretNsName := types.NamespacedName{Namespace: namespace, Name: secretName}
+		secret := &corev1.Secret{}
+		if err := c.Get(ctx, secretNsName, secret); err != nil {
+			return nil, nil, err
+		}
+		creds, err := pdclient.FromSecret(secret, "", defaultAppKey)
+		if err != nil {
+			return nil, nil, microerror.Maskf(err, "invalid credentials in secret %v", secretNsName)
+		}
+		pdClient, err := clients.Get(secret, creds)
+		return pdClient, nil, err
+	}
+
+	var spec *observabilityv1alpha1.PingdomAccountSpec
+	var secretNamespace string
+	switch ref.Kind {
+	case observabilityv1alpha1.ClusterPingdomAccountKind:
+		account := &observabilityv1alpha1.ClusterPingdomAccount{}
+		if err := c.Get(ctx, types.NamespacedName{Name: ref.Name}, account); err != nil {
+			return nil, nil, microerror.Maskf(
+				accountNotFoundError, "%s %s: %v", ref.Kind, ref.Name, err,
+			)
+		}
+		spec = &account.Spec
+		secretNamespace = account.Spec.SecretRef.Namespace
+	default:
+		account := &observabilityv1alpha1.PingdomAccount{}
+		accountNsName := types.NamespacedName{Namespace: namespace, Name: ref.Name}
+		if err := c.Get(ctx, accountNsName, account); err != nil {
+			return nil, nil, microerror.Maskf(
+				accountNotFoundError, "%s %v: %v", ref.Kind, accountNsName, err,
+			)
+		}
+		spec = &account.Spec
+		secretNamespace = account.GetNamespace()
+	}
+
+	pdClient, _, err := accountClient(ctx, c, clients, spec, secretNamespace, defaultAppKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pdClient, spec.Defaults, nil
+}
+
+/*
+accountValidator validates credentials of PingdomAccounts and
+ClusterPingdomAccounts by making a request to the Pingdom API with them.
+*/
+type accountValidator struct {
+	client.Client
+
+	// PdAPIKey is the operator-wide Pingdom application key
+	PdAPIKey string
+
+	// PdClients is the cache of Pingdom API clients
+	PdClients *pdclient.Cache
+}
+
+/*
+validate checks the credentials of an account and records the result in the
+status of the account, returning an error if the validation couldn't be
+completed, e.g. because of Pingdom API rate limits.
+
+Rejected credentials are not an error, they are only reported with the
+CredentialsValid condition; the Ready condition mirrors it.
+*/
+func (v *accountValidator) validate(
+	ctx context.Context,
+	log logr.Logger,
+	spec *observabilityv1alpha1.PingdomAccountSpec,
+	secretNamespace string,
+	status *observabilityv1alpha1.PingdomAccountStatus,
+) error {
+	setConditions := func(condStatus corev1.ConditionStatus, reason, message string) {
+		for _, condType := range []observabilityv1alpha1.ConditionType{
+			observabilityv1alpha1.CredentialsValid,
+			observabilityv1alpha1.Ready,
+		} {
+			status.SetCondition(condType, condStatus, reason, message)
+		}
+	}
+
+	pdClient, creds, err := accountClient(
+		ctx, v.Client, v.PdClients, spec, secretNamespace, v.PdAPIKey,
+	)
+	if err != nil {
+		log.Error(err, "Unable to read Pingdom API credentials")
+		reason := "SecretInvalid"
+		if pdclient.IsAppKeyMissing(err) {
+			reason = "AppKeyMissing"
+		}
+		setConditions(corev1.ConditionFalse, reason, err.Error())
+		return nil
+	}
+	status.APIVersion = creds.APIVersion
+	if _, err := pdClient.ListChecks(map[string]string{"limit": "1"}); err != nil {
+		if checkreconciler.IsRateLimitError(err) {
+			setConditions(corev1.ConditionUnknown, "RateLimited", err.Error())
+			return err
+		}
+		if checkreconciler.IsUnauthorizedError(err) {
+			log.Info("Pingdom API rejected the credentials", "error", err.Error())
+			setConditions(corev1.ConditionFalse, "Unauthorized", err.Error())
+			return nil
+		}
+		setConditions(corev1.ConditionUnknown, "APIError", err.Error())
+		return microerror.Maskf(err, "unable to validate credentials")
+	}
+
+	setConditions(corev1.ConditionTrue, "Authorized", "Pingdom API accepted the credentials")
+	return nil
+}
@@ -0,0 +1,113 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+)
+
+var _ = Describe("accountValidator", func() {
+	var (
+		server *httptest.Server
+		status observabilityv1alpha1.PingdomAccountStatus
+		spec   observabilityv1alpha1.PingdomAccountSpec
+		secret *corev1.Secret
+	)
+
+	validate := func() error {
+		validator := &accountValidator{
+			Client:    fake.NewFakeClientWithScheme(scheme.Scheme, secret),
+			PdClients: pdclient.NewCache(pdclient.CacheConfig{BaseURL: server.URL + "/api/"}),
+		}
+		return validator.validate(
+			context.Background(), zap.Logger(true), &spec, "default", &status,
+		)
+	}
+	validCondition := func() *observabilityv1alpha1.Condition {
+		return status.GetCondition(observabilityv1alpha1.CredentialsValid)
+	}
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.Header.Get("Authorization") != "Bearer good" {
+					w.WriteHeader(http.StatusUnauthorized)
+					_, _ = w.Write([]byte(`{"error": {"statuscode": 401, "statusdesc": "Unauthorized", "errormessage": "Invalid token"}}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"checks": []}`))
+			},
+		))
+		status = observabilityv1alpha1.PingdomAccountStatus{}
+		spec = observabilityv1alpha1.PingdomAccountSpec{
+			SecretRef: corev1.SecretReference{Name: "creds"},
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "default"},
+			Data:       map[string][]byte{"apiToken": []byte("good")},
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("marks accepted credentials as valid", func() {
+		Expect(validate()).To(Succeed())
+		Expect(validCondition().Status).To(Equal(corev1.ConditionTrue))
+		Expect(status.GetCondition(observabilityv1alpha1.Ready).Status).
+			To(Equal(corev1.ConditionTrue))
+		Expect(status.APIVersion).To(Equal("3.1"))
+	})
+
+	It("marks rejected credentials as invalid", func() {
+		secret.Data["apiToken"] = []byte("bad")
+		Expect(validate()).To(Succeed())
+		Expect(validCondition().Status).To(Equal(corev1.ConditionFalse))
+		Expect(validCondition().Reason).To(Equal("Unauthorized"))
+	})
+
+	It("marks a missing secret as invalid", func() {
+		spec.SecretRef.Name = "missing"
+		Expect(validate()).To(Succeed())
+		Expect(validCondition().Status).To(Equal(corev1.ConditionFalse))
+		Expect(validCondition().Reason).To(Equal("SecretInvalid"))
+	})
+
+	It("reports a missing app key for API 2.1", func() {
+		apiVersion := "2.1"
+		spec.APIVersion = &apiVersion
+		secret.Data = map[string][]byte{
+			"user": []byte("u"), "password": []byte("p"),
+		}
+		Expect(validate()).To(Succeed())
+		Expect(validCondition().Reason).To(Equal("AppKeyMissing"))
+	})
+})
@@ -18,31 +18,87 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/giantswarm/microerror"
 	"github.com/go-logr/logr"
-	"github.com/russellcardullo/go-pingdom/pingdom"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
 	"gitlab.com/mig4/pingdom-operator/controllers/finalizer"
+	"gitlab.com/mig4/pingdom-operator/controllers/metrics"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
 	checkreconciler "gitlab.com/mig4/pingdom-operator/controllers/resources/check"
 )
 
+// Default intervals between reconciles of a Check, see CheckReconciler
+const (
+	DefaultRequeueInterval            = time.Minute
+	DefaultRequeueAfterChangeInterval = 10 * time.Second
+)
+
+// DefaultSummaryInterval is the default interval between refreshes of the
+// summary in the Status of a Check, see CheckReconciler
+const DefaultSummaryInterval = 15 * time.Minute
+
+// checkControllerName labels metrics of the Check controller
+const checkControllerName = "check"
+
 // CheckReconciler reconciles a Check object
 type CheckReconciler struct {
 	client.Client
 	Log      logr.Logger
+	Recorder record.EventRecorder
+
+	// PdAPIKey is the Pingdom application key used for all checks, unless
+	// overridden by the `appKey` in the credentials secret; only used with
+	// Pingdom API 2.1
 	PdAPIKey string
+
+	// AuthChecksumKey is the secret key of checksums of basic authentication
+	// credentials and HTTP header values stored in the Status of Checks;
+	// changing it causes all checks with either to be updated once
+	AuthChecksumKey []byte
+
+	// RequeueInterval is the default interval between reconciles of a Check,
+	// used when it's longer than the check's resolution and the Check
+	// doesn't set SyncInterval; defaults to DefaultRequeueInterval
+	RequeueInterval time.Duration
+
+	// RequeueAfterChangeInterval is the interval before the next reconcile
+	// after a change was made to a Pingdom check, so its status is refreshed
+	// soon; defaults to DefaultRequeueAfterChangeInterval
+	RequeueAfterChangeInterval time.Duration
+
+	// SummaryInterval is the interval between refreshes of the uptime,
+	// response time and last outage summary in the Status of a Check, which
+	// takes a few Pingdom API requests per check; 0 disables the summary
+	SummaryInterval time.Duration
+
+	// PdClients is the cache of Pingdom API clients shared by all
+	// reconcilers, so clients are only built when credentials change
+	PdClients *pdclient.Cache
 }
 
 // +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=checks,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=checks/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=pingdomaccounts,verbs=get;list;watch
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=clusterpingdomaccounts,verbs=get;list;watch
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=pingdomcontacts,verbs=get;list;watch
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=pingdomteams,verbs=get;list;watch
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=checkdefaults,verbs=get;list;watch
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=clustercheckdefaults,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile performs a single reconciliation run for a resource specified in
 // the given request.
@@ -54,157 +110,501 @@ func (r *CheckReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	var check observabilityv1alpha1.Check
 	if err := r.Get(ctx, req.NamespacedName, &check); err != nil {
 		log.Error(err, "Check not found")
+		if apierrors.IsNotFound(err) {
+			metrics.ForgetCheck(req.Namespace, req.Name)
+		}
 		// Ignore NotFound errors, we'll get a new notification when the
 		// object exists.
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	// TODO: move to a defaulting webhook
-	r.initCheckDefaults(log, req, &check)
+	// The spec is completed below with defaults and resolved references,
+	// which must not be written back to Kube, so keep the Check as stored to
+	// attach and detach the finalizer to
+	stored := check.DeepCopy()
+
+	// Parameters the Check doesn't set are taken from CheckDefaults and
+	// ClusterCheckDefaults (in memory only), so changes to them apply to
+	// existing Checks; account defaults are applied later, once the account
+	// is known
+	defaults, err := listCheckDefaults(ctx, r.Client, check.GetNamespace())
+	if err != nil {
+		metrics.RecordReconcileError(checkControllerName, "DefaultsLookupFailed")
+		return ctrl.Result{}, err
+	}
+	applyCheckDefaults(&check, defaults)
+
+	// Defaults are normally set by the defaulting webhook, but set them here
+	// too in case webhooks are not deployed; this is also needed for Status
+	// to pass validation before the resource is created on Pingdom
+	check.Default()
+	check.DefaultPort()
+
+	// Ensure to update status subresource of the CRD in Kube when we're done
+	// so it's visible to users; it's deferred so it picks up any changes the
+	// reconciler makes to the object (like setting the ID initially) as well
+	// as conditions set on any of the paths below
+	defer r.updateStatus(ctx, log, &check)
+
+	if !check.GetDeletionTimestamp().IsZero() {
+		check.Status.SetCondition(
+			observabilityv1alpha1.Deleting, corev1.ConditionTrue,
+			"Deleting", "Check is being deleted",
+		)
+	}
 
 	// Initialise Pingdom client
-	pdClient, err := r.initPingdomClient(
-		ctx, check.GetNamespace(), check.Spec.CredentialsSecret.Name,
-	)
+	pdClient, err := r.initPingdomClient(ctx, &check)
 	if err != nil {
 		log.Error(err, "Unable to initialise Pingdom client")
+		r.Recorder.Eventf(
+			&check, corev1.EventTypeWarning, "CredentialsInvalid",
+			"Unable to read Pingdom API credentials: %v", err,
+		)
+		reason := "SecretInvalid"
+		if pdclient.IsAppKeyMissing(err) {
+			reason = "AppKeyMissing"
+		} else if IsAccountNotFound(err) {
+			reason = "AccountNotFound"
+		} else if IsCredentialsMissing(err) {
+			reason = "CredentialsMissing"
+		}
+		check.Status.SetCondition(
+			observabilityv1alpha1.CredentialsValid, corev1.ConditionFalse,
+			reason, err.Error(),
+		)
+		metrics.RecordReconcileError(checkControllerName, reason)
 		return ctrl.Result{}, err
 	}
 
+	// Resolve basic authentication credentials for HTTP checks; not needed
+	// when deleting, so a missing Secret doesn't block the deletion
+	var httpAuth *checkreconciler.Credentials
+	if check.GetDeletionTimestamp().IsZero() {
+		httpAuth, err = r.resolveHTTPAuth(ctx, check.GetNamespace(), check.Spec.Auth)
+		if err != nil {
+			log.Error(err, "Unable to resolve HTTP authentication credentials")
+			r.Recorder.Eventf(
+				&check, corev1.EventTypeWarning, "AuthSecretInvalid",
+				"Unable to read HTTP authentication credentials: %v", err,
+			)
+			check.Status.SetCondition(
+				observabilityv1alpha1.Synced, corev1.ConditionFalse,
+				"AuthSecretInvalid", err.Error(),
+			)
+			metrics.RecordReconcileError(checkControllerName, "AuthSecretInvalid")
+			return ctrl.Result{}, err
+		}
+
+		// Resolve PingdomContacts and PingdomTeams to alert; the Check is
+		// reconciled again when they change, so wait for them if not ready
+		if err := resolveAlerting(ctx, r.Client, &check); err != nil {
+			reason := "AlertingLookupFailed"
+			if IsContactNotReady(err) {
+				reason = "ContactNotReady"
+			}
+			log.Info("Unable to resolve contacts to alert", "reason", err.Error())
+			check.Status.SetCondition(
+				observabilityv1alpha1.Synced, corev1.ConditionFalse,
+				reason, err.Error(),
+			)
+			if IsContactNotReady(err) {
+				return ctrl.Result{}, nil
+			}
+			metrics.RecordReconcileError(checkControllerName, reason)
+			return ctrl.Result{}, err
+		}
+	}
+
 	// Initialise Pingdom resource reconciler and a finalizer manager for it
 	reconciler := checkreconciler.New(&checkreconciler.Config{
-		Logger:   log,
-		PdClient: pdClient,
-		Check:    &check,
+		Logger:     log,
+		Recorder:   r.Recorder,
+		PdClient:   pdClient.Client,
+		APIVersion: pdClient.APIVersion,
+		Snapshot:   pdClient.Snapshot,
+		Check:      &check,
+		HTTPAuth:   httpAuth,
+		AuthKey:    r.AuthChecksumKey,
+
+		SummaryInterval: r.SummaryInterval,
 	})
 	finalizerMgr := finalizer.New(log, r.Client, reconciler)
 
 	// Ensure finalizer is registered
-	if err := finalizerMgr.EnsureAttached(ctx, &check); err != nil {
+	if err := updateFinalizers(ctx, finalizerMgr.EnsureAttached, &check, stored); err != nil {
+		metrics.RecordReconcileError(checkControllerName, "FinalizerFailed")
 		return ctrl.Result{}, microerror.Maskf(err, "failure handling finalizer")
 	}
 
 	// Refresh internal representation of state of the external resource
 	if err := reconciler.RefreshState(ctx); err != nil {
+		if checkreconciler.IsRateLimitError(err) {
+			return r.requeueRateLimited(log, &check, err), nil
+		}
+		r.setCredentialsCondition(&check, err)
+		metrics.RecordReconcileError(checkControllerName, "RefreshFailed")
 		return ctrl.Result{}, microerror.Maskf(
 			err, "failure refreshing state of the check",
 		)
 	}
 
-	// Ensure to update status subresource of the CRD in Kube when we're done
-	// so it's visible to users; do it later so it picks up any changes the
-	// reconciler makes to the object (like setting the ID initially)
-	defer r.updateStatus(ctx, log, &check)
-
 	// Ensure external resource (Pingdom check) matches desired spec
 	if err := reconciler.EnsureState(ctx); err != nil {
+		if checkreconciler.IsRateLimitError(err) {
+			return r.requeueRateLimited(log, &check, err), nil
+		}
+		// Retrying won't help until the check or the Check changes, the
+		// Synced condition and an event already report it
+		if checkreconciler.IsCheckOwned(err) {
+			log.Info("Pingdom check belongs to a different Check", "error", err.Error())
+			metrics.RecordReconcileError(checkControllerName, "CheckOwned")
+			return ctrl.Result{RequeueAfter: r.requeueInterval(&check)}, nil
+		}
+		r.setCredentialsCondition(&check, err)
+		metrics.RecordReconcileError(checkControllerName, "SyncFailed")
 		return ctrl.Result{}, microerror.Maskf(
 			err, "failure reconciling external resource",
 		)
 	}
+	r.setCredentialsCondition(&check, nil)
+	check.Status.ObservedGeneration = check.GetGeneration()
 
 	// If object is being deleted and we got here without errors means external
 	// resource is already gone and we can remove the finalizer.
 	if !check.GetDeletionTimestamp().IsZero() {
-		if err := finalizerMgr.EnsureDetached(ctx, &check); err != nil {
+		if err := updateFinalizers(ctx, finalizerMgr.EnsureDetached, &check, stored); err != nil {
+			metrics.RecordReconcileError(checkControllerName, "FinalizerFailed")
 			return ctrl.Result{}, microerror.Maskf(err, "failure handling finalizer")
 		}
 	}
 
 	// Schedule next run after some time.
-	nextIn := "1m" // normally "5m" == default Pingdom resolution
+	nextIn := r.requeueInterval(&check)
 	if reconciler.DidWork() {
 		log.V(1).Info("reconciler.DidWork == true", "obj", &check)
-		nextIn = "10s"
+		nextIn = r.RequeueAfterChangeInterval
+		if nextIn == 0 {
+			nextIn = DefaultRequeueAfterChangeInterval
+		}
 	}
 	log.Info("exiting Reconcile, scheduling next run", "nextIn", nextIn)
-	delay, _ := time.ParseDuration(nextIn)
-	return ctrl.Result{RequeueAfter: delay}, nil
+	return ctrl.Result{RequeueAfter: nextIn}, nil
+}
+
+/*
+updateFinalizers attaches or detaches the finalizer, using given function of a
+finalizer manager, on stored, the Check as read from Kube, and copies the
+resulting finalizers and resource version to check.
+
+The spec of check has defaults and references to contacts and teams resolved
+in memory, which would be persisted by updating it, after which changes to
+them would no longer apply to the Check. Its status is preserved too, as
+updating an object overwrites it with the one stored in Kube.
+*/
+func updateFinalizers(
+	ctx context.Context,
+	update func(context.Context, runtime.Object) error,
+	check, stored *observabilityv1alpha1.Check,
+) error {
+	if err := update(ctx, stored); err != nil {
+		return err
+	}
+	check.SetFinalizers(stored.GetFinalizers())
+	check.SetResourceVersion(stored.GetResourceVersion())
+	return nil
+}
+
+/*
+requeueInterval returns the interval before the next reconcile of the Check
+when nothing changed: SyncInterval if set, otherwise the check's resolution,
+as the status can't change more often than the check is tested, or the
+default requeue interval if that's longer.
+*/
+func (r *CheckReconciler) requeueInterval(check *observabilityv1alpha1.Check) time.Duration {
+	if check.Spec.SyncInterval != nil {
+		return check.Spec.SyncInterval.Duration
+	}
+
+	interval := r.RequeueInterval
+	if interval == 0 {
+		interval = DefaultRequeueInterval
+	}
+	resolution := check.Status.ResolutionMinutes
+	if resolution == nil {
+		resolution = check.Spec.ResolutionMinutes
+	}
+	if resolution != nil {
+		if byResolution := time.Duration(*resolution) * time.Minute; byResolution > interval {
+			interval = byResolution
+		}
+	}
+	return interval
 }
 
 /*
-initPingdomClient reads the secrets and initialises a Pingdom API client.
+initPingdomClient reads the credentials of the Check and returns a Pingdom API
+client for them, from the cache if the credentials haven't changed.
+
+Credentials are read either from the Secret referenced by the Check directly
+or from the referenced account, in which case the account's defaults are
+applied to the Check's spec (in memory only). The API version is selected
+based on the account and contents of the Secret, see pdclient.FromSecret.
 */
 func (r *CheckReconciler) initPingdomClient(
 	ctx context.Context,
-	namespace, secretName string,
-) (*pingdom.Client, error) {
+	check *observabilityv1alpha1.Check,
+) (*pdclient.Client, error) {
+	pdClient, defaults, err := credentialsClient(
+		ctx, r.Client, r.PdClients, check.GetNamespace(),
+		check.Spec.CredentialsSecret.Name, check.Spec.AccountRef, r.PdAPIKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+	applyAccountDefaults(check, defaults)
+	return pdClient, nil
+}
+
+// applyAccountDefaults sets parameters of the Check spec which are not set to
+// the account defaults; the spec must not be written back afterwards, so
+// changes to the defaults apply to existing Checks.
+func applyAccountDefaults(
+	check *observabilityv1alpha1.Check,
+	defaults *observabilityv1alpha1.AccountDefaults,
+) {
+	if defaults == nil {
+		return
+	}
+	if check.Spec.ResolutionMinutes == nil && defaults.ResolutionMinutes != nil {
+		resolution := *defaults.ResolutionMinutes
+		check.Spec.ResolutionMinutes = &resolution
+	}
+	if check.Spec.UserIds == nil && defaults.UserIds != nil {
+		userIds := append([]int(nil), *defaults.UserIds...)
+		check.Spec.UserIds = &userIds
+	}
+}
+
+/*
+resolveAlerting adds identifiers of Pingdom contacts and teams of the
+PingdomContacts and PingdomTeams referenced by the Check to its spec, along
+with the ones set directly by UserIds and TeamIds.
+
+This is in memory only, to build the request to Pingdom: the Check must not be
+updated in Kube afterwards (see updateFinalizers), otherwise contacts and teams
+removed from its references would still be alerted.
+*/
+func resolveAlerting(
+	ctx context.Context,
+	c client.Client,
+	check *observabilityv1alpha1.Check,
+) error {
+	if len(check.Spec.Contacts) > 0 {
+		ids, err := contactIDs(ctx, c, check.GetNamespace(), check.Spec.Contacts)
+		if err != nil {
+			return err
+		}
+		check.Spec.UserIds = mergeIDs(check.Spec.UserIds, ids)
+	}
+	if len(check.Spec.Teams) > 0 {
+		ids, err := teamIDs(ctx, c, check.GetNamespace(), check.Spec.Teams)
+		if err != nil {
+			return err
+		}
+		check.Spec.TeamIds = mergeIDs(check.Spec.TeamIds, ids)
+	}
+	return nil
+}
+
+// mergeIDs returns sorted, unique identifiers from both given lists.
+func mergeIDs(ids *[]int, more []int32) *[]int {
+	seen := map[int]bool{}
+	merged := []int{}
+	add := func(id int) {
+		if !seen[id] {
+			seen[id] = true
+			merged = append(merged, id)
+		}
+	}
+	if ids != nil {
+		for _, id := range *ids {
+			add(id)
+		}
+	}
+	for _, id := range more {
+		add(int(id))
+	}
+	sort.Ints(merged)
+	return &merged
+}
+
+/*
+resolveHTTPAuth reads basic authentication credentials for HTTP checks from the
+Secret referenced in the given HTTPAuth.
+
+Returns nil credentials if no authentication is requested.
+*/
+func (r *CheckReconciler) resolveHTTPAuth(
+	ctx context.Context,
+	namespace string,
+	auth *observabilityv1alpha1.HTTPAuth,
+) (*checkreconciler.Credentials, error) {
+	if auth == nil {
+		return nil, nil
+	}
+
 	secretNsName := types.NamespacedName{
 		Namespace: namespace,
-		Name:      secretName,
+		Name:      auth.SecretRef.Name,
 	}
 	secret := &corev1.Secret{}
 	if err := r.Get(ctx, secretNsName, secret); err != nil {
 		return nil, err
 	}
 
-	if secret.Data["user"] == nil {
+	usernameKey, passwordKey := "username", "password"
+	if auth.UsernameKey != nil {
+		usernameKey = *auth.UsernameKey
+	}
+	if auth.PasswordKey != nil {
+		passwordKey = *auth.PasswordKey
+	}
+
+	if secret.Data[usernameKey] == nil {
 		return nil, fmt.Errorf(
-			"Pingdom API username not found in secret %v", secretNsName,
+			"HTTP auth username key %q not found in secret %v", usernameKey, secretNsName,
 		)
 	}
-	if secret.Data["password"] == nil {
+	if secret.Data[passwordKey] == nil {
 		return nil, fmt.Errorf(
-			"Pingdom API password not found in secret %v", secretNsName,
+			"HTTP auth password key %q not found in secret %v", passwordKey, secretNsName,
 		)
 	}
 
-	pdClient, err := pingdom.NewClientWithConfig(pingdom.ClientConfig{
-		User:     string(secret.Data["user"]),
-		Password: string(secret.Data["password"]),
-		APIKey:   r.PdAPIKey,
-	})
-	if err != nil {
-		return nil, err
-	}
-	return pdClient, nil
+	return &checkreconciler.Credentials{
+		Username: string(secret.Data[usernameKey]),
+		Password: string(secret.Data[passwordKey]),
+	}, nil
 }
 
 /*
-initCheckDefaults initializes defaults on the Check object
+setCredentialsCondition sets the CredentialsValid condition on the Check based
+on the error returned from the Pingdom API (if any), recording an event when
+the credentials are rejected.
 
-TODO: this should be done if a defaulting webhook but in the meantime we need
-  it to be able to pass validation before the resource is created on Pingdom
-  and its status reflected in Check's Status object
+Errors other than authorization errors don't say anything about validity of
+credentials, so they leave the condition unchanged.
 */
-func (r *CheckReconciler) initCheckDefaults(
-	parentLog logr.Logger,
-	req ctrl.Request,
+func (r *CheckReconciler) setCredentialsCondition(
 	check *observabilityv1alpha1.Check,
+	err error,
 ) {
-	log := parentLog.WithValues("stage", "initDefaults")
-	if check.Spec.Name == nil {
-		check.Spec.Name = &req.Name
-		log.V(1).Info("using default Name", "name", req.Name)
-	}
-	if check.Status.CreatedTime.IsZero() {
-		check.Status.CreatedTime = check.CreationTimestamp
-		log.V(1).Info("using default CreatedTime", "created", check.Status.CreatedTime)
-	}
-	if check.Status.Name == nil {
-		check.Status.Name = check.Spec.Name
+	switch {
+	case err == nil:
+		check.Status.SetCondition(
+			observabilityv1alpha1.CredentialsValid, corev1.ConditionTrue,
+			"Authorized", "Pingdom API accepted the credentials",
+		)
+	case checkreconciler.IsUnauthorizedError(err):
+		r.Recorder.Eventf(
+			check, corev1.EventTypeWarning, "CredentialsInvalid",
+			"Pingdom API rejected the credentials: %v", err,
+		)
+		check.Status.SetCondition(
+			observabilityv1alpha1.CredentialsValid, corev1.ConditionFalse,
+			"Unauthorized", err.Error(),
+		)
 	}
-	if string(check.Status.Status) == "" {
-		check.Status.Status = observabilityv1alpha1.Unknown
-		log.V(1).Info("using default Status", "status", check.Status.Status)
+}
+
+/*
+requeueRateLimited handles a Pingdom API rate limit error by scheduling the
+next run of the Check after the delay advised by the API, rather than
+returning the error which would retry with an exponential backoff regardless
+of when the limit resets.
+*/
+func (r *CheckReconciler) requeueRateLimited(
+	log logr.Logger,
+	check *observabilityv1alpha1.Check,
+	err error,
+) ctrl.Result {
+	delay := checkreconciler.RetryAfter(err)
+	log.Info("Pingdom API request limit reached, scheduling next run", "nextIn", delay)
+	metrics.RecordReconcileError(checkControllerName, "RateLimited")
+	check.Status.SetCondition(
+		observabilityv1alpha1.Synced, corev1.ConditionFalse,
+		"RateLimited", err.Error(),
+	)
+	return ctrl.Result{RequeueAfter: delay}
+}
+
+/*
+setReadyCondition sets the Ready condition on the Check summarising the other
+conditions: the Check is ready when it's not being deleted, credentials are
+valid and the Pingdom check is in sync with the spec.
+*/
+func setReadyCondition(check *observabilityv1alpha1.Check) {
+	setReadyConditionOn(
+		&check.Status, "Check",
+		fmt.Sprintf("Pingdom check status is %s", check.Status.Status),
+	)
+}
+
+// conditionsStatus is a status with conditions, see setReadyConditionOn
+type conditionsStatus interface {
+	SetCondition(observabilityv1alpha1.ConditionType, corev1.ConditionStatus, string, string)
+	GetCondition(observabilityv1alpha1.ConditionType) *observabilityv1alpha1.Condition
+}
+
+/*
+setReadyConditionOn sets the Ready condition on a status of given kind of
+object which has CredentialsValid, Synced and Deleting conditions, with
+readyMessage as the message of the condition when it's true.
+*/
+func setReadyConditionOn(status conditionsStatus, kind, readyMessage string) {
+	for _, condType := range []observabilityv1alpha1.ConditionType{
+		observabilityv1alpha1.CredentialsValid,
+		observabilityv1alpha1.Synced,
+	} {
+		cond := status.GetCondition(condType)
+		if cond == nil {
+			status.SetCondition(
+				observabilityv1alpha1.Ready, corev1.ConditionUnknown,
+				"Reconciling", fmt.Sprintf("%s condition is not known yet", condType),
+			)
+			return
+		}
+		if cond.Status != corev1.ConditionTrue {
+			status.SetCondition(
+				observabilityv1alpha1.Ready, corev1.ConditionFalse,
+				cond.Reason, cond.Message,
+			)
+			return
+		}
 	}
-	if string(check.Status.Type) == "" {
-		// TODO: technically there should be no default type; this only needs
-		//   to be set in order to avoid failing validation before the resource
-		//   is created and type is set correctly; this happens when setting up
-		//   finalizers initially as we get an empty object and after adding
-		//   finalizers and giving that object to Update() it blows up because
-		//   the Status nested object is empty and so fails validation
-		check.Status.Type = observabilityv1alpha1.Ping
-		log.V(1).Info("using default Type", "type", check.Status.Type)
+	if deleting := status.GetCondition(observabilityv1alpha1.Deleting); deleting != nil &&
+		deleting.Status == corev1.ConditionTrue {
+		status.SetCondition(
+			observabilityv1alpha1.Ready, corev1.ConditionFalse,
+			"Deleting", fmt.Sprintf("%s is being deleted", kind),
+		)
+		return
 	}
+	status.SetCondition(
+		observabilityv1alpha1.Ready, corev1.ConditionTrue,
+		"Synced", readyMessage,
+	)
 }
 
 /*
 updateStatus updates the status subresource on the API Check object.
 
-It will skip the update if the object is being deleted as in this case the
-external resource may not exist anymore so status would be out-of-date anyway.
+It sets the Ready condition based on other conditions first. It will skip the
+update if the object is being deleted and has no finalizers left as in this
+case it's about to be removed by the API server.
+It also updates metrics of the Check, or removes them if it's about to be
+removed.
 Errors are only logged, not returned as a call to updateStatus should be
 deferred so there would be no way of handling the error.
 */
@@ -214,10 +614,13 @@ func (r *CheckReconciler) updateStatus(
 	check *observabilityv1alpha1.Check,
 ) {
 	log := parentLog.WithValues("action", "updateStatus")
-	if !check.GetDeletionTimestamp().IsZero() {
+	if !check.GetDeletionTimestamp().IsZero() && len(check.GetFinalizers()) == 0 {
 		log.V(1).Info("skip object status update as object is deleted")
+		metrics.ForgetCheck(check.GetNamespace(), check.GetName())
 		return
 	}
+	setReadyCondition(check)
+	metrics.RecordCheck(check)
 	if err := r.Status().Update(ctx, check); err != nil {
 		log.Error(err, "unable to update object status")
 		return
@@ -225,10 +628,82 @@ func (r *CheckReconciler) updateStatus(
 	log.V(1).Info("updated object status")
 }
 
-// SetupWithManager configures this reconciler to be triggered for events
-// pertaining to specified resource kinds.
+/*
+SetupWithManager configures this reconciler to be triggered for events
+pertaining to specified resource kinds.
+
+Besides Checks it watches Secrets and accounts, so Checks using them are
+reconciled as soon as credentials are rotated or created, as well as
+PingdomContacts and PingdomTeams, so Checks alert them once they're created,
+and CheckDefaults and ClusterCheckDefaults, so changes apply to Checks
+immediately.
+*/
 func (r *CheckReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	indexer := mgr.GetFieldIndexer()
+	if err := indexer.IndexField(
+		&observabilityv1alpha1.Check{}, checkSecretsField, checkSecretNames,
+	); err != nil {
+		return err
+	}
+	if err := indexer.IndexField(
+		&observabilityv1alpha1.Check{}, checkAccountField, checkAccount,
+	); err != nil {
+		return err
+	}
+	if err := indexer.IndexField(
+		&observabilityv1alpha1.Check{}, checkContactsField, checkContacts,
+	); err != nil {
+		return err
+	}
+	if err := indexer.IndexField(
+		&observabilityv1alpha1.Check{}, checkTeamsField, checkTeams,
+	); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&observabilityv1alpha1.Check{}).
+		Watches(
+			&source.Kind{Type: &corev1.Secret{}},
+			&handler.EnqueueRequestsFromMapFunc{
+				ToRequests: handler.ToRequestsFunc(r.requestsForSecret),
+			},
+		).
+		Watches(
+			&source.Kind{Type: &observabilityv1alpha1.PingdomAccount{}},
+			&handler.EnqueueRequestsFromMapFunc{
+				ToRequests: handler.ToRequestsFunc(r.requestsForAccount),
+			},
+		).
+		Watches(
+			&source.Kind{Type: &observabilityv1alpha1.ClusterPingdomAccount{}},
+			&handler.EnqueueRequestsFromMapFunc{
+				ToRequests: handler.ToRequestsFunc(r.requestsForAccount),
+			},
+		).
+		Watches(
+			&source.Kind{Type: &observabilityv1alpha1.PingdomContact{}},
+			&handler.EnqueueRequestsFromMapFunc{
+				ToRequests: handler.ToRequestsFunc(r.requestsForContact),
+			},
+		).
+		Watches(
+			&source.Kind{Type: &observabilityv1alpha1.PingdomTeam{}},
+			&handler.EnqueueRequestsFromMapFunc{
+				ToRequests: handler.ToRequestsFunc(r.requestsForTeam),
+			},
+		).
+		Watches(
+			&source.Kind{Type: &observabilityv1alpha1.CheckDefaults{}},
+			&handler.EnqueueRequestsFromMapFunc{
+				ToRequests: handler.ToRequestsFunc(r.requestsForCheckDefaults),
+			},
+		).
+		Watches(
+			&source.Kind{Type: &observabilityv1alpha1.ClusterCheckDefaults{}},
+			&handler.EnqueueRequestsFromMapFunc{
+				ToRequests: handler.ToRequestsFunc(r.requestsForCheckDefaults),
+			},
+		).
 		Complete(r)
 }
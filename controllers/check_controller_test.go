@@ -0,0 +1,196 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/finalizer"
+)
+
+func ptrI32(i int32) *int32 {
+	return &i
+}
+
+func ptrS(s string) *string {
+	return &s
+}
+
+// finalizingReconciler is a ResourceReconciler which only has a finalizer,
+// for testing handling of finalizers
+type finalizingReconciler struct{}
+
+func (finalizingReconciler) RefreshState(context.Context) error { return nil }
+func (finalizingReconciler) EnsureState(context.Context) error  { return nil }
+func (finalizingReconciler) DidWork() bool                      { return false }
+func (finalizingReconciler) FinalizerName() *string             { return ptrS("test") }
+
+var _ = Describe("CheckReconciler", func() {
+	withConditions := func(conds ...observabilityv1alpha1.Condition) *observabilityv1alpha1.Check {
+		return &observabilityv1alpha1.Check{
+			Status: observabilityv1alpha1.CheckStatus{
+				Status:     observabilityv1alpha1.Up,
+				Conditions: conds,
+			},
+		}
+	}
+	cond := func(
+		condType observabilityv1alpha1.ConditionType,
+		status corev1.ConditionStatus,
+		reason string,
+	) observabilityv1alpha1.Condition {
+		return observabilityv1alpha1.Condition{
+			Type: condType, Status: status, Reason: reason, LastTransitionTime: metav1.Now(),
+		}
+	}
+
+	DescribeTable("setReadyCondition",
+		func(check *observabilityv1alpha1.Check, status corev1.ConditionStatus, reason string) {
+			setReadyCondition(check)
+			ready := check.Status.GetCondition(observabilityv1alpha1.Ready)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Status).To(Equal(status))
+			Expect(ready.Reason).To(Equal(reason))
+		},
+		Entry("with no conditions", withConditions(),
+			corev1.ConditionUnknown, "Reconciling"),
+		Entry("with invalid credentials", withConditions(
+			cond(observabilityv1alpha1.CredentialsValid, corev1.ConditionFalse, "SecretInvalid"),
+		), corev1.ConditionFalse, "SecretInvalid"),
+		Entry("with failed sync", withConditions(
+			cond(observabilityv1alpha1.CredentialsValid, corev1.ConditionTrue, "Authorized"),
+			cond(observabilityv1alpha1.Synced, corev1.ConditionFalse, "UpdateFailed"),
+		), corev1.ConditionFalse, "UpdateFailed"),
+		Entry("when being deleted", withConditions(
+			cond(observabilityv1alpha1.CredentialsValid, corev1.ConditionTrue, "Authorized"),
+			cond(observabilityv1alpha1.Synced, corev1.ConditionTrue, "Deleted"),
+			cond(observabilityv1alpha1.Deleting, corev1.ConditionTrue, "Deleting"),
+		), corev1.ConditionFalse, "Deleting"),
+		Entry("when in sync", withConditions(
+			cond(observabilityv1alpha1.CredentialsValid, corev1.ConditionTrue, "Authorized"),
+			cond(observabilityv1alpha1.Synced, corev1.ConditionTrue, "UpToDate"),
+		), corev1.ConditionTrue, "Synced"),
+	)
+
+	Describe("applyAccountDefaults", func() {
+		defaults := &observabilityv1alpha1.AccountDefaults{
+			ResolutionMinutes: ptrI32(5),
+			UserIds:           &[]int{1, 2},
+		}
+
+		It("sets parameters which are not set", func() {
+			check := &observabilityv1alpha1.Check{}
+			applyAccountDefaults(check, defaults)
+			Expect(*check.Spec.ResolutionMinutes).To(BeEquivalentTo(5))
+			Expect(*check.Spec.UserIds).To(Equal([]int{1, 2}))
+		})
+
+		It("keeps parameters which are set", func() {
+			check := &observabilityv1alpha1.Check{}
+			check.Spec.ResolutionMinutes = ptrI32(1)
+			check.Spec.UserIds = &[]int{3}
+			applyAccountDefaults(check, defaults)
+			Expect(*check.Spec.ResolutionMinutes).To(BeEquivalentTo(1))
+			Expect(*check.Spec.UserIds).To(Equal([]int{3}))
+		})
+
+		It("ignores missing defaults", func() {
+			check := &observabilityv1alpha1.Check{}
+			applyAccountDefaults(check, nil)
+			Expect(check.Spec.ResolutionMinutes).To(BeNil())
+		})
+
+		It("picks up changed defaults after the finalizer is attached", func() {
+			ctx := context.Background()
+			nsName := types.NamespacedName{Namespace: "default", Name: "check"}
+			scheme := runtime.NewScheme()
+			Expect(observabilityv1alpha1.AddToScheme(scheme)).To(Succeed())
+			c := fake.NewFakeClientWithScheme(scheme, &observabilityv1alpha1.Check{
+				ObjectMeta: metav1.ObjectMeta{Name: nsName.Name, Namespace: nsName.Namespace},
+			})
+
+			check := &observabilityv1alpha1.Check{}
+			Expect(c.Get(ctx, nsName, check)).To(Succeed())
+			stored := check.DeepCopy()
+			applyAccountDefaults(check, defaults)
+			finalizerMgr := finalizer.New(zap.Logger(true), c, &finalizingReconciler{})
+			Expect(updateFinalizers(ctx, finalizerMgr.EnsureAttached, check, stored)).To(Succeed())
+
+			check = &observabilityv1alpha1.Check{}
+			Expect(c.Get(ctx, nsName, check)).To(Succeed())
+			applyAccountDefaults(check, &observabilityv1alpha1.AccountDefaults{
+				ResolutionMinutes: ptrI32(15),
+			})
+			Expect(*check.Spec.ResolutionMinutes).To(BeEquivalentTo(15))
+			Expect(check.Spec.UserIds).To(BeNil())
+		})
+	})
+
+	DescribeTable("mergeIDs",
+		func(ids *[]int, more []int32, expected []int) {
+			Expect(*mergeIDs(ids, more)).To(Equal(expected))
+		},
+		Entry("without IDs set directly", nil, []int32{3, 1}, []int{1, 3}),
+		Entry("with IDs set directly", &[]int{5, 1}, []int32{3}, []int{1, 3, 5}),
+		Entry("with duplicates", &[]int{3}, []int32{3, 1}, []int{1, 3}),
+	)
+
+	Describe("requeueInterval", func() {
+		r := &CheckReconciler{RequeueInterval: 2 * time.Minute}
+		withResolution := func(spec, status *int32) *observabilityv1alpha1.Check {
+			check := &observabilityv1alpha1.Check{}
+			check.Spec.ResolutionMinutes = spec
+			check.Status.ResolutionMinutes = status
+			return check
+		}
+
+		DescribeTable("picks the interval",
+			func(check *observabilityv1alpha1.Check, expected time.Duration) {
+				Expect(r.requeueInterval(check)).To(Equal(expected))
+			},
+			Entry("default without resolution", withResolution(nil, nil), 2*time.Minute),
+			Entry("default when resolution is shorter",
+				withResolution(nil, ptrI32(1)), 2*time.Minute),
+			Entry("resolution from status when longer",
+				withResolution(ptrI32(1), ptrI32(60)), 60*time.Minute),
+			Entry("resolution from spec before the check is read",
+				withResolution(ptrI32(15), nil), 15*time.Minute),
+		)
+
+		It("uses sync interval if set", func() {
+			check := withResolution(nil, ptrI32(60))
+			check.Spec.SyncInterval = &metav1.Duration{Duration: 30 * time.Second}
+			Expect(r.requeueInterval(check)).To(Equal(30 * time.Second))
+		})
+
+		It("falls back to the built-in default", func() {
+			Expect((&CheckReconciler{}).requeueInterval(withResolution(nil, nil))).
+				To(Equal(DefaultRequeueInterval))
+		})
+	})
+})
@@ -0,0 +1,177 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"sort"
+
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+)
+
+// checkDefaults are parameters of a CheckDefaults or ClusterCheckDefaults
+// along with the kind and name of the object they come from
+type checkDefaults struct {
+	kind, name string
+	spec       *observabilityv1alpha1.CheckDefaultsSpec
+}
+
+/*
+listCheckDefaults returns CheckDefaults in given namespace followed by all
+ClusterCheckDefaults, each sorted by name, which is the order they are
+applied to Checks in.
+*/
+func listCheckDefaults(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+) ([]checkDefaults, error) {
+	var namespaced observabilityv1alpha1.CheckDefaultsList
+	if err := c.List(ctx, &namespaced, client.InNamespace(namespace)); err != nil {
+		return nil, microerror.Maskf(err, "unable to list CheckDefaults")
+	}
+	var cluster observabilityv1alpha1.ClusterCheckDefaultsList
+	if err := c.List(ctx, &cluster); err != nil {
+		return nil, microerror.Maskf(err, "unable to list ClusterCheckDefaults")
+	}
+
+	sort.Slice(namespaced.Items, func(i, j int) bool {
+		return namespaced.Items[i].GetName() < namespaced.Items[j].GetName()
+	})
+	sort.Slice(cluster.Items, func(i, j int) bool {
+		return cluster.Items[i].GetName() < cluster.Items[j].GetName()
+	})
+	defaults := make([]checkDefaults, 0, len(namespaced.Items)+len(cluster.Items))
+	for i := range namespaced.Items {
+		defaults = append(defaults, checkDefaults{
+			kind: observabilityv1alpha1.CheckDefaultsKind,
+			name: namespaced.Items[i].GetName(),
+			spec: &namespaced.Items[i].Spec,
+		})
+	}
+	for i := range cluster.Items {
+		defaults = append(defaults, checkDefaults{
+			kind: observabilityv1alpha1.ClusterCheckDefaultsKind,
+			name: cluster.Items[i].GetName(),
+			spec: &cluster.Items[i].Spec,
+		})
+	}
+	return defaults, nil
+}
+
+/*
+applyCheckDefaults sets parameters the Check doesn't set to the value from
+the first of given defaults which sets them (in memory only) and records
+where each was taken from in the status.
+
+Credentials are applied as a whole, from the first defaults setting either a
+Secret or an account, and only if the Check sets neither.
+*/
+func applyCheckDefaults(check *observabilityv1alpha1.Check, defaults []checkDefaults) {
+	spec := &check.Spec
+	check.Status.DefaultedFields = nil
+	record := func(field string, from *checkDefaults) {
+		check.Status.DefaultedFields = append(
+			check.Status.DefaultedFields,
+			observabilityv1alpha1.DefaultedField{Field: field, Kind: from.kind, Name: from.name},
+		)
+	}
+
+	for i := range defaults {
+		from := &defaults[i]
+		if spec.ResolutionMinutes == nil && from.spec.ResolutionMinutes != nil {
+			resolution := *from.spec.ResolutionMinutes
+			spec.ResolutionMinutes = &resolution
+			record("resolutionMinutes", from)
+		}
+		if spec.Contacts == nil && from.spec.Contacts != nil {
+			spec.Contacts = append([]corev1.LocalObjectReference{}, from.spec.Contacts...)
+			record("contacts", from)
+		}
+		if spec.Teams == nil && from.spec.Teams != nil {
+			spec.Teams = append([]corev1.LocalObjectReference{}, from.spec.Teams...)
+			record("teams", from)
+		}
+		if spec.Tags == nil && from.spec.Tags != nil {
+			spec.Tags = append([]string{}, from.spec.Tags...)
+			record("tags", from)
+		}
+		if spec.CredentialsSecret.Name == "" && spec.AccountRef == nil {
+			switch {
+			case from.spec.CredentialsSecret != nil:
+				spec.CredentialsSecret = *from.spec.CredentialsSecret
+				record("credentialsSecret", from)
+			case from.spec.AccountRef != nil:
+				spec.AccountRef = from.spec.AccountRef.DeepCopy()
+				record("accountRef", from)
+			}
+		}
+	}
+}
+
+// requestsForCheckDefaults maps a CheckDefaults or ClusterCheckDefaults to
+// reconcile requests for all Checks it applies to.
+func (r *CheckReconciler) requestsForCheckDefaults(obj handler.MapObject) []reconcile.Request {
+	return r.requestsForChecks(context.Background(), obj.Meta.GetNamespace())
+}
+
+/*
+requestsForDefaulted returns reconcile requests for Checks in given namespace
+(or all namespaces if empty) which may take a reference to an object from
+CheckDefaults or ClusterCheckDefaults, i.e. all of them if any of the
+defaults applying to them references the object according to references.
+
+Checks are only indexed by references set in their spec, so this complements
+requestsForIndex for references taken from defaults.
+*/
+func (r *CheckReconciler) requestsForDefaulted(
+	ctx context.Context,
+	namespace string,
+	references func(*observabilityv1alpha1.CheckDefaultsSpec) bool,
+) []reconcile.Request {
+	var cluster observabilityv1alpha1.ClusterCheckDefaultsList
+	if err := r.List(ctx, &cluster); err != nil {
+		r.Log.Error(err, "unable to list ClusterCheckDefaults")
+		return nil
+	}
+	for i := range cluster.Items {
+		if references(&cluster.Items[i].Spec) {
+			return r.requestsForChecks(ctx, namespace)
+		}
+	}
+
+	var namespaced observabilityv1alpha1.CheckDefaultsList
+	if err := r.List(ctx, &namespaced, client.InNamespace(namespace)); err != nil {
+		r.Log.Error(err, "unable to list CheckDefaults", "namespace", namespace)
+		return nil
+	}
+	requests := []reconcile.Request{}
+	matched := map[string]bool{}
+	for i := range namespaced.Items {
+		defaultsNamespace := namespaced.Items[i].GetNamespace()
+		if !matched[defaultsNamespace] && references(&namespaced.Items[i].Spec) {
+			matched[defaultsNamespace] = true
+			requests = append(requests, r.requestsForChecks(ctx, defaultsNamespace)...)
+		}
+	}
+	return requests
+}
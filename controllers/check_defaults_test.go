@@ -0,0 +1,171 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/finalizer"
+)
+
+var _ = Describe("CheckDefaults", func() {
+	var c client.Client
+
+	namespaced := func(name string, spec observabilityv1alpha1.CheckDefaultsSpec) runtime.Object {
+		return &observabilityv1alpha1.CheckDefaults{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       spec,
+		}
+	}
+	cluster := func(name string, spec observabilityv1alpha1.CheckDefaultsSpec) runtime.Object {
+		return &observabilityv1alpha1.ClusterCheckDefaults{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       spec,
+		}
+	}
+	withObjects := func(objs ...runtime.Object) {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(observabilityv1alpha1.AddToScheme(scheme)).To(Succeed())
+		c = fake.NewFakeClientWithScheme(scheme, objs...)
+	}
+	applied := func(check *observabilityv1alpha1.Check) *observabilityv1alpha1.Check {
+		defaults, err := listCheckDefaults(context.Background(), c, "default")
+		Expect(err).NotTo(HaveOccurred())
+		applyCheckDefaults(check, defaults)
+		return check
+	}
+
+	It("sets parameters which are not set and records where from", func() {
+		withObjects(
+			namespaced("team", observabilityv1alpha1.CheckDefaultsSpec{
+				Contacts: []corev1.LocalObjectReference{{Name: "ops"}},
+			}),
+			cluster("global", observabilityv1alpha1.CheckDefaultsSpec{
+				ResolutionMinutes: ptrI32(5),
+				Contacts:          []corev1.LocalObjectReference{{Name: "everyone"}},
+				Tags:              []string{"managed"},
+				CredentialsSecret: &corev1.LocalObjectReference{Name: "creds"},
+			}),
+		)
+
+		check := applied(&observabilityv1alpha1.Check{})
+		Expect(*check.Spec.ResolutionMinutes).To(BeEquivalentTo(5))
+		Expect(check.Spec.Contacts).To(Equal([]corev1.LocalObjectReference{{Name: "ops"}}))
+		Expect(check.Spec.CredentialsSecret.Name).To(Equal("creds"))
+		Expect(check.Spec.Tags).To(Equal([]string{"managed"}))
+		Expect(check.Status.DefaultedFields).To(Equal([]observabilityv1alpha1.DefaultedField{
+			{Field: "contacts", Kind: observabilityv1alpha1.CheckDefaultsKind, Name: "team"},
+			{Field: "resolutionMinutes", Kind: observabilityv1alpha1.ClusterCheckDefaultsKind, Name: "global"},
+			{Field: "tags", Kind: observabilityv1alpha1.ClusterCheckDefaultsKind, Name: "global"},
+			{Field: "credentialsSecret", Kind: observabilityv1alpha1.ClusterCheckDefaultsKind, Name: "global"},
+		}))
+	})
+
+	It("keeps parameters which are set", func() {
+		withObjects(namespaced("team", observabilityv1alpha1.CheckDefaultsSpec{
+			ResolutionMinutes: ptrI32(5),
+			Teams:             []corev1.LocalObjectReference{{Name: "on-call"}},
+			CredentialsSecret: &corev1.LocalObjectReference{Name: "creds"},
+		}))
+
+		check := &observabilityv1alpha1.Check{}
+		check.Spec.ResolutionMinutes = ptrI32(1)
+		check.Spec.Teams = []corev1.LocalObjectReference{}
+		check.Spec.AccountRef = &observabilityv1alpha1.AccountReference{Name: "account"}
+		check.Status.DefaultedFields = []observabilityv1alpha1.DefaultedField{{Field: "stale"}}
+		applied(check)
+		Expect(*check.Spec.ResolutionMinutes).To(BeEquivalentTo(1))
+		Expect(check.Spec.Teams).To(BeEmpty())
+		Expect(check.Spec.CredentialsSecret.Name).To(BeEmpty())
+		Expect(check.Status.DefaultedFields).To(BeNil())
+	})
+
+	It("applies defaults in order of their names", func() {
+		withObjects(
+			namespaced("b", observabilityv1alpha1.CheckDefaultsSpec{ResolutionMinutes: ptrI32(2)}),
+			namespaced("a", observabilityv1alpha1.CheckDefaultsSpec{ResolutionMinutes: ptrI32(1)}),
+		)
+		Expect(*applied(&observabilityv1alpha1.Check{}).Spec.ResolutionMinutes).To(BeEquivalentTo(1))
+	})
+
+	It("are not persisted when the finalizer is attached", func() {
+		nsName := types.NamespacedName{Namespace: "default", Name: "web"}
+		withObjects(
+			&observabilityv1alpha1.Check{
+				ObjectMeta: metav1.ObjectMeta{Name: nsName.Name, Namespace: nsName.Namespace},
+			},
+			namespaced("team", observabilityv1alpha1.CheckDefaultsSpec{ResolutionMinutes: ptrI32(5)}),
+		)
+		var check observabilityv1alpha1.Check
+		Expect(c.Get(context.Background(), nsName, &check)).To(Succeed())
+		stored := check.DeepCopy()
+		applied(&check)
+
+		finalizerMgr := finalizer.New(zap.Logger(true), c, &finalizingReconciler{})
+		Expect(updateFinalizers(
+			context.Background(), finalizerMgr.EnsureAttached, &check, stored,
+		)).To(Succeed())
+		Expect(check.GetFinalizers()).To(HaveLen(1))
+		Expect(*check.Spec.ResolutionMinutes).To(BeEquivalentTo(5))
+		Expect(check.Status.DefaultedFields).NotTo(BeEmpty())
+
+		var persisted observabilityv1alpha1.Check
+		Expect(c.Get(context.Background(), nsName, &persisted)).To(Succeed())
+		Expect(persisted.GetFinalizers()).To(Equal(check.GetFinalizers()))
+		Expect(persisted.GetResourceVersion()).To(Equal(check.GetResourceVersion()))
+		Expect(persisted.Spec.ResolutionMinutes).To(BeNil())
+	})
+
+	It("maps objects referenced from defaults to Checks in the namespace", func() {
+		check := func(namespace string) runtime.Object {
+			return &observabilityv1alpha1.Check{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: namespace},
+			}
+		}
+		withObjects(
+			check("default"), check("other"),
+			namespaced("team", observabilityv1alpha1.CheckDefaultsSpec{
+				Contacts: []corev1.LocalObjectReference{{Name: "ops"}},
+			}),
+		)
+		r := &CheckReconciler{Client: c, Log: zap.Logger(true)}
+		referencesOps := func(defaults *observabilityv1alpha1.CheckDefaultsSpec) bool {
+			return hasRef(defaults.Contacts, "ops")
+		}
+
+		Expect(r.requestsForDefaulted(context.Background(), "default", referencesOps)).To(ConsistOf(
+			ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "web"}},
+		))
+		Expect(r.requestsForDefaulted(context.Background(), "other", referencesOps)).To(BeEmpty())
+		Expect(r.requestsForDefaulted(context.Background(), "", referencesOps)).To(ConsistOf(
+			ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "web"}},
+		))
+	})
+})
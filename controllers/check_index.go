@@ -0,0 +1,260 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+)
+
+// Fields Checks are indexed by, so they can be looked up when objects they
+// depend on change
+const (
+	// checkSecretsField indexes Checks by names of Secrets (in the Check's
+	// namespace) they reference directly, for Pingdom API credentials or
+	// HTTP authentication
+	checkSecretsField = ".spec.secretNames"
+
+	// checkAccountField indexes Checks by the account they reference, see
+	// accountIndexKey
+	checkAccountField = ".spec.accountRef"
+
+	// checkContactsField indexes Checks by names of PingdomContacts (in the
+	// Check's namespace) they alert
+	checkContactsField = ".spec.contacts"
+
+	// checkTeamsField indexes Checks by names of PingdomTeams (in the Check's
+	// namespace) they alert
+	checkTeamsField = ".spec.teams"
+)
+
+// checkSecretNames extracts names of Secrets referenced by a Check, for the
+// checkSecretsField index.
+func checkSecretNames(obj runtime.Object) []string {
+	check := obj.(*observabilityv1alpha1.Check)
+	names := []string{}
+	if check.Spec.CredentialsSecret.Name != "" {
+		names = append(names, check.Spec.CredentialsSecret.Name)
+	}
+	if check.Spec.Auth != nil && check.Spec.Auth.SecretRef.Name != "" {
+		names = append(names, check.Spec.Auth.SecretRef.Name)
+	}
+	return names
+}
+
+// checkAccount extracts the account referenced by a Check, for the
+// checkAccountField index.
+func checkAccount(obj runtime.Object) []string {
+	check := obj.(*observabilityv1alpha1.Check)
+	if check.Spec.AccountRef == nil {
+		return nil
+	}
+	return []string{accountIndexKey(check.Spec.AccountRef.Kind, check.Spec.AccountRef.Name)}
+}
+
+// checkContacts extracts names of PingdomContacts referenced by a Check, for
+// the checkContactsField index.
+func checkContacts(obj runtime.Object) []string {
+	return refNames(obj.(*observabilityv1alpha1.Check).Spec.Contacts)
+}
+
+// checkTeams extracts names of PingdomTeams referenced by a Check, for the
+// checkTeamsField index.
+func checkTeams(obj runtime.Object) []string {
+	return refNames(obj.(*observabilityv1alpha1.Check).Spec.Teams)
+}
+
+func refNames(refs []corev1.LocalObjectReference) []string {
+	names := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		names = append(names, ref.Name)
+	}
+	return names
+}
+
+// hasRef returns true if given references include one to given name.
+func hasRef(refs []corev1.LocalObjectReference, name string) bool {
+	for _, ref := range refs {
+		if ref.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// accountIndexKey returns the value an account of given kind and name is
+// indexed by in the checkAccountField index.
+func accountIndexKey(kind, name string) string {
+	if kind == "" {
+		kind = observabilityv1alpha1.PingdomAccountKind
+	}
+	return kind + "/" + name
+}
+
+/*
+requestsForSecret maps a Secret to reconcile requests for Checks using it,
+either directly or through a PingdomAccount or ClusterPingdomAccount, set in
+their spec or taken from defaults.
+
+It also drops the cached Pingdom client for the Secret, as any event means it
+changed or is gone, removing the account state too if the Secret is deleted.
+*/
+func (r *CheckReconciler) requestsForSecret(obj handler.MapObject) []reconcile.Request {
+	ctx := context.Background()
+	namespace, name := obj.Meta.GetNamespace(), obj.Meta.GetName()
+	log := r.Log.WithValues("secret", types.NamespacedName{Namespace: namespace, Name: name})
+
+	var secret corev1.Secret
+	err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &secret)
+	if apierrors.IsNotFound(err) {
+		r.PdClients.Remove(obj.Meta.GetUID())
+	} else {
+		r.PdClients.Invalidate(obj.Meta.GetUID())
+	}
+
+	requests := r.requestsForIndex(ctx, namespace, checkSecretsField, name)
+	requests = append(requests, r.requestsForDefaulted(
+		ctx, namespace, func(defaults *observabilityv1alpha1.CheckDefaultsSpec) bool {
+			return defaults.CredentialsSecret != nil && defaults.CredentialsSecret.Name == name
+		},
+	)...)
+
+	var accounts observabilityv1alpha1.PingdomAccountList
+	if err := r.List(ctx, &accounts, client.InNamespace(namespace)); err != nil {
+		log.Error(err, "unable to list PingdomAccounts using the Secret")
+	}
+	for _, account := range accounts.Items {
+		if account.Spec.SecretRef.Name == name {
+			requests = append(requests, r.requestsForAccountKey(
+				ctx, namespace,
+				accountIndexKey(observabilityv1alpha1.PingdomAccountKind, account.GetName()),
+			)...)
+		}
+	}
+
+	var clusterAccounts observabilityv1alpha1.ClusterPingdomAccountList
+	if err := r.List(ctx, &clusterAccounts); err != nil {
+		log.Error(err, "unable to list ClusterPingdomAccounts using the Secret")
+	}
+	for _, account := range clusterAccounts.Items {
+		if account.Spec.SecretRef.Namespace == namespace && account.Spec.SecretRef.Name == name {
+			requests = append(requests, r.requestsForAccountKey(
+				ctx, "",
+				accountIndexKey(observabilityv1alpha1.ClusterPingdomAccountKind, account.GetName()),
+			)...)
+		}
+	}
+
+	return requests
+}
+
+// requestsForAccount maps a PingdomAccount or ClusterPingdomAccount to
+// reconcile requests for Checks using it, set in their spec or taken from
+// defaults.
+func (r *CheckReconciler) requestsForAccount(obj handler.MapObject) []reconcile.Request {
+	ctx := context.Background()
+	kind, namespace := observabilityv1alpha1.PingdomAccountKind, obj.Meta.GetNamespace()
+	if _, ok := obj.Object.(*observabilityv1alpha1.ClusterPingdomAccount); ok {
+		kind, namespace = observabilityv1alpha1.ClusterPingdomAccountKind, ""
+	}
+	return r.requestsForAccountKey(ctx, namespace, accountIndexKey(kind, obj.Meta.GetName()))
+}
+
+// requestsForAccountKey returns reconcile requests for Checks in given
+// namespace (or all namespaces if empty) using the account with given
+// accountIndexKey, set in their spec or taken from defaults.
+func (r *CheckReconciler) requestsForAccountKey(
+	ctx context.Context,
+	namespace, key string,
+) []reconcile.Request {
+	requests := r.requestsForIndex(ctx, namespace, checkAccountField, key)
+	return append(requests, r.requestsForDefaulted(
+		ctx, namespace, func(defaults *observabilityv1alpha1.CheckDefaultsSpec) bool {
+			return defaults.AccountRef != nil &&
+				accountIndexKey(defaults.AccountRef.Kind, defaults.AccountRef.Name) == key
+		},
+	)...)
+}
+
+// requestsForContact maps a PingdomContact to reconcile requests for Checks
+// alerting it, set in their spec or taken from defaults.
+func (r *CheckReconciler) requestsForContact(obj handler.MapObject) []reconcile.Request {
+	ctx := context.Background()
+	namespace, name := obj.Meta.GetNamespace(), obj.Meta.GetName()
+	requests := r.requestsForIndex(ctx, namespace, checkContactsField, name)
+	return append(requests, r.requestsForDefaulted(
+		ctx, namespace, func(defaults *observabilityv1alpha1.CheckDefaultsSpec) bool {
+			return hasRef(defaults.Contacts, name)
+		},
+	)...)
+}
+
+// requestsForTeam maps a PingdomTeam to reconcile requests for Checks
+// alerting it, set in their spec or taken from defaults.
+func (r *CheckReconciler) requestsForTeam(obj handler.MapObject) []reconcile.Request {
+	ctx := context.Background()
+	namespace, name := obj.Meta.GetNamespace(), obj.Meta.GetName()
+	requests := r.requestsForIndex(ctx, namespace, checkTeamsField, name)
+	return append(requests, r.requestsForDefaulted(
+		ctx, namespace, func(defaults *observabilityv1alpha1.CheckDefaultsSpec) bool {
+			return hasRef(defaults.Teams, name)
+		},
+	)...)
+}
+
+// requestsForIndex returns reconcile requests for Checks in given namespace
+// (or all namespaces if empty) with the given value of an indexed field.
+func (r *CheckReconciler) requestsForIndex(
+	ctx context.Context,
+	namespace, field, value string,
+) []reconcile.Request {
+	return r.requestsForChecks(ctx, namespace, client.MatchingField(field, value))
+}
+
+// requestsForChecks returns reconcile requests for Checks in given namespace
+// (or all namespaces if empty) matching given list options.
+func (r *CheckReconciler) requestsForChecks(
+	ctx context.Context,
+	namespace string,
+	opts ...client.ListOption,
+) []reconcile.Request {
+	var checks observabilityv1alpha1.CheckList
+	err := r.List(ctx, &checks, append(opts, client.InNamespace(namespace))...)
+	if err != nil {
+		r.Log.Error(err, "unable to list Checks", "namespace", namespace)
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(checks.Items))
+	for _, check := range checks.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: check.GetNamespace(),
+				Name:      check.GetName(),
+			},
+		})
+	}
+	return requests
+}
@@ -0,0 +1,216 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+)
+
+/*
+indexedClient filters Checks listed by the fake client by field selectors
+using the index functions, like the manager's cache does, as the fake client
+ignores field selectors.
+*/
+type indexedClient struct {
+	client.Client
+}
+
+var checkIndexes = map[string]func(runtime.Object) []string{
+	checkSecretsField:  checkSecretNames,
+	checkAccountField:  checkAccount,
+	checkContactsField: checkContacts,
+	checkTeamsField:    checkTeams,
+}
+
+func (c *indexedClient) List(ctx context.Context, list runtime.Object, opts ...client.ListOption) error {
+	if err := c.Client.List(ctx, list, opts...); err != nil {
+		return err
+	}
+	listOpts := (&client.ListOptions{}).ApplyOptions(opts)
+	checks, ok := list.(*observabilityv1alpha1.CheckList)
+	if !ok || listOpts.FieldSelector == nil {
+		return nil
+	}
+	matching := []observabilityv1alpha1.Check{}
+	for _, check := range checks.Items {
+		if indexMatches(&check, listOpts.FieldSelector.Requirements()) {
+			matching = append(matching, check)
+		}
+	}
+	checks.Items = matching
+	return nil
+}
+
+func indexMatches(check *observabilityv1alpha1.Check, requirements []fields.Requirement) bool {
+	for _, requirement := range requirements {
+		found := false
+		for _, value := range checkIndexes[requirement.Field](check) {
+			found = found || value == requirement.Value
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+var _ = Describe("Check indexes", func() {
+	withSpec := func(spec observabilityv1alpha1.CheckSpec) *observabilityv1alpha1.Check {
+		return &observabilityv1alpha1.Check{Spec: spec}
+	}
+
+	DescribeTable("checkSecretNames",
+		func(check *observabilityv1alpha1.Check, expected []string) {
+			Expect(checkSecretNames(check)).To(Equal(expected))
+		},
+		Entry("with credentials secret", withSpec(observabilityv1alpha1.CheckSpec{
+			CredentialsSecret: corev1.LocalObjectReference{Name: "creds"},
+		}), []string{"creds"}),
+		Entry("with credentials and auth secrets", withSpec(observabilityv1alpha1.CheckSpec{
+			CredentialsSecret: corev1.LocalObjectReference{Name: "creds"},
+			Auth: &observabilityv1alpha1.HTTPAuth{
+				SecretRef: corev1.LocalObjectReference{Name: "auth"},
+			},
+		}), []string{"creds", "auth"}),
+		Entry("with an account", withSpec(observabilityv1alpha1.CheckSpec{
+			AccountRef: &observabilityv1alpha1.AccountReference{Name: "account"},
+		}), []string{}),
+	)
+
+	DescribeTable("checkAccount",
+		func(check *observabilityv1alpha1.Check, expected []string) {
+			Expect(checkAccount(check)).To(Equal(expected))
+		},
+		Entry("without an account", withSpec(observabilityv1alpha1.CheckSpec{
+			CredentialsSecret: corev1.LocalObjectReference{Name: "creds"},
+		}), nil),
+		Entry("with a default kind account", withSpec(observabilityv1alpha1.CheckSpec{
+			AccountRef: &observabilityv1alpha1.AccountReference{Name: "account"},
+		}), []string{"PingdomAccount/account"}),
+		Entry("with a cluster account", withSpec(observabilityv1alpha1.CheckSpec{
+			AccountRef: &observabilityv1alpha1.AccountReference{
+				Kind: observabilityv1alpha1.ClusterPingdomAccountKind, Name: "account",
+			},
+		}), []string{"ClusterPingdomAccount/account"}),
+	)
+
+	It("indexes contacts and teams", func() {
+		check := withSpec(observabilityv1alpha1.CheckSpec{
+			Contacts: []corev1.LocalObjectReference{{Name: "ops"}, {Name: "dev"}},
+			Teams:    []corev1.LocalObjectReference{{Name: "on-call"}},
+		})
+		Expect(checkContacts(check)).To(Equal([]string{"ops", "dev"}))
+		Expect(checkTeams(check)).To(Equal([]string{"on-call"}))
+		Expect(checkTeams(withSpec(observabilityv1alpha1.CheckSpec{}))).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Check watches", func() {
+	var r *CheckReconciler
+
+	withObjects := func(objs ...runtime.Object) {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(observabilityv1alpha1.AddToScheme(scheme)).To(Succeed())
+		r = &CheckReconciler{
+			Client:    &indexedClient{fake.NewFakeClientWithScheme(scheme, objs...)},
+			Log:       zap.Logger(true),
+			PdClients: pdclient.NewCache(pdclient.CacheConfig{}),
+		}
+	}
+	check := func(namespace, name string, spec observabilityv1alpha1.CheckSpec) runtime.Object {
+		return &observabilityv1alpha1.Check{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       spec,
+		}
+	}
+	request := func(namespace, name string) ctrl.Request {
+		return ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}
+	}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "default"}}
+	secretEvent := handler.MapObject{Meta: secret, Object: secret}
+
+	It("maps a Secret to Checks using it directly", func() {
+		withObjects(
+			check("default", "web", observabilityv1alpha1.CheckSpec{
+				CredentialsSecret: corev1.LocalObjectReference{Name: "creds"},
+			}),
+			check("default", "db", observabilityv1alpha1.CheckSpec{
+				CredentialsSecret: corev1.LocalObjectReference{Name: "other"},
+			}),
+		)
+		Expect(r.requestsForSecret(secretEvent)).To(ConsistOf(request("default", "web")))
+	})
+
+	It("maps a Secret to Checks using an account with it from defaults", func() {
+		withObjects(
+			&observabilityv1alpha1.PingdomAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"},
+				Spec: observabilityv1alpha1.PingdomAccountSpec{
+					SecretRef: corev1.SecretReference{Name: "creds"},
+				},
+			},
+			&observabilityv1alpha1.CheckDefaults{
+				ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: "default"},
+				Spec: observabilityv1alpha1.CheckDefaultsSpec{
+					AccountRef: &observabilityv1alpha1.AccountReference{Name: "main"},
+				},
+			},
+			check("default", "web", observabilityv1alpha1.CheckSpec{}),
+			check("other", "web", observabilityv1alpha1.CheckSpec{}),
+		)
+		Expect(r.requestsForSecret(secretEvent)).To(ConsistOf(request("default", "web")))
+	})
+
+	It("maps a Secret to Checks using a cluster account with it from defaults", func() {
+		withObjects(
+			&observabilityv1alpha1.ClusterPingdomAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: "global"},
+				Spec: observabilityv1alpha1.PingdomAccountSpec{
+					SecretRef: corev1.SecretReference{Name: "creds", Namespace: "default"},
+				},
+			},
+			&observabilityv1alpha1.ClusterCheckDefaults{
+				ObjectMeta: metav1.ObjectMeta{Name: "global"},
+				Spec: observabilityv1alpha1.CheckDefaultsSpec{
+					AccountRef: &observabilityv1alpha1.AccountReference{
+						Kind: observabilityv1alpha1.ClusterPingdomAccountKind, Name: "global",
+					},
+				},
+			},
+			check("other", "web", observabilityv1alpha1.CheckSpec{}),
+		)
+		Expect(r.requestsForSecret(secretEvent)).To(ConsistOf(request("other", "web")))
+	})
+})
@@ -0,0 +1,360 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/giantswarm/microerror"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/metrics"
+)
+
+// checkSetControllerName labels metrics of the CheckSet controller
+const checkSetControllerName = "checkset"
+
+// CheckSetReconciler reconciles a CheckSet object
+type CheckSetReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Recorder record.EventRecorder
+	Scheme   *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=checksets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=checksets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=checks,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+
+/*
+Reconcile renders the template of the CheckSet specified in the given request
+into a Check for each of its hosts, updating Checks when the template changes
+and deleting Checks of hosts removed from the set.
+*/
+func (r *CheckSetReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	log := r.Log.WithValues("resource", "checkset", "namespacedName", req.NamespacedName)
+	generated := &generatedChecks{Client: r.Client, scheme: r.Scheme, kind: "CheckSet"}
+
+	var set observabilityv1alpha1.CheckSet
+	if err := r.Get(ctx, req.NamespacedName, &set); err != nil {
+		// Checks of deleted sets are deleted by the garbage collector
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !set.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	defer r.updateStatus(ctx, log, generated, &set)
+
+	hosts, err := r.setHosts(ctx, &set)
+	if err != nil {
+		reason := "HostsFailed"
+		if apierrors.IsNotFound(err) {
+			// the set is reconciled again once the ConfigMap is created
+			reason = "ConfigMapNotFound"
+			err = nil
+		}
+		set.Status.SetCondition(
+			observabilityv1alpha1.Synced, corev1.ConditionFalse, reason, fmt.Sprint(err),
+		)
+		return ctrl.Result{}, err
+	}
+
+	desired, err := checkSetChecks(&set, hosts)
+	if err != nil {
+		// retrying won't help until the spec or ConfigMaps change
+		log.Info("invalid CheckSet", "error", err.Error())
+		set.Status.SetCondition(
+			observabilityv1alpha1.Synced, corev1.ConditionFalse, "Invalid", err.Error(),
+		)
+		return ctrl.Result{}, nil
+	}
+
+	if err := generated.sync(ctx, &set, desired); IsCheckInvalid(err) {
+		// retrying won't help until the object the Checks are generated from
+		// changes
+		log.Info("generated Checks are invalid", "reason", err.Error())
+		r.Recorder.Event(&set, corev1.EventTypeWarning, "Invalid", err.Error())
+		set.Status.SetCondition(
+			observabilityv1alpha1.Synced, corev1.ConditionFalse, "Invalid", err.Error(),
+		)
+		metrics.RecordReconcileError(checkSetControllerName, "Invalid")
+		return ctrl.Result{}, nil
+	} else if IsCheckNameConflict(err) {
+		// retrying won't help until the other Checks are gone
+		log.Info("names of generated Checks are taken", "reason", err.Error())
+		r.Recorder.Event(&set, corev1.EventTypeWarning, "NameConflict", err.Error())
+		set.Status.SetCondition(
+			observabilityv1alpha1.Synced, corev1.ConditionFalse, "NameConflict", err.Error(),
+		)
+		metrics.RecordReconcileError(checkSetControllerName, "NameConflict")
+		return ctrl.Result{}, nil
+	} else if err != nil {
+		r.Recorder.Eventf(
+			&set, corev1.EventTypeWarning, "ChecksFailed",
+			"Unable to generate Checks: %v", err,
+		)
+		set.Status.SetCondition(
+			observabilityv1alpha1.Synced, corev1.ConditionFalse, "ChecksFailed", err.Error(),
+		)
+		metrics.RecordReconcileError(checkSetControllerName, "ChecksFailed")
+		return ctrl.Result{}, err
+	}
+	set.Status.SetCondition(
+		observabilityv1alpha1.Synced, corev1.ConditionTrue,
+		"Synced", fmt.Sprintf("%d Checks match the template", len(desired)),
+	)
+	set.Status.ObservedGeneration = set.GetGeneration()
+	return ctrl.Result{}, nil
+}
+
+/*
+setHosts returns hosts of the CheckSet, the ones listed in its spec followed
+by the ones from ConfigMaps, sorted by name within each ConfigMap.
+*/
+func (r *CheckSetReconciler) setHosts(
+	ctx context.Context,
+	set *observabilityv1alpha1.CheckSet,
+) ([]observabilityv1alpha1.CheckSetHost, error) {
+	hosts := append([]observabilityv1alpha1.CheckSetHost{}, set.Spec.Hosts...)
+	for _, source := range set.Spec.HostsFrom {
+		var configMaps []corev1.ConfigMap
+		switch {
+		case source.ConfigMapRef != nil:
+			var configMap corev1.ConfigMap
+			nsName := types.NamespacedName{Namespace: set.GetNamespace(), Name: source.ConfigMapRef.Name}
+			if err := r.Get(ctx, nsName, &configMap); err != nil {
+				return nil, err
+			}
+			configMaps = append(configMaps, configMap)
+		case source.ConfigMapSelector != nil:
+			selector, err := metav1.LabelSelectorAsSelector(source.ConfigMapSelector)
+			if err != nil {
+				return nil, microerror.Maskf(err, "invalid `configMapSelector`")
+			}
+			var list corev1.ConfigMapList
+			if err := r.List(
+				ctx, &list, client.InNamespace(set.GetNamespace()), matchingSelector{selector},
+			); err != nil {
+				return nil, microerror.Maskf(err, "unable to list ConfigMaps")
+			}
+			sort.Slice(list.Items, func(i, j int) bool {
+				return list.Items[i].GetName() < list.Items[j].GetName()
+			})
+			configMaps = append(configMaps, list.Items...)
+		}
+		for _, configMap := range configMaps {
+			hosts = append(hosts, configMapHosts(&configMap)...)
+		}
+	}
+	return hosts, nil
+}
+
+// configMapHosts returns hosts in the data of a ConfigMap, which maps names
+// of hosts to the hosts, sorted by name.
+func configMapHosts(configMap *corev1.ConfigMap) []observabilityv1alpha1.CheckSetHost {
+	hosts := make([]observabilityv1alpha1.CheckSetHost, 0, len(configMap.Data))
+	for name, host := range configMap.Data {
+		hosts = append(hosts, observabilityv1alpha1.CheckSetHost{Name: name, Host: host})
+	}
+	sort.Slice(hosts, func(i, j int) bool { return hosts[i].Name < hosts[j].Name })
+	return hosts
+}
+
+// matchingSelector filters a list by a label selector, including match
+// expressions, which client.MatchingLabels doesn't support.
+type matchingSelector struct {
+	labels.Selector
+}
+
+func (m matchingSelector) ApplyToList(opts *client.ListOptions) {
+	opts.LabelSelector = m.Selector
+}
+
+/*
+checkSetChecks renders the template of the CheckSet into a Check for each of
+given hosts, named `<set name>-<host name>`, or as other generated Checks
+(see generatedCheckName) if that's not a valid name, e.g. when the host name
+is a ConfigMap key with capital letters. Names of hosts must be unique.
+*/
+func checkSetChecks(
+	set *observabilityv1alpha1.CheckSet,
+	hosts []observabilityv1alpha1.CheckSetHost,
+) ([]observabilityv1alpha1.Check, error) {
+	template := &set.Spec.Template
+	checks := make([]observabilityv1alpha1.Check, 0, len(hosts))
+	seen := map[string]bool{}
+	for _, host := range hosts {
+		if host.Name == "" || host.Host == "" {
+			return nil, fmt.Errorf("hosts must have a name and a host, got %+v", host)
+		}
+		if seen[host.Name] {
+			return nil, fmt.Errorf("duplicate host name %q", host.Name)
+		}
+		seen[host.Name] = true
+
+		check := observabilityv1alpha1.Check{}
+		checkName := set.GetName() + "-" + host.Name
+		if len(validation.IsDNS1123Subdomain(checkName)) > 0 {
+			checkName = generatedCheckName("CheckSet", set.GetName(), host.Name)
+		}
+		check.SetName(checkName)
+		check.SetLabels(template.Labels)
+		check.Spec = *template.Spec.DeepCopy()
+		check.Spec.Host = host.Host
+		if template.Spec.Name != nil {
+			name := *template.Spec.Name + "-" + host.Name
+			check.Spec.Name = &name
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+/*
+updateStatus counts the Checks of the CheckSet and how many of them are
+Ready, sets the Ready condition and updates the status subresource.
+*/
+func (r *CheckSetReconciler) updateStatus(
+	ctx context.Context,
+	log logr.Logger,
+	generated *generatedChecks,
+	set *observabilityv1alpha1.CheckSet,
+) {
+	log = log.WithValues("action", "updateStatus")
+	checks, err := generated.owned(ctx, set)
+	if err != nil {
+		log.Error(err, "unable to count Checks")
+		return
+	}
+	set.Status.Checks = int32(len(checks))
+	set.Status.ReadyChecks = 0
+	for _, check := range checks {
+		if ready := check.Status.GetCondition(observabilityv1alpha1.Ready); ready != nil &&
+			ready.Status == corev1.ConditionTrue {
+			set.Status.ReadyChecks++
+		}
+	}
+
+	synced := set.Status.GetCondition(observabilityv1alpha1.Synced)
+	switch {
+	case synced == nil || synced.Status != corev1.ConditionTrue:
+		reason, message := "Reconciling", "Synced condition is not known yet"
+		if synced != nil {
+			reason, message = synced.Reason, synced.Message
+		}
+		set.Status.SetCondition(observabilityv1alpha1.Ready, corev1.ConditionFalse, reason, message)
+	case set.Status.ReadyChecks < set.Status.Checks:
+		set.Status.SetCondition(
+			observabilityv1alpha1.Ready, corev1.ConditionFalse, "ChecksNotReady", fmt.Sprintf(
+				"%d of %d Checks are Ready", set.Status.ReadyChecks, set.Status.Checks,
+			),
+		)
+	default:
+		set.Status.SetCondition(
+			observabilityv1alpha1.Ready, corev1.ConditionTrue, "ChecksReady", "All Checks are Ready",
+		)
+	}
+
+	if err := r.Status().Update(ctx, set); err != nil {
+		log.Error(err, "unable to update object status")
+		return
+	}
+	log.V(1).Info("updated object status")
+}
+
+// setUsesConfigMap returns true if a CheckSet reads hosts from the ConfigMap
+// with given metadata.
+func setUsesConfigMap(set *observabilityv1alpha1.CheckSet, configMap metav1.Object) bool {
+	if configMap.GetNamespace() != set.GetNamespace() {
+		return false
+	}
+	for _, source := range set.Spec.HostsFrom {
+		if source.ConfigMapRef != nil && source.ConfigMapRef.Name == configMap.GetName() {
+			return true
+		}
+		if source.ConfigMapSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(source.ConfigMapSelector)
+			if err == nil && selector.Matches(labels.Set(configMap.GetLabels())) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// requestsForConfigMap maps a ConfigMap to reconcile requests for CheckSets
+// in its namespace reading hosts from it.
+func (r *CheckSetReconciler) requestsForConfigMap(obj handler.MapObject) []reconcile.Request {
+	ctx := context.Background()
+	var sets observabilityv1alpha1.CheckSetList
+	if err := r.List(ctx, &sets, client.InNamespace(obj.Meta.GetNamespace())); err != nil {
+		r.Log.Error(err, "unable to list CheckSets", "namespace", obj.Meta.GetNamespace())
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for i := range sets.Items {
+		if setUsesConfigMap(&sets.Items[i], obj.Meta) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: sets.Items[i].GetNamespace(),
+					Name:      sets.Items[i].GetName(),
+				},
+			})
+		}
+	}
+	return requests
+}
+
+/*
+SetupWithManager configures this reconciler to be triggered for events
+pertaining to specified resource kinds.
+
+Besides CheckSets it watches the Checks they own, to revert changes made to
+them directly and to keep count of the Ready ones, and ConfigMaps they read
+hosts from.
+*/
+func (r *CheckSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&observabilityv1alpha1.CheckSet{}).
+		Owns(&observabilityv1alpha1.Check{}).
+		Watches(
+			&source.Kind{Type: &corev1.ConfigMap{}},
+			&handler.EnqueueRequestsFromMapFunc{
+				ToRequests: handler.ToRequestsFunc(r.requestsForConfigMap),
+			},
+		).
+		Complete(r)
+}
@@ -0,0 +1,205 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+)
+
+var _ = Describe("CheckSet", func() {
+	var (
+		c   client.Client
+		set *observabilityv1alpha1.CheckSet
+	)
+	nsName := types.NamespacedName{Namespace: "default", Name: "sites"}
+
+	reconciler := func() *CheckSetReconciler {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(observabilityv1alpha1.AddToScheme(scheme)).To(Succeed())
+		return &CheckSetReconciler{
+			Client:   c,
+			Log:      zap.Logger(true),
+			Recorder: record.NewFakeRecorder(10),
+			Scheme:   scheme,
+		}
+	}
+	checkHosts := func() map[string]string {
+		var checks observabilityv1alpha1.CheckList
+		Expect(c.List(context.Background(), &checks, client.InNamespace("default"))).To(Succeed())
+		hosts := map[string]string{}
+		for _, check := range checks.Items {
+			hosts[check.GetName()] = check.Spec.Host
+		}
+		return hosts
+	}
+
+	BeforeEach(func() {
+		set = &observabilityv1alpha1.CheckSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "sites", Namespace: "default"},
+			Spec: observabilityv1alpha1.CheckSetSpec{
+				Template: observabilityv1alpha1.CheckSetTemplate{
+					Labels: map[string]string{"team": "web"},
+					Spec: observabilityv1alpha1.CheckSpec{
+						CheckParameters: observabilityv1alpha1.CheckParameters{
+							Name: ptrS("site"), Type: observabilityv1alpha1.HTTP,
+						},
+						CredentialsSecret: corev1.LocalObjectReference{Name: "creds"},
+					},
+				},
+				Hosts: []observabilityv1alpha1.CheckSetHost{{Name: "www", Host: "www.example.com"}},
+			},
+		}
+	})
+
+	It("renders the template for each host", func() {
+		checks, err := checkSetChecks(set, []observabilityv1alpha1.CheckSetHost{
+			{Name: "www", Host: "www.example.com"},
+			{Name: "shop", Host: "shop.example.com"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(checks).To(HaveLen(2))
+		Expect(checks[1].GetName()).To(Equal("sites-shop"))
+		Expect(checks[1].GetLabels()).To(Equal(map[string]string{"team": "web"}))
+		Expect(checks[1].Spec.Host).To(Equal("shop.example.com"))
+		Expect(*checks[1].Spec.Name).To(Equal("site-shop"))
+		Expect(checks[1].Spec.CredentialsSecret.Name).To(Equal("creds"))
+		Expect(set.Spec.Template.Spec.Host).To(BeEmpty())
+	})
+
+	It("generates names for host names not valid in Check names", func() {
+		checks, err := checkSetChecks(set, []observabilityv1alpha1.CheckSetHost{
+			{Name: "Shop_EU", Host: "shop.example.eu"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(checks[0].GetName()).To(Equal(generatedCheckName("CheckSet", "sites", "Shop_EU")))
+		Expect(validation.IsDNS1123Subdomain(checks[0].GetName())).To(BeEmpty())
+		Expect(*checks[0].Spec.Name).To(Equal("site-Shop_EU"))
+	})
+
+	It("rejects duplicate host names", func() {
+		_, err := checkSetChecks(set, []observabilityv1alpha1.CheckSetHost{
+			{Name: "www", Host: "www.example.com"},
+			{Name: "www", Host: "www.example.org"},
+		})
+		Expect(err).To(MatchError(ContainSubstring(`duplicate host name "www"`)))
+	})
+
+	It("generates Checks for hosts from ConfigMaps and prunes removed ones", func() {
+		set.Spec.HostsFrom = []observabilityv1alpha1.HostsSource{{
+			ConfigMapSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key: "hosts", Operator: metav1.LabelSelectorOpIn, Values: []string{"true"},
+				}},
+			},
+		}}
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "more", Namespace: "default", Labels: map[string]string{"hosts": "true"},
+			},
+			Data: map[string]string{"shop": "shop.example.com", "blog": "blog.example.com"},
+		}
+		other := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "other", Namespace: "default", Labels: map[string]string{"hosts": "false"},
+			},
+			Data: map[string]string{"wiki": "wiki.example.com"},
+		}
+		c = fake.NewFakeClientWithScheme(reconciler().Scheme, set, configMap, other)
+
+		_, err := reconciler().Reconcile(ctrl.Request{NamespacedName: nsName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(checkHosts()).To(Equal(map[string]string{
+			"sites-www":  "www.example.com",
+			"sites-blog": "blog.example.com",
+			"sites-shop": "shop.example.com",
+		}))
+		Expect(c.Get(context.Background(), nsName, set)).To(Succeed())
+		Expect(set.Status.Checks).To(BeEquivalentTo(3))
+		Expect(set.Status.GetCondition(observabilityv1alpha1.Synced).Status).
+			To(Equal(corev1.ConditionTrue))
+		Expect(set.Status.GetCondition(observabilityv1alpha1.Ready).Reason).
+			To(Equal("ChecksNotReady"))
+
+		delete(configMap.Data, "blog")
+		Expect(c.Update(context.Background(), configMap)).To(Succeed())
+		_, err = reconciler().Reconcile(ctrl.Request{NamespacedName: nsName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(checkHosts()).To(Equal(map[string]string{
+			"sites-www":  "www.example.com",
+			"sites-shop": "shop.example.com",
+		}))
+	})
+
+	It("reports Checks rejected by validation", func() {
+		set.Spec.Template.Spec.ShouldContain = ptrS("OK")
+		set.Spec.Template.Spec.ShouldNotContain = ptrS("Error")
+		c = fake.NewFakeClientWithScheme(reconciler().Scheme, set)
+
+		_, err := reconciler().Reconcile(ctrl.Request{NamespacedName: nsName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(checkHosts()).To(BeEmpty())
+		Expect(c.Get(context.Background(), nsName, set)).To(Succeed())
+		Expect(set.Status.GetCondition(observabilityv1alpha1.Synced).Reason).To(Equal("Invalid"))
+	})
+
+	It("waits for a missing ConfigMap", func() {
+		set.Spec.HostsFrom = []observabilityv1alpha1.HostsSource{{
+			ConfigMapRef: &corev1.LocalObjectReference{Name: "missing"},
+		}}
+		c = fake.NewFakeClientWithScheme(reconciler().Scheme, set)
+
+		_, err := reconciler().Reconcile(ctrl.Request{NamespacedName: nsName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(checkHosts()).To(BeEmpty())
+		Expect(c.Get(context.Background(), nsName, set)).To(Succeed())
+		Expect(set.Status.GetCondition(observabilityv1alpha1.Synced).Reason).
+			To(Equal("ConfigMapNotFound"))
+	})
+
+	It("maps ConfigMaps to CheckSets reading them", func() {
+		set.Spec.HostsFrom = []observabilityv1alpha1.HostsSource{{
+			ConfigMapRef: &corev1.LocalObjectReference{Name: "hosts"},
+		}}
+		c = fake.NewFakeClientWithScheme(reconciler().Scheme, set)
+		configMap := func(namespace, name string) handler.MapObject {
+			meta := &metav1.ObjectMeta{Namespace: namespace, Name: name}
+			return handler.MapObject{Meta: meta}
+		}
+
+		Expect(reconciler().requestsForConfigMap(configMap("default", "hosts"))).
+			To(ConsistOf(ctrl.Request{NamespacedName: nsName}))
+		Expect(reconciler().requestsForConfigMap(configMap("default", "other"))).To(BeEmpty())
+		Expect(reconciler().requestsForConfigMap(configMap("other", "hosts"))).To(BeEmpty())
+	})
+})
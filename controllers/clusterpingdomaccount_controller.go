@@ -0,0 +1,86 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/metrics"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+	checkreconciler "gitlab.com/mig4/pingdom-operator/controllers/resources/check"
+)
+
+// ClusterPingdomAccountReconciler reconciles a ClusterPingdomAccount object
+type ClusterPingdomAccountReconciler struct {
+	client.Client
+	Log logr.Logger
+
+	// PdAPIKey is the operator-wide Pingdom application key used unless the
+	// account specifies one
+	PdAPIKey string
+
+	// PdClients is the cache of Pingdom API clients shared by all
+	// reconcilers
+	PdClients *pdclient.Cache
+}
+
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=clusterpingdomaccounts,verbs=get;list;watch
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=clusterpingdomaccounts/status,verbs=get;update;patch
+
+// Reconcile validates credentials of the ClusterPingdomAccount specified in
+// the given request.
+func (r *ClusterPingdomAccountReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	log := r.Log.WithValues("resource", "clusterpingdomaccount", "namespacedName", req.NamespacedName)
+
+	var account observabilityv1alpha1.ClusterPingdomAccount
+	if err := r.Get(ctx, req.NamespacedName, &account); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	validator := &accountValidator{r.Client, r.PdAPIKey, r.PdClients}
+	validateErr := validator.validate(
+		ctx, log, &account.Spec, account.Spec.SecretRef.Namespace, &account.Status,
+	)
+	account.Status.ObservedGeneration = account.GetGeneration()
+	if err := r.Status().Update(ctx, &account); err != nil {
+		metrics.RecordReconcileError("clusterpingdomaccount", "StatusUpdateFailed")
+		return ctrl.Result{}, microerror.Maskf(err, "unable to update object status")
+	}
+	if checkreconciler.IsRateLimitError(validateErr) {
+		metrics.RecordReconcileError("clusterpingdomaccount", "RateLimited")
+		return ctrl.Result{RequeueAfter: checkreconciler.RetryAfter(validateErr)}, nil
+	}
+	if validateErr != nil {
+		metrics.RecordReconcileError("clusterpingdomaccount", "APIError")
+		return ctrl.Result{}, validateErr
+	}
+	return ctrl.Result{RequeueAfter: accountRevalidateInterval}, nil
+}
+
+// SetupWithManager configures this reconciler to be triggered for events
+// pertaining to specified resource kinds.
+func (r *ClusterPingdomAccountReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&observabilityv1alpha1.ClusterPingdomAccount{}).
+		Complete(r)
+}
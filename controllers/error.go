@@ -0,0 +1,83 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import "github.com/giantswarm/microerror"
+
+// An error returned when the account referenced by a Check can't be found
+var accountNotFoundError = &microerror.Error{
+	Kind: "accountNotFoundError",
+}
+
+// IsAccountNotFound returns true if given error indicates the account
+// referenced by a Check can't be found.
+func IsAccountNotFound(err error) bool {
+	return microerror.Cause(err) == accountNotFoundError
+}
+
+/*
+An error returned when a PingdomContact or PingdomTeam referenced by a Check
+(or a PingdomTeam) doesn't exist or has no Pingdom identifier yet.
+*/
+var contactNotReadyError = &microerror.Error{
+	Kind: "contactNotReadyError",
+}
+
+// IsContactNotReady returns true if given error indicates a referenced
+// PingdomContact or PingdomTeam is not ready to be used.
+func IsContactNotReady(err error) bool {
+	return microerror.Cause(err) == contactNotReadyError
+}
+
+// An error returned when a Check has no credentials, neither in its spec nor
+// from defaults
+var credentialsMissingError = &microerror.Error{
+	Kind: "credentialsMissingError",
+}
+
+// IsCredentialsMissing returns true if given error indicates a Check has no
+// credentials to use.
+func IsCredentialsMissing(err error) bool {
+	return microerror.Cause(err) == credentialsMissingError
+}
+
+/*
+An error returned when names of Checks generated from an object are taken by
+Checks not generated from it, e.g. created by hand
+*/
+var checkNameConflictError = &microerror.Error{
+	Kind: "checkNameConflictError",
+}
+
+// IsCheckNameConflict returns true if given error indicates names of
+// generated Checks are taken by other Checks.
+func IsCheckNameConflict(err error) bool {
+	return microerror.Cause(err) == checkNameConflictError
+}
+
+/*
+An error returned when Checks generated from an object are rejected by the
+validation the Check webhook applies, e.g. because of invalid annotations
+*/
+var checkInvalidError = &microerror.Error{
+	Kind: "checkInvalidError",
+}
+
+// IsCheckInvalid returns true if given error indicates generated Checks are
+// invalid.
+func IsCheckInvalid(err error) bool {
+	return microerror.Cause(err) == checkInvalidError
+}
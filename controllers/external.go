@@ -0,0 +1,227 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/finalizer"
+	"gitlab.com/mig4/pingdom-operator/controllers/metrics"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+	"gitlab.com/mig4/pingdom-operator/controllers/resources"
+	checkreconciler "gitlab.com/mig4/pingdom-operator/controllers/resources/check"
+)
+
+/*
+externalSync runs a ResourceReconciler for an object maintaining a Pingdom
+resource other than a check (e.g. a maintenance window), recording the outcome
+in conditions of the object's status.
+*/
+type externalSync struct {
+	client.Client
+	log logr.Logger
+
+	// controller labels metrics of the controller
+	controller string
+
+	// resyncInterval is the interval before the next reconcile after a
+	// successful one
+	resyncInterval time.Duration
+}
+
+/*
+credentialsClient returns a Pingdom API client for an object's credentials,
+see credentialsClient, setting the CredentialsValid condition on the status.
+*/
+func (s *externalSync) credentialsClient(
+	ctx context.Context,
+	obj runtime.Object,
+	status conditionsStatus,
+	secretName string,
+	ref *observabilityv1alpha1.AccountReference,
+	defaultAppKey string,
+	clients *pdclient.Cache,
+) (*pdclient.Client, error) {
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	pdClient, _, err := credentialsClient(
+		ctx, s.Client, clients, objMeta.GetNamespace(), secretName, ref, defaultAppKey,
+	)
+	if err != nil {
+		s.log.Error(err, "Unable to initialise Pingdom client")
+		reason := "SecretInvalid"
+		if pdclient.IsAppKeyMissing(err) {
+			reason = "AppKeyMissing"
+		} else if IsAccountNotFound(err) {
+			reason = "AccountNotFound"
+		}
+		status.SetCondition(
+			observabilityv1alpha1.CredentialsValid, corev1.ConditionFalse,
+			reason, err.Error(),
+		)
+		metrics.RecordReconcileError(s.controller, reason)
+		return nil, err
+	}
+	status.SetCondition(
+		observabilityv1alpha1.CredentialsValid, corev1.ConditionTrue,
+		"Valid", "Pingdom API credentials are valid",
+	)
+	return pdClient, nil
+}
+
+/*
+run attaches the reconciler's finalizer to the object, refreshes and ensures
+state of the external resource and detaches the finalizer once the object is
+being deleted and the external resource is gone.
+
+observedGeneration is set to the object's generation when the external
+resource matches the spec.
+*/
+func (s *externalSync) run(
+	ctx context.Context,
+	obj runtime.Object,
+	status conditionsStatus,
+	observedGeneration *int64,
+	reconciler resources.ResourceReconciler,
+) (ctrl.Result, error) {
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	finalizerMgr := finalizer.New(s.log, s.Client, reconciler)
+
+	// Updating the object overwrites its status with the one stored in Kube,
+	// so attach the finalizer to a copy to preserve the one we have
+	attached := obj.DeepCopyObject()
+	if err := finalizerMgr.EnsureAttached(ctx, attached); err != nil {
+		metrics.RecordReconcileError(s.controller, "FinalizerFailed")
+		return ctrl.Result{}, microerror.Maskf(err, "failure handling finalizer")
+	}
+	if attachedMeta, err := meta.Accessor(attached); err == nil {
+		objMeta.SetFinalizers(attachedMeta.GetFinalizers())
+		objMeta.SetResourceVersion(attachedMeta.GetResourceVersion())
+	}
+
+	if err := reconciler.RefreshState(ctx); err != nil {
+		return s.failed(status, err, "failure refreshing state of the external resource")
+	}
+	if err := reconciler.EnsureState(ctx); err != nil {
+		return s.failed(status, err, "failure reconciling external resource")
+	}
+	*observedGeneration = objMeta.GetGeneration()
+
+	if !objMeta.GetDeletionTimestamp().IsZero() {
+		if err := finalizerMgr.EnsureDetached(ctx, obj); err != nil {
+			metrics.RecordReconcileError(s.controller, "FinalizerFailed")
+			return ctrl.Result{}, microerror.Maskf(err, "failure handling finalizer")
+		}
+	}
+	return ctrl.Result{RequeueAfter: s.resyncInterval}, nil
+}
+
+/*
+failed returns the result of a reconcile which failed with given error from
+the resource reconciler: rate limited requests are retried once the limit
+resets, other errors are returned to be retried with a back off.
+*/
+func (s *externalSync) failed(
+	status conditionsStatus,
+	err error,
+	message string,
+) (ctrl.Result, error) {
+	if checkreconciler.IsRateLimitError(err) {
+		delay := checkreconciler.RetryAfter(err)
+		s.log.Info("Pingdom API request limit reached, scheduling next run", "nextIn", delay)
+		status.SetCondition(
+			observabilityv1alpha1.Synced, corev1.ConditionFalse,
+			"RateLimited", err.Error(),
+		)
+		metrics.RecordReconcileError(s.controller, "RateLimited")
+		return ctrl.Result{RequeueAfter: delay}, nil
+	}
+	if checkreconciler.IsUnauthorizedError(err) {
+		status.SetCondition(
+			observabilityv1alpha1.CredentialsValid, corev1.ConditionFalse,
+			"Unauthorized", err.Error(),
+		)
+	}
+	metrics.RecordReconcileError(s.controller, "SyncFailed")
+	return ctrl.Result{}, microerror.Maskf(err, message)
+}
+
+/*
+updateStatus updates the status subresource of the object, after setting the
+Ready condition based on other conditions; see CheckReconciler.updateStatus.
+*/
+func (s *externalSync) updateStatus(
+	ctx context.Context,
+	obj runtime.Object,
+	status conditionsStatus,
+	kind, readyMessage string,
+) {
+	log := s.log.WithValues("action", "updateStatus")
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		log.Error(err, "invalid object, cannot access ObjectMeta")
+		return
+	}
+	if !objMeta.GetDeletionTimestamp().IsZero() && len(objMeta.GetFinalizers()) == 0 {
+		log.V(1).Info("skip object status update as object is deleted")
+		return
+	}
+	setReadyConditionOn(status, kind, readyMessage)
+	if err := s.Status().Update(ctx, obj); err != nil {
+		log.Error(err, "unable to update object status")
+		return
+	}
+	log.V(1).Info("updated object status")
+}
+
+/*
+requireAPIVersion sets the Synced condition to false if the client doesn't use
+given version of Pingdom API, for resources only supported by that version,
+and returns whether it does.
+*/
+func requireAPIVersion(
+	pdClient *pdclient.Client,
+	status conditionsStatus,
+	apiVersion, kind string,
+) bool {
+	if pdClient.APIVersion == apiVersion {
+		return true
+	}
+	status.SetCondition(
+		observabilityv1alpha1.Synced, corev1.ConditionFalse,
+		"UnsupportedAPIVersion", fmt.Sprintf(
+			"%s requires Pingdom API %s, credentials use %s",
+			kind, apiVersion, pdClient.APIVersion,
+		),
+	)
+	return false
+}
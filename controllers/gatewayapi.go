@@ -0,0 +1,130 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+)
+
+/*
+Gateway API resources are read as unstructured objects and decoded into the
+subset of their types below, so the operator doesn't depend on the Gateway API
+module (and the Kubernetes version it requires); only the fields used to
+generate Checks are decoded.
+*/
+var (
+	httpRouteGVK = schema.GroupVersionKind{
+		Group: observabilityv1alpha1.GatewayAPIGroup, Version: "v1", Kind: "HTTPRoute",
+	}
+	gatewayGVK = schema.GroupVersionKind{
+		Group: observabilityv1alpha1.GatewayAPIGroup, Version: "v1", Kind: "Gateway",
+	}
+)
+
+// Gateway API listener protocols Checks are generated for
+const (
+	gatewayProtocolHTTP  = "HTTP"
+	gatewayProtocolHTTPS = "HTTPS"
+)
+
+// Gateway API path match types Checks are generated for
+const (
+	pathMatchExact      = "Exact"
+	pathMatchPathPrefix = "PathPrefix"
+)
+
+// httpRoute is the subset of a Gateway API HTTPRoute used to generate Checks
+type httpRoute struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              httpRouteSpec `json:"spec"`
+}
+
+type httpRouteSpec struct {
+	ParentRefs []parentReference `json:"parentRefs,omitempty"`
+	Hostnames  []string          `json:"hostnames,omitempty"`
+	Rules      []httpRouteRule   `json:"rules,omitempty"`
+}
+
+type parentReference struct {
+	Group       *string `json:"group,omitempty"`
+	Kind        *string `json:"kind,omitempty"`
+	Namespace   *string `json:"namespace,omitempty"`
+	Name        string  `json:"name"`
+	SectionName *string `json:"sectionName,omitempty"`
+}
+
+type httpRouteRule struct {
+	Matches []httpRouteMatch `json:"matches,omitempty"`
+}
+
+type httpRouteMatch struct {
+	Path *httpPathMatch `json:"path,omitempty"`
+}
+
+type httpPathMatch struct {
+	Type  *string `json:"type,omitempty"`
+	Value *string `json:"value,omitempty"`
+}
+
+// gateway is the subset of a Gateway API Gateway used to generate Checks
+type gateway struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              gatewaySpec `json:"spec"`
+}
+
+type gatewaySpec struct {
+	Listeners []listener `json:"listeners"`
+}
+
+type listener struct {
+	Name     string  `json:"name"`
+	Hostname *string `json:"hostname,omitempty"`
+	Port     int32   `json:"port"`
+	Protocol string  `json:"protocol"`
+}
+
+// newUnstructured returns an empty unstructured object of given kind.
+func newUnstructured(gvk schema.GroupVersionKind) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	return obj
+}
+
+// fromUnstructured decodes an unstructured object into one of the types
+// above.
+func fromUnstructured(obj *unstructured.Unstructured, into interface{}) error {
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), into)
+}
+
+// isGateway returns true if a parent reference refers to a Gateway.
+func (ref *parentReference) isGateway() bool {
+	return ((ref.Group == nil || *ref.Group == observabilityv1alpha1.GatewayAPIGroup) &&
+		(ref.Kind == nil || *ref.Kind == gatewayGVK.Kind))
+}
+
+// namespace returns the namespace of the parent, which defaults to the
+// route's.
+func (ref *parentReference) namespace(routeNamespace string) string {
+	if ref.Namespace != nil && *ref.Namespace != "" {
+		return *ref.Namespace
+	}
+	return routeNamespace
+}
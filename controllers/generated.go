@@ -0,0 +1,275 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+)
+
+// Maximum length of names of Kubernetes objects
+const maxNameLength = 253
+
+// generateChecks returns true if an object opted in to generating Checks.
+func generateChecks(obj metav1.Object) bool {
+	return obj.GetAnnotations()[observabilityv1alpha1.CheckAnnotation] == "true"
+}
+
+/*
+checkTemplate returns the spec generated Checks start from, with parameters
+set by annotations of the object they are generated from, see
+observabilityv1alpha1.CheckAnnotation.
+
+Credentials can be set with one of CredentialsSecretAnnotation and
+AccountAnnotation, otherwise they are taken from CheckDefaults.
+*/
+func checkTemplate(obj metav1.Object) (*observabilityv1alpha1.CheckSpec, error) {
+	annotations := obj.GetAnnotations()
+	spec := &observabilityv1alpha1.CheckSpec{}
+
+	secret, hasSecret := annotations[observabilityv1alpha1.CredentialsSecretAnnotation]
+	account, hasAccount := annotations[observabilityv1alpha1.AccountAnnotation]
+	switch {
+	case hasSecret && hasAccount:
+		return nil, fmt.Errorf(
+			"at most one of %s and %s annotations may be set",
+			observabilityv1alpha1.CredentialsSecretAnnotation,
+			observabilityv1alpha1.AccountAnnotation,
+		)
+	case hasSecret:
+		spec.CredentialsSecret = corev1.LocalObjectReference{Name: secret}
+	case hasAccount:
+		ref := &observabilityv1alpha1.AccountReference{Name: account}
+		if i := strings.Index(account, "/"); i >= 0 {
+			ref.Kind, ref.Name = account[:i], account[i+1:]
+			if ref.Kind != observabilityv1alpha1.PingdomAccountKind &&
+				ref.Kind != observabilityv1alpha1.ClusterPingdomAccountKind {
+				return nil, fmt.Errorf(
+					"invalid %s annotation: unknown account kind %q",
+					observabilityv1alpha1.AccountAnnotation, ref.Kind,
+				)
+			}
+		}
+		spec.AccountRef = ref
+	}
+
+	if value, ok := annotations[observabilityv1alpha1.ResolutionAnnotation]; ok {
+		resolution, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"invalid %s annotation: %v", observabilityv1alpha1.ResolutionAnnotation, err,
+			)
+		}
+		resolution32 := int32(resolution)
+		spec.ResolutionMinutes = &resolution32
+	}
+	spec.Contacts = annotationRefs(annotations[observabilityv1alpha1.ContactsAnnotation])
+	spec.Teams = annotationRefs(annotations[observabilityv1alpha1.TeamsAnnotation])
+	if value, ok := annotations[observabilityv1alpha1.ShouldContainAnnotation]; ok {
+		spec.ShouldContain = &value
+	}
+	if value, ok := annotations[observabilityv1alpha1.ShouldNotContainAnnotation]; ok {
+		spec.ShouldNotContain = &value
+	}
+	return spec, nil
+}
+
+// annotationRefs returns references to objects named in a comma separated
+// list.
+func annotationRefs(value string) []corev1.LocalObjectReference {
+	var refs []corev1.LocalObjectReference
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			refs = append(refs, corev1.LocalObjectReference{Name: name})
+		}
+	}
+	return refs
+}
+
+/*
+generatedCheckName returns the name of a Check generated from the object of
+given kind and name for the endpoint identified by parts, e.g. host and path:
+the object's name with a hash of its kind and the parts, so it's stable and
+unique for each endpoint, also when objects of different kinds with the same
+name generate Checks for the same endpoint (e.g. when migrating from an
+Ingress to an HTTPRoute).
+*/
+func generatedCheckName(ownerKind, ownerName string, parts ...string) string {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(strings.Join(append([]string{ownerKind}, parts...), "\x00")))
+	suffix := fmt.Sprintf("-%08x", hash.Sum32())
+	if len(ownerName)+len(suffix) > maxNameLength {
+		ownerName = ownerName[:maxNameLength-len(suffix)]
+	}
+	return ownerName + suffix
+}
+
+// hostMatches returns true if a host matches a pattern, which is either a
+// host name or a wildcard matching a single label, e.g. `*.example.com`.
+func hostMatches(pattern, host string) bool {
+	if pattern == host {
+		return true
+	}
+	if strings.HasPrefix(pattern, "*.") {
+		i := strings.Index(host, ".")
+		return i > 0 && host[i:] == pattern[1:]
+	}
+	return false
+}
+
+/*
+generatedChecks maintains Checks generated from an owner object: it creates
+or updates the desired Checks, owned by the owner and labelled with its kind
+with GeneratedByLabel, and deletes Checks it owns which are not desired any
+more. Owned Checks are deleted along with the owner by the garbage collector.
+
+Only names, labels and specs of desired Checks are used. Desired Checks are
+defaulted and validated like the Check webhook does, as they don't
+necessarily go through it. Desired Checks which are invalid are skipped,
+keeping the Check of the same name as is, and reported with a
+checkInvalidError once the others are in sync; ones whose name is taken by a
+Check the owner doesn't own are skipped and reported with a
+checkNameConflictError. Retrying won't help in either case until the source
+object or the other Check changes.
+*/
+type generatedChecks struct {
+	client.Client
+	scheme *runtime.Scheme
+
+	// kind of the owner object, set as the GeneratedByLabel
+	kind string
+}
+
+// owned returns the Checks owned by owner.
+func (g *generatedChecks) owned(
+	ctx context.Context,
+	owner metav1.Object,
+) ([]observabilityv1alpha1.Check, error) {
+	var checks observabilityv1alpha1.CheckList
+	if err := g.List(
+		ctx, &checks, client.InNamespace(owner.GetNamespace()),
+		client.MatchingLabels(map[string]string{observabilityv1alpha1.GeneratedByLabel: g.kind}),
+	); err != nil {
+		return nil, microerror.Maskf(err, "unable to list generated Checks")
+	}
+	owned := []observabilityv1alpha1.Check{}
+	for _, check := range checks.Items {
+		if metav1.IsControlledBy(&check, owner) {
+			owned = append(owned, check)
+		}
+	}
+	return owned, nil
+}
+
+// sync makes the Checks owned by owner match the desired ones.
+func (g *generatedChecks) sync(
+	ctx context.Context,
+	owner metav1.Object,
+	desired []observabilityv1alpha1.Check,
+) error {
+	checks, err := g.owned(ctx, owner)
+	if err != nil {
+		return err
+	}
+	existing := map[string]*observabilityv1alpha1.Check{}
+	for i := range checks {
+		existing[checks[i].GetName()] = &checks[i]
+	}
+
+	var conflicts, invalid []string
+	for i := range desired {
+		check := &desired[i]
+		check.SetNamespace(owner.GetNamespace())
+		labels := map[string]string{}
+		for key, value := range check.GetLabels() {
+			labels[key] = value
+		}
+		labels[observabilityv1alpha1.GeneratedByLabel] = g.kind
+		check.SetLabels(labels)
+		if err := controllerutil.SetControllerReference(owner, check, g.scheme); err != nil {
+			return microerror.Maskf(err, "unable to set owner of Check %s", check.GetName())
+		}
+		check.Default()
+
+		current, ok := existing[check.GetName()]
+		delete(existing, check.GetName())
+		if err := validateGenerated(check, current); err != nil {
+			invalid = append(invalid, fmt.Sprintf("%s: %v", check.GetName(), err))
+			continue
+		}
+		if !ok {
+			err := g.Create(ctx, check)
+			if apierrors.IsAlreadyExists(err) {
+				conflicts = append(conflicts, check.GetName())
+			} else if err != nil {
+				return microerror.Maskf(err, "unable to create Check %s", check.GetName())
+			}
+			continue
+		}
+		if equality.Semantic.DeepEqual(current.Spec, check.Spec) &&
+			equality.Semantic.DeepEqual(current.GetLabels(), check.GetLabels()) {
+			continue
+		}
+		current.Spec = check.Spec
+		current.SetLabels(check.GetLabels())
+		if err := g.Update(ctx, current); err != nil {
+			return microerror.Maskf(err, "unable to update Check %s", check.GetName())
+		}
+	}
+
+	for _, check := range existing {
+		if err := g.Delete(ctx, check); client.IgnoreNotFound(err) != nil {
+			return microerror.Maskf(err, "unable to delete Check %s", check.GetName())
+		}
+	}
+
+	if len(invalid) > 0 {
+		return microerror.Maskf(
+			checkInvalidError, "invalid Checks generated from %s %s: %s",
+			g.kind, owner.GetName(), strings.Join(invalid, "; "),
+		)
+	}
+	if len(conflicts) > 0 {
+		return microerror.Maskf(
+			checkNameConflictError, "Checks %s already exist and are not generated from %s %s",
+			strings.Join(conflicts, ", "), g.kind, owner.GetName(),
+		)
+	}
+	return nil
+}
+
+// validateGenerated validates a desired Check like the Check webhook would
+// on creating it, or on updating current if it's not nil.
+func validateGenerated(check, current *observabilityv1alpha1.Check) error {
+	if current == nil {
+		return check.ValidateCreate()
+	}
+	return check.ValidateUpdate(current)
+}
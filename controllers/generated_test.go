@@ -0,0 +1,237 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+)
+
+var _ = Describe("checkTemplate", func() {
+	withAnnotations := func(annotations map[string]string) metav1.Object {
+		return &metav1.ObjectMeta{Annotations: annotations}
+	}
+
+	It("sets parameters from annotations", func() {
+		spec, err := checkTemplate(withAnnotations(map[string]string{
+			observabilityv1alpha1.CredentialsSecretAnnotation: "creds",
+			observabilityv1alpha1.ResolutionAnnotation:        "5",
+			observabilityv1alpha1.ContactsAnnotation:          "ops, dev",
+			observabilityv1alpha1.TeamsAnnotation:             "on-call",
+			observabilityv1alpha1.ShouldContainAnnotation:     "OK",
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(spec).To(Equal(&observabilityv1alpha1.CheckSpec{
+			CheckParameters: observabilityv1alpha1.CheckParameters{
+				ResolutionMinutes: ptrI32(5),
+				ShouldContain:     ptrS("OK"),
+			},
+			CredentialsSecret: corev1.LocalObjectReference{Name: "creds"},
+			Contacts:          []corev1.LocalObjectReference{{Name: "ops"}, {Name: "dev"}},
+			Teams:             []corev1.LocalObjectReference{{Name: "on-call"}},
+		}))
+	})
+
+	DescribeTable("reads the account",
+		func(value string, expected *observabilityv1alpha1.AccountReference) {
+			spec, err := checkTemplate(withAnnotations(map[string]string{
+				observabilityv1alpha1.AccountAnnotation: value,
+			}))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spec.AccountRef).To(Equal(expected))
+		},
+		Entry("by name", "team", &observabilityv1alpha1.AccountReference{Name: "team"}),
+		Entry("by kind and name", "ClusterPingdomAccount/shared", &observabilityv1alpha1.AccountReference{
+			Kind: observabilityv1alpha1.ClusterPingdomAccountKind, Name: "shared",
+		}),
+	)
+
+	It("leaves credentials to CheckDefaults when not set", func() {
+		spec, err := checkTemplate(withAnnotations(map[string]string{}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(spec.CredentialsSecret.Name).To(BeEmpty())
+		Expect(spec.AccountRef).To(BeNil())
+	})
+
+	DescribeTable("rejects invalid annotations",
+		func(annotations map[string]string) {
+			_, err := checkTemplate(withAnnotations(annotations))
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("with both credentials", map[string]string{
+			observabilityv1alpha1.CredentialsSecretAnnotation: "creds",
+			observabilityv1alpha1.AccountAnnotation:           "team",
+		}),
+		Entry("with unknown account kind", map[string]string{
+			observabilityv1alpha1.AccountAnnotation: "Account/team",
+		}),
+		Entry("with invalid resolution", map[string]string{
+			observabilityv1alpha1.CredentialsSecretAnnotation: "creds",
+			observabilityv1alpha1.ResolutionAnnotation:        "often",
+		}),
+	)
+})
+
+var _ = Describe("generatedCheckName", func() {
+	It("is stable and unique for each endpoint", func() {
+		name := generatedCheckName("Ingress", "web", "example.com", "/")
+		Expect(name).To(HavePrefix("web-"))
+		Expect(generatedCheckName("Ingress", "web", "example.com", "/")).To(Equal(name))
+		Expect(generatedCheckName("Ingress", "web", "example.com", "/api")).NotTo(Equal(name))
+	})
+
+	It("is unique for each kind of owner", func() {
+		Expect(generatedCheckName("HTTPRoute", "web", "example.com", "/")).NotTo(
+			Equal(generatedCheckName("Ingress", "web", "example.com", "/")),
+		)
+	})
+
+	It("fits in the name length limit", func() {
+		long := string(make([]byte, maxNameLength))
+		Expect(generatedCheckName("Ingress", long, "example.com")).To(HaveLen(maxNameLength))
+	})
+})
+
+var _ = Describe("generatedChecks", func() {
+	var (
+		ctx       = context.Background()
+		c         client.Client
+		scheme    *runtime.Scheme
+		owner     *corev1.ConfigMap
+		generated *generatedChecks
+	)
+
+	checkFor := func(name, host string) observabilityv1alpha1.Check {
+		check := observabilityv1alpha1.Check{}
+		check.SetName(name)
+		check.Spec.Host = host
+		check.Spec.Type = observabilityv1alpha1.Ping
+		check.Spec.CredentialsSecret.Name = "creds"
+		return check
+	}
+	get := func(name string) (*observabilityv1alpha1.Check, error) {
+		check := &observabilityv1alpha1.Check{}
+		err := c.Get(ctx, types.NamespacedName{Namespace: "default", Name: name}, check)
+		return check, err
+	}
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(observabilityv1alpha1.AddToScheme(scheme)).To(Succeed())
+		owner = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "owner", Namespace: "default", UID: "owner-uid"},
+		}
+		c = fake.NewFakeClientWithScheme(scheme, owner)
+		generated = &generatedChecks{Client: c, scheme: scheme, kind: "ConfigMap"}
+	})
+
+	It("creates owned Checks", func() {
+		Expect(generated.sync(ctx, owner, []observabilityv1alpha1.Check{
+			checkFor("a", "a.example.com"),
+		})).To(Succeed())
+
+		check, err := get("a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(check.Spec.Host).To(Equal("a.example.com"))
+		Expect(check.GetLabels()).To(HaveKeyWithValue(observabilityv1alpha1.GeneratedByLabel, "ConfigMap"))
+		Expect(metav1.IsControlledBy(check, owner)).To(BeTrue())
+	})
+
+	It("updates changed Checks and deletes ones not desired any more", func() {
+		Expect(generated.sync(ctx, owner, []observabilityv1alpha1.Check{
+			checkFor("a", "a.example.com"), checkFor("b", "b.example.com"),
+		})).To(Succeed())
+		Expect(generated.sync(ctx, owner, []observabilityv1alpha1.Check{
+			checkFor("a", "moved.example.com"),
+		})).To(Succeed())
+
+		check, err := get("a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(check.Spec.Host).To(Equal("moved.example.com"))
+		_, err = get("b")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("reports names taken by Checks it doesn't own", func() {
+		other := checkFor("a", "other.example.com")
+		other.SetNamespace("default")
+		Expect(c.Create(ctx, &other)).To(Succeed())
+
+		err := generated.sync(ctx, owner, []observabilityv1alpha1.Check{
+			checkFor("a", "a.example.com"), checkFor("b", "b.example.com"),
+		})
+		Expect(IsCheckNameConflict(err)).To(BeTrue())
+		check, err := get("a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(check.Spec.Host).To(Equal("other.example.com"))
+		_, err = get("b")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("skips and reports invalid Checks", func() {
+		invalid := checkFor("b", "b.example.com")
+		invalid.Spec.Type = observabilityv1alpha1.TCP
+
+		err := generated.sync(ctx, owner, []observabilityv1alpha1.Check{
+			checkFor("a", "a.example.com"), invalid,
+		})
+		Expect(IsCheckInvalid(err)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring("b: check `Port` is required")))
+		_, err = get("a")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = get("b")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("keeps a Check the desired one can't replace", func() {
+		Expect(generated.sync(ctx, owner, []observabilityv1alpha1.Check{
+			checkFor("a", "a.example.com"),
+		})).To(Succeed())
+		changed := checkFor("a", "a.example.com")
+		changed.Spec.Type = observabilityv1alpha1.DNS
+
+		err := generated.sync(ctx, owner, []observabilityv1alpha1.Check{changed})
+		Expect(IsCheckInvalid(err)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring("cannot be changed")))
+		check, err := get("a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(check.Spec.Type).To(Equal(observabilityv1alpha1.Ping))
+	})
+
+	It("leaves Checks it doesn't own alone", func() {
+		other := checkFor("other", "other.example.com")
+		other.SetNamespace("default")
+		other.SetLabels(map[string]string{observabilityv1alpha1.GeneratedByLabel: "ConfigMap"})
+		Expect(c.Create(ctx, &other)).To(Succeed())
+
+		Expect(generated.sync(ctx, owner, nil)).To(Succeed())
+		_, err := get("other")
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
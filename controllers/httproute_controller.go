@@ -0,0 +1,471 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/metrics"
+)
+
+// httpRouteControllerName labels metrics of the HTTPRoute controller
+const httpRouteControllerName = "httproute"
+
+/*
+HTTPRouteReconciler generates Checks from Gateway API HTTPRoutes, which either
+opted in with annotations, like Ingresses, or are targeted by a
+PingdomCheckPolicy.
+
+It requires Gateway API CRDs to be installed, so it's only enabled on request.
+*/
+type HTTPRouteReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Recorder record.EventRecorder
+	Scheme   *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes;gateways,verbs=get;list;watch
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=pingdomcheckpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=pingdomcheckpolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=checks,verbs=get;list;watch;create;update;patch;delete
+
+/*
+Reconcile maintains an HTTP Check for each hostname and path of the HTTPRoute
+specified in the given request, using HTTPS when the route's Gateway listener
+for the hostname does, and records whether PingdomCheckPolicies targeting the
+route apply to it.
+
+Parameters of the Checks come from the oldest PingdomCheckPolicy targeting the
+route, or from its annotations (see observabilityv1alpha1.CheckAnnotation) if
+there is none.
+*/
+func (r *HTTPRouteReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	log := r.Log.WithValues("resource", "httproute", "namespacedName", req.NamespacedName)
+
+	policies, err := r.targetingPolicies(ctx, req.NamespacedName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	route := newUnstructured(httpRouteGVK)
+	if err := r.Get(ctx, req.NamespacedName, route); err != nil {
+		if apierrors.IsNotFound(err) {
+			// Checks of deleted routes are deleted by the garbage collector
+			r.updatePolicies(ctx, log, policies, false, nil)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !route.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+	var typed httpRoute
+	if err := fromUnstructured(route, &typed); err != nil {
+		return ctrl.Result{}, microerror.Maskf(err, "invalid HTTPRoute")
+	}
+
+	var template *observabilityv1alpha1.CheckSpec
+	switch {
+	case len(policies) > 0:
+		template, err = policyTemplate(&policies[0])
+	case generateChecks(route):
+		template, err = checkTemplate(route)
+	}
+	r.updatePolicies(ctx, log, policies, true, err)
+	if err != nil {
+		// retrying won't help until the policy or annotations change
+		log.Info("invalid Check parameters", "error", err.Error())
+		r.Recorder.Event(route, corev1.EventTypeWarning, "InvalidChecks", err.Error())
+		metrics.RecordReconcileError(httpRouteControllerName, "InvalidChecks")
+		return ctrl.Result{}, nil
+	}
+
+	var desired []observabilityv1alpha1.Check
+	if template != nil {
+		listeners, err := r.parentListeners(ctx, &typed)
+		if err != nil {
+			metrics.RecordReconcileError(httpRouteControllerName, "GatewayLookupFailed")
+			return ctrl.Result{}, err
+		}
+		desired = routeChecks(&typed, listeners, template)
+	}
+
+	generated := &generatedChecks{Client: r.Client, scheme: r.Scheme, kind: httpRouteGVK.Kind}
+	if err := generated.sync(ctx, route, desired); IsCheckInvalid(err) {
+		// retrying won't help until the object the Checks are generated from
+		// changes
+		log.Info("generated Checks are invalid", "reason", err.Error())
+		r.Recorder.Event(route, corev1.EventTypeWarning, "Invalid", err.Error())
+		metrics.RecordReconcileError(httpRouteControllerName, "Invalid")
+		return ctrl.Result{}, nil
+	} else if IsCheckNameConflict(err) {
+		// retrying won't help until the other Checks are gone
+		log.Info("names of generated Checks are taken", "reason", err.Error())
+		r.Recorder.Event(route, corev1.EventTypeWarning, "NameConflict", err.Error())
+		metrics.RecordReconcileError(httpRouteControllerName, "NameConflict")
+		return ctrl.Result{}, nil
+	} else if err != nil {
+		r.Recorder.Eventf(
+			route, corev1.EventTypeWarning, "ChecksFailed",
+			"Unable to generate Checks: %v", err,
+		)
+		metrics.RecordReconcileError(httpRouteControllerName, "ChecksFailed")
+		return ctrl.Result{}, err
+	}
+	log.V(1).Info("generated Checks are in sync", "checks", len(desired))
+	return ctrl.Result{}, nil
+}
+
+// targetingPolicies returns PingdomCheckPolicies targeting the HTTPRoute with
+// given name, oldest first.
+func (r *HTTPRouteReconciler) targetingPolicies(
+	ctx context.Context,
+	route types.NamespacedName,
+) ([]observabilityv1alpha1.PingdomCheckPolicy, error) {
+	var policies observabilityv1alpha1.PingdomCheckPolicyList
+	if err := r.List(ctx, &policies, client.InNamespace(route.Namespace)); err != nil {
+		return nil, microerror.Maskf(err, "unable to list PingdomCheckPolicies")
+	}
+	targeting := []observabilityv1alpha1.PingdomCheckPolicy{}
+	for _, policy := range policies.Items {
+		if policyTargets(&policy, route.Name) {
+			targeting = append(targeting, policy)
+		}
+	}
+	sort.SliceStable(targeting, func(i, j int) bool {
+		ti, tj := targeting[i].GetCreationTimestamp(), targeting[j].GetCreationTimestamp()
+		if ti.Equal(&tj) {
+			return targeting[i].GetName() < targeting[j].GetName()
+		}
+		return ti.Before(&tj)
+	})
+	return targeting, nil
+}
+
+// policyTargets returns true if a policy targets the HTTPRoute with given
+// name in its namespace.
+func policyTargets(policy *observabilityv1alpha1.PingdomCheckPolicy, routeName string) bool {
+	ref := policy.Spec.TargetRef
+	return ((ref.Group == "" || ref.Group == observabilityv1alpha1.GatewayAPIGroup) &&
+		ref.Kind == httpRouteGVK.Kind && ref.Name == routeName)
+}
+
+// policyTemplate returns the spec Checks generated by a policy start from.
+func policyTemplate(
+	policy *observabilityv1alpha1.PingdomCheckPolicy,
+) (*observabilityv1alpha1.CheckSpec, error) {
+	spec := &policy.Spec
+	if spec.CredentialsSecret.Name != "" && spec.AccountRef != nil {
+		return nil, fmt.Errorf(
+			"at most one of `credentialsSecret` and `accountRef` may be set in PingdomCheckPolicy %s",
+			policy.GetName(),
+		)
+	}
+	template := &observabilityv1alpha1.CheckSpec{
+		CheckParameters: observabilityv1alpha1.CheckParameters{
+			ResolutionMinutes: spec.ResolutionMinutes,
+			ShouldContain:     spec.ShouldContain,
+			ShouldNotContain:  spec.ShouldNotContain,
+		},
+		CredentialsSecret: spec.CredentialsSecret,
+		Contacts:          spec.Contacts,
+		Teams:             spec.Teams,
+		AccountRef:        spec.AccountRef,
+	}
+	return template.DeepCopy(), nil
+}
+
+/*
+updatePolicies sets the Accepted condition of policies targeting a route:
+only the oldest one applies if the route exists and the policy is valid
+(templateErr is nil), others conflict with it.
+*/
+func (r *HTTPRouteReconciler) updatePolicies(
+	ctx context.Context,
+	log logr.Logger,
+	policies []observabilityv1alpha1.PingdomCheckPolicy,
+	routeFound bool,
+	templateErr error,
+) {
+	for i := range policies {
+		policy := &policies[i]
+		before := policy.Status.DeepCopy()
+		switch {
+		case !routeFound:
+			policy.Status.SetCondition(
+				observabilityv1alpha1.Accepted, corev1.ConditionFalse,
+				"TargetNotFound", "HTTPRoute "+policy.Spec.TargetRef.Name+" not found",
+			)
+		case i > 0:
+			policy.Status.SetCondition(
+				observabilityv1alpha1.Accepted, corev1.ConditionFalse,
+				"Conflicted", "Older PingdomCheckPolicy "+policies[0].GetName()+" targets the same HTTPRoute",
+			)
+		case templateErr != nil:
+			policy.Status.SetCondition(
+				observabilityv1alpha1.Accepted, corev1.ConditionFalse,
+				"Invalid", templateErr.Error(),
+			)
+		default:
+			policy.Status.SetCondition(
+				observabilityv1alpha1.Accepted, corev1.ConditionTrue,
+				"Accepted", "Checks are generated from the HTTPRoute",
+			)
+		}
+		policy.Status.ObservedGeneration = policy.GetGeneration()
+		if equality.Semantic.DeepEqual(before, &policy.Status) {
+			continue
+		}
+		if err := r.Status().Update(ctx, policy); err != nil {
+			log.Error(err, "unable to update PingdomCheckPolicy status", "policy", policy.GetName())
+		}
+	}
+}
+
+/*
+parentListeners returns the listeners of the Gateways a route is attached to,
+only the ones the route refers to by section name if it does. Missing
+Gateways are skipped, the route is reconciled again once they are created.
+*/
+func (r *HTTPRouteReconciler) parentListeners(
+	ctx context.Context,
+	route *httpRoute,
+) ([]listener, error) {
+	listeners := []listener{}
+	for _, ref := range route.Spec.ParentRefs {
+		if !ref.isGateway() {
+			continue
+		}
+		obj := newUnstructured(gatewayGVK)
+		nsName := types.NamespacedName{Namespace: ref.namespace(route.GetNamespace()), Name: ref.Name}
+		if err := r.Get(ctx, nsName, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				r.Log.V(1).Info("parent Gateway not found", "gateway", nsName)
+				continue
+			}
+			return nil, microerror.Maskf(err, "unable to get Gateway %v", nsName)
+		}
+		var gw gateway
+		if err := fromUnstructured(obj, &gw); err != nil {
+			return nil, microerror.Maskf(err, "invalid Gateway %v", nsName)
+		}
+		for _, l := range gw.Spec.Listeners {
+			if ref.SectionName == nil || *ref.SectionName == l.Name {
+				listeners = append(listeners, l)
+			}
+		}
+	}
+	return listeners, nil
+}
+
+/*
+routeChecks returns Checks of each hostname and path of the route, based on
+given template.
+
+Hostnames default to the listeners' hostnames, wildcards are skipped. Each
+hostname is checked through a matching HTTPS listener if there is one, or an
+HTTP one otherwise; hostnames without a matching listener are skipped. Only
+Exact and PathPrefix path matches are checked.
+*/
+func routeChecks(
+	route *httpRoute,
+	listeners []listener,
+	template *observabilityv1alpha1.CheckSpec,
+) []observabilityv1alpha1.Check {
+	hostnames := route.Spec.Hostnames
+	if len(hostnames) == 0 {
+		for _, l := range listeners {
+			if l.Hostname != nil {
+				hostnames = append(hostnames, *l.Hostname)
+			}
+		}
+	}
+
+	paths := []string{}
+	for _, rule := range route.Spec.Rules {
+		if len(rule.Matches) == 0 {
+			paths = append(paths, "/")
+		}
+		for _, match := range rule.Matches {
+			if path, ok := matchPath(match.Path); ok {
+				paths = append(paths, path)
+			}
+		}
+	}
+	if len(route.Spec.Rules) == 0 {
+		paths = append(paths, "/")
+	}
+
+	checks := []observabilityv1alpha1.Check{}
+	seen := map[string]bool{}
+	for _, host := range hostnames {
+		if strings.HasPrefix(host, "*") {
+			continue
+		}
+		l := hostListener(listeners, host)
+		if l == nil {
+			continue
+		}
+		for _, path := range paths {
+			if seen[host+path] {
+				continue
+			}
+			seen[host+path] = true
+
+			check := observabilityv1alpha1.Check{}
+			check.SetName(generatedCheckName(httpRouteGVK.Kind, route.GetName(), host, path))
+			check.Spec = *template.DeepCopy()
+			name, url := host+path, path
+			encryption := l.Protocol == gatewayProtocolHTTPS
+			port := l.Port
+			check.Spec.Name = &name
+			check.Spec.Host = host
+			check.Spec.Type = observabilityv1alpha1.HTTP
+			check.Spec.URL = &url
+			check.Spec.Encryption = &encryption
+			check.Spec.Port = &port
+			checks = append(checks, check)
+		}
+	}
+	return checks
+}
+
+// matchPath returns the path to check for a path match, if it's checked.
+func matchPath(match *httpPathMatch) (string, bool) {
+	if match == nil {
+		return "/", true
+	}
+	if match.Type != nil && *match.Type != pathMatchExact && *match.Type != pathMatchPathPrefix {
+		return "", false
+	}
+	if match.Value == nil || *match.Value == "" {
+		return "/", true
+	}
+	return *match.Value, true
+}
+
+// hostListener returns the listener a hostname is checked through, HTTPS
+// preferred, or nil if there's none.
+func hostListener(listeners []listener, host string) *listener {
+	var found *listener
+	for i := range listeners {
+		l := &listeners[i]
+		if l.Protocol != gatewayProtocolHTTP && l.Protocol != gatewayProtocolHTTPS {
+			continue
+		}
+		if l.Hostname != nil && !hostMatches(*l.Hostname, host) {
+			continue
+		}
+		if found == nil || (found.Protocol != gatewayProtocolHTTPS && l.Protocol == gatewayProtocolHTTPS) {
+			found = l
+		}
+	}
+	return found
+}
+
+// requestsForGateway maps a Gateway to reconcile requests for HTTPRoutes
+// attached to it.
+func (r *HTTPRouteReconciler) requestsForGateway(obj handler.MapObject) []reconcile.Request {
+	ctx := context.Background()
+	routes := &unstructured.UnstructuredList{}
+	routes.SetGroupVersionKind(httpRouteGVK.GroupVersion().WithKind(httpRouteGVK.Kind + "List"))
+	if err := r.List(ctx, routes); err != nil {
+		r.Log.Error(err, "unable to list HTTPRoutes")
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for i := range routes.Items {
+		var route httpRoute
+		if err := fromUnstructured(&routes.Items[i], &route); err != nil {
+			continue
+		}
+		for _, ref := range route.Spec.ParentRefs {
+			if ref.isGateway() && ref.Name == obj.Meta.GetName() &&
+				ref.namespace(route.GetNamespace()) == obj.Meta.GetNamespace() {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Namespace: route.GetNamespace(),
+						Name:      route.GetName(),
+					},
+				})
+				break
+			}
+		}
+	}
+	return requests
+}
+
+// requestsForPolicy maps a PingdomCheckPolicy to a reconcile request for the
+// HTTPRoute it targets.
+func (r *HTTPRouteReconciler) requestsForPolicy(obj handler.MapObject) []reconcile.Request {
+	policy, ok := obj.Object.(*observabilityv1alpha1.PingdomCheckPolicy)
+	if !ok || !policyTargets(policy, policy.Spec.TargetRef.Name) {
+		return nil
+	}
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{
+			Namespace: policy.GetNamespace(),
+			Name:      policy.Spec.TargetRef.Name,
+		},
+	}}
+}
+
+/*
+SetupWithManager configures this reconciler to be triggered for events
+pertaining to specified resource kinds.
+
+Besides HTTPRoutes it watches the Checks they own, the Gateways they are
+attached to and PingdomCheckPolicies targeting them.
+*/
+func (r *HTTPRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(newUnstructured(httpRouteGVK)).
+		Owns(&observabilityv1alpha1.Check{}).
+		Watches(
+			&source.Kind{Type: newUnstructured(gatewayGVK)},
+			&handler.EnqueueRequestsFromMapFunc{
+				ToRequests: handler.ToRequestsFunc(r.requestsForGateway),
+			},
+		).
+		Watches(
+			&source.Kind{Type: &observabilityv1alpha1.PingdomCheckPolicy{}},
+			&handler.EnqueueRequestsFromMapFunc{
+				ToRequests: handler.ToRequestsFunc(r.requestsForPolicy),
+			},
+		).
+		Complete(r)
+}
@@ -0,0 +1,138 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+)
+
+var _ = Describe("HTTPRoute Checks", func() {
+	template := &observabilityv1alpha1.CheckSpec{
+		CredentialsSecret: corev1.LocalObjectReference{Name: "creds"},
+	}
+	summarize := func(checks []observabilityv1alpha1.Check) []string {
+		summary := []string{}
+		for _, check := range checks {
+			scheme := "http://"
+			if *check.Spec.Encryption {
+				scheme = "https://"
+			}
+			summary = append(summary, scheme+check.Spec.Host+*check.Spec.URL)
+		}
+		return summary
+	}
+	decodeRoute := func(spec map[string]interface{}) *httpRoute {
+		obj := newUnstructured(httpRouteGVK)
+		obj.SetName("web")
+		obj.SetNamespace("default")
+		Expect(unstructured.SetNestedField(obj.Object, spec, "spec")).To(Succeed())
+		route := &httpRoute{}
+		Expect(fromUnstructured(obj, route)).To(Succeed())
+		return route
+	}
+	wildcard := "*.example.com"
+	listeners := []listener{
+		{Name: "http", Port: 80, Protocol: gatewayProtocolHTTP},
+		{Name: "https", Hostname: &wildcard, Port: 443, Protocol: gatewayProtocolHTTPS},
+		{Name: "tls", Port: 8443, Protocol: "TLS"},
+	}
+
+	It("generates a Check for each hostname and path", func() {
+		route := decodeRoute(map[string]interface{}{
+			"parentRefs": []interface{}{map[string]interface{}{"name": "gateway"}},
+			"hostnames":  []interface{}{"www.example.com", "example.org"},
+			"rules": []interface{}{
+				map[string]interface{}{"matches": []interface{}{
+					map[string]interface{}{"path": map[string]interface{}{
+						"type": "PathPrefix", "value": "/api",
+					}},
+					map[string]interface{}{"path": map[string]interface{}{
+						"type": "RegularExpression", "value": "/v[0-9]+",
+					}},
+				}},
+				map[string]interface{}{},
+			},
+		})
+		Expect(route.Spec.ParentRefs[0].isGateway()).To(BeTrue())
+
+		checks := routeChecks(route, listeners, template)
+		Expect(summarize(checks)).To(Equal([]string{
+			"https://www.example.com/api", "https://www.example.com/",
+			"http://example.org/api", "http://example.org/",
+		}))
+		Expect(*checks[0].Spec.Port).To(BeEquivalentTo(443))
+		Expect(*checks[2].Spec.Port).To(BeEquivalentTo(80))
+		Expect(checks[0].Spec.CredentialsSecret.Name).To(Equal("creds"))
+		Expect(checks[0].GetName()).To(Equal(generatedCheckName(httpRouteGVK.Kind, "web", "www.example.com", "/api")))
+	})
+
+	It("defaults hostnames to the listeners'", func() {
+		host := "shop.example.com"
+		route := decodeRoute(map[string]interface{}{})
+		checks := routeChecks(route, append(listeners, listener{
+			Name: "shop", Hostname: &host, Port: 443, Protocol: gatewayProtocolHTTPS,
+		}), template)
+		Expect(summarize(checks)).To(Equal([]string{"https://shop.example.com/"}))
+	})
+
+	It("skips hostnames without a listener", func() {
+		route := decodeRoute(map[string]interface{}{
+			"hostnames": []interface{}{"www.example.com"},
+		})
+		Expect(routeChecks(route, listeners[2:], template)).To(BeEmpty())
+	})
+
+	Describe("policies", func() {
+		policy := func(name string) *observabilityv1alpha1.PingdomCheckPolicy {
+			return &observabilityv1alpha1.PingdomCheckPolicy{
+				Spec: observabilityv1alpha1.PingdomCheckPolicySpec{
+					TargetRef: observabilityv1alpha1.PolicyTargetReference{
+						Kind: "HTTPRoute", Name: name,
+					},
+					ResolutionMinutes: ptrI32(5),
+					CredentialsSecret: corev1.LocalObjectReference{Name: "creds"},
+				},
+			}
+		}
+
+		It("target routes by name", func() {
+			Expect(policyTargets(policy("web"), "web")).To(BeTrue())
+			Expect(policyTargets(policy("web"), "api")).To(BeFalse())
+			other := policy("web")
+			other.Spec.TargetRef.Group = "example.com"
+			Expect(policyTargets(other, "web")).To(BeFalse())
+		})
+
+		It("provide the template", func() {
+			spec, err := policyTemplate(policy("web"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*spec.ResolutionMinutes).To(BeEquivalentTo(5))
+			Expect(spec.CredentialsSecret.Name).To(Equal("creds"))
+		})
+
+		It("reject both credentials", func() {
+			invalid := policy("web")
+			invalid.Spec.AccountRef = &observabilityv1alpha1.AccountReference{Name: "team"}
+			_, err := policyTemplate(invalid)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,180 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/metrics"
+)
+
+// ingressControllerName labels metrics of the Ingress controller
+const ingressControllerName = "ingress"
+
+// IngressReconciler generates Checks from annotated Ingress objects
+type IngressReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Recorder record.EventRecorder
+	Scheme   *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=checks,verbs=get;list;watch;create;update;patch;delete
+
+/*
+Reconcile maintains an HTTP Check for each host and path of the Ingress
+specified in the given request, if it opted in with the CheckAnnotation, and
+deletes Checks of hosts and paths removed from it.
+*/
+func (r *IngressReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	log := r.Log.WithValues("resource", "ingress", "namespacedName", req.NamespacedName)
+
+	var ingress networkingv1beta1.Ingress
+	if err := r.Get(ctx, req.NamespacedName, &ingress); err != nil {
+		// Checks of deleted Ingresses are deleted by the garbage collector
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !ingress.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	var desired []observabilityv1alpha1.Check
+	if generateChecks(&ingress) {
+		template, err := checkTemplate(&ingress)
+		if err != nil {
+			// retrying won't help until the annotations change
+			log.Info("invalid Check annotations", "error", err.Error())
+			r.Recorder.Event(&ingress, corev1.EventTypeWarning, "InvalidAnnotation", err.Error())
+			metrics.RecordReconcileError(ingressControllerName, "InvalidAnnotation")
+			return ctrl.Result{}, nil
+		}
+		desired = ingressChecks(&ingress, template)
+	}
+
+	generated := &generatedChecks{Client: r.Client, scheme: r.Scheme, kind: "Ingress"}
+	if err := generated.sync(ctx, &ingress, desired); IsCheckInvalid(err) {
+		// retrying won't help until the object the Checks are generated from
+		// changes
+		log.Info("generated Checks are invalid", "reason", err.Error())
+		r.Recorder.Event(&ingress, corev1.EventTypeWarning, "Invalid", err.Error())
+		metrics.RecordReconcileError(ingressControllerName, "Invalid")
+		return ctrl.Result{}, nil
+	} else if IsCheckNameConflict(err) {
+		// retrying won't help until the other Checks are gone
+		log.Info("names of generated Checks are taken", "reason", err.Error())
+		r.Recorder.Event(&ingress, corev1.EventTypeWarning, "NameConflict", err.Error())
+		metrics.RecordReconcileError(ingressControllerName, "NameConflict")
+		return ctrl.Result{}, nil
+	} else if err != nil {
+		r.Recorder.Eventf(
+			&ingress, corev1.EventTypeWarning, "ChecksFailed",
+			"Unable to generate Checks: %v", err,
+		)
+		metrics.RecordReconcileError(ingressControllerName, "ChecksFailed")
+		return ctrl.Result{}, err
+	}
+	log.V(1).Info("generated Checks are in sync", "checks", len(desired))
+	return ctrl.Result{}, nil
+}
+
+/*
+ingressChecks returns Checks of each host and path of the Ingress, based on
+given template. Rules without a host or with a wildcard host are skipped, as
+there is no single host to check.
+
+Checks use HTTPS if the Ingress has a TLS section covering their host.
+*/
+func ingressChecks(
+	ingress *networkingv1beta1.Ingress,
+	template *observabilityv1alpha1.CheckSpec,
+) []observabilityv1alpha1.Check {
+	checks := []observabilityv1alpha1.Check{}
+	seen := map[string]bool{}
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host == "" || strings.HasPrefix(rule.Host, "*") {
+			continue
+		}
+		paths := []string{"/"}
+		if rule.HTTP != nil && len(rule.HTTP.Paths) > 0 {
+			paths = paths[:0]
+			for _, path := range rule.HTTP.Paths {
+				if path.Path == "" {
+					path.Path = "/"
+				}
+				paths = append(paths, path.Path)
+			}
+		}
+
+		encryption := ingressTLSCovers(ingress.Spec.TLS, rule.Host)
+		for _, path := range paths {
+			if seen[rule.Host+path] {
+				continue
+			}
+			seen[rule.Host+path] = true
+
+			check := observabilityv1alpha1.Check{}
+			check.SetName(generatedCheckName("Ingress", ingress.GetName(), rule.Host, path))
+			check.Spec = *template.DeepCopy()
+			name, url := rule.Host+path, path
+			check.Spec.Name = &name
+			check.Spec.Host = rule.Host
+			check.Spec.Type = observabilityv1alpha1.HTTP
+			check.Spec.URL = &url
+			check.Spec.Encryption = &encryption
+			checks = append(checks, check)
+		}
+	}
+	return checks
+}
+
+// ingressTLSCovers returns true if any of the TLS sections of an Ingress
+// covers given host, directly or with a wildcard.
+func ingressTLSCovers(tls []networkingv1beta1.IngressTLS, host string) bool {
+	for _, section := range tls {
+		for _, tlsHost := range section.Hosts {
+			if hostMatches(tlsHost, host) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+/*
+SetupWithManager configures this reconciler to be triggered for events
+pertaining to specified resource kinds.
+
+Besides Ingresses it watches the Checks they own, so changes made to them
+directly are reverted.
+*/
+func (r *IngressReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&networkingv1beta1.Ingress{}).
+		Owns(&observabilityv1alpha1.Check{}).
+		Complete(r)
+}
@@ -0,0 +1,93 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+)
+
+var _ = Describe("ingressChecks", func() {
+	template := &observabilityv1alpha1.CheckSpec{
+		CheckParameters:   observabilityv1alpha1.CheckParameters{ResolutionMinutes: ptrI32(5)},
+		CredentialsSecret: corev1.LocalObjectReference{Name: "creds"},
+	}
+	rule := func(host string, paths ...string) networkingv1beta1.IngressRule {
+		rule := networkingv1beta1.IngressRule{Host: host}
+		if len(paths) > 0 {
+			rule.HTTP = &networkingv1beta1.HTTPIngressRuleValue{}
+			for _, path := range paths {
+				rule.HTTP.Paths = append(rule.HTTP.Paths, networkingv1beta1.HTTPIngressPath{Path: path})
+			}
+		}
+		return rule
+	}
+	summarize := func(checks []observabilityv1alpha1.Check) []string {
+		summary := []string{}
+		for _, check := range checks {
+			scheme := "http://"
+			if *check.Spec.Encryption {
+				scheme = "https://"
+			}
+			summary = append(summary, scheme+check.Spec.Host+*check.Spec.URL)
+		}
+		return summary
+	}
+
+	It("generates a Check for each host and path", func() {
+		ingress := &networkingv1beta1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec: networkingv1beta1.IngressSpec{
+				TLS: []networkingv1beta1.IngressTLS{{Hosts: []string{"*.example.com"}}},
+				Rules: []networkingv1beta1.IngressRule{
+					rule("www.example.com", "/", "/api"),
+					rule("example.org"),
+					rule(""),
+					rule("*.example.net"),
+					rule("example.org", ""),
+				},
+			},
+		}
+
+		checks := ingressChecks(ingress, template)
+		Expect(summarize(checks)).To(Equal([]string{
+			"https://www.example.com/", "https://www.example.com/api", "http://example.org/",
+		}))
+		Expect(*checks[1].Spec.Name).To(Equal("www.example.com/api"))
+		Expect(checks[1].Spec.Type).To(Equal(observabilityv1alpha1.HTTP))
+		Expect(*checks[1].Spec.ResolutionMinutes).To(BeEquivalentTo(5))
+		Expect(checks[1].Spec.CredentialsSecret.Name).To(Equal("creds"))
+		Expect(checks[1].GetName()).To(Equal(generatedCheckName("Ingress", "web", "www.example.com", "/api")))
+	})
+
+	DescribeTable("ingressTLSCovers",
+		func(tlsHosts []string, host string, expected bool) {
+			tls := []networkingv1beta1.IngressTLS{{Hosts: tlsHosts}}
+			Expect(ingressTLSCovers(tls, host)).To(Equal(expected))
+		},
+		Entry("exact host", []string{"example.com"}, "example.com", true),
+		Entry("other host", []string{"example.com"}, "www.example.com", false),
+		Entry("wildcard", []string{"*.example.com"}, "www.example.com", true),
+		Entry("wildcard and apex", []string{"*.example.com"}, "example.com", false),
+		Entry("wildcard and subdomain", []string{"*.example.com"}, "a.b.example.com", false),
+	)
+})
@@ -0,0 +1,124 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package pdfake provides the scaffolding of in-memory fakes of Pingdom API 3.1
+endpoints used in tests of resource reconcilers: an HTTP server recording
+requests, a client for it and helpers writing responses.
+*/
+package pdfake
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+)
+
+// Handler serves a request to a fake for given path, relative to the base
+// URL of the API (e.g. `/tms/check/1`).
+type Handler func(w http.ResponseWriter, r *http.Request, path string)
+
+/*
+Server is a fake Pingdom API 3.1 server. Requests are served by a Handler
+while holding the lock, which fakes should also hold while accessing their
+state from tests.
+*/
+type Server struct {
+	sync.Mutex
+
+	server   *httptest.Server
+	handler  Handler
+	nextID   int
+	requests []string
+}
+
+// NewServer starts a server serving requests with the handler; identifiers
+// returned by NextID start after firstID.
+func NewServer(firstID int, handler Handler) *Server {
+	s := &Server{handler: handler, nextID: firstID}
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns a client for the server.
+func (s *Server) Client() *pingdom.Client {
+	client, err := pdclient.New(&pdclient.Credentials{
+		APIVersion: pdclient.APIVersion31, APIToken: "token",
+	}, s.server.URL+"/api/", nil)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// Requests returns methods and paths of requests received so far.
+func (s *Server) Requests() []string {
+	s.Lock()
+	defer s.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// NextID returns a new identifier for a created resource; it must be called
+// holding the lock, e.g. from a Handler.
+func (s *Server) NextID() int {
+	s.nextID++
+	return s.nextID
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/api/3.1")
+	s.requests = append(s.requests, r.Method+" "+path)
+	s.handler(w, r, path)
+}
+
+// Params returns the query parameters of a request, the first value of each.
+func Params(r *http.Request) map[string]string {
+	params := map[string]string{}
+	for key := range r.URL.Query() {
+		params[key] = r.URL.Query().Get(key)
+	}
+	return params
+}
+
+// WriteNotFound writes the error the Pingdom API responds with when a
+// resource doesn't exist.
+func WriteNotFound(w http.ResponseWriter) {
+	WriteJSON(w, http.StatusNotFound, map[string]interface{}{
+		"error": map[string]interface{}{
+			"statuscode": 404, "statusdesc": "Not Found", "errormessage": "Not found",
+		},
+	})
+}
+
+// WriteJSON writes a response with given status and JSON encoded body.
+func WriteJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
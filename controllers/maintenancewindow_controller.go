@@ -0,0 +1,298 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/metrics"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+	maintenancereconciler "gitlab.com/mig4/pingdom-operator/controllers/resources/maintenance"
+)
+
+// How often maintenance windows are re-synced, besides when their Checks
+// change
+const maintenanceResyncInterval = 10 * time.Minute
+
+// maintenanceControllerName labels metrics of the MaintenanceWindow
+// controller
+const maintenanceControllerName = "maintenancewindow"
+
+// MaintenanceWindowReconciler reconciles a MaintenanceWindow object
+type MaintenanceWindowReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Recorder record.EventRecorder
+
+	// PdAPIKey is the operator-wide Pingdom application key, see
+	// CheckReconciler
+	PdAPIKey string
+
+	// PdClients is the cache of Pingdom API clients shared by all
+	// reconcilers
+	PdClients *pdclient.Cache
+}
+
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=maintenancewindows,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=maintenancewindows/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=checks,verbs=get;list;watch
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=checkdefaults,verbs=get;list;watch
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=clustercheckdefaults,verbs=get;list;watch
+
+/*
+Reconcile ensures the Pingdom maintenance window of the MaintenanceWindow
+specified in the given request matches its spec and applies to the Pingdom
+checks of the Checks it selects.
+*/
+func (r *MaintenanceWindowReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	log := r.Log.WithValues("resource", "maintenancewindow", "namespacedName", req.NamespacedName)
+	sync := &externalSync{
+		Client:         r.Client,
+		log:            log,
+		controller:     maintenanceControllerName,
+		resyncInterval: maintenanceResyncInterval,
+	}
+
+	var window observabilityv1alpha1.MaintenanceWindow
+	if err := r.Get(ctx, req.NamespacedName, &window); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	defer func() {
+		sync.updateStatus(ctx, &window, &window.Status, "MaintenanceWindow", fmt.Sprintf(
+			"Pingdom maintenance window applies to %d checks", len(window.Status.CheckIDs),
+		))
+	}()
+
+	deleting := !window.GetDeletionTimestamp().IsZero()
+	if deleting {
+		window.Status.SetCondition(
+			observabilityv1alpha1.Deleting, corev1.ConditionTrue,
+			"Deleting", "MaintenanceWindow is being deleted",
+		)
+	} else if err := window.Spec.Valid(); err != nil {
+		// retrying won't help until the spec changes
+		log.Info("invalid MaintenanceWindow spec", "error", err.Error())
+		window.Status.SetCondition(
+			observabilityv1alpha1.Synced, corev1.ConditionFalse,
+			"Invalid", err.Error(),
+		)
+		return ctrl.Result{}, nil
+	}
+
+	pdClient, err := sync.credentialsClient(
+		ctx, &window, &window.Status, window.Spec.CredentialsSecret.Name,
+		window.Spec.AccountRef, r.PdAPIKey, r.PdClients,
+	)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var checkIDs []int32
+	if !deleting {
+		var skipped []string
+		if checkIDs, skipped, err = r.selectCheckIDs(ctx, &window); err != nil {
+			metrics.RecordReconcileError(maintenanceControllerName, "ListFailed")
+			return ctrl.Result{}, err
+		}
+		if len(skipped) > 0 {
+			window.Status.SetCondition(
+				observabilityv1alpha1.ChecksMatchAccount, corev1.ConditionFalse,
+				"AccountMismatch", fmt.Sprintf(
+					"Checks %s use a different Pingdom account, the window doesn't apply to them",
+					strings.Join(skipped, ", "),
+				),
+			)
+		} else {
+			window.Status.SetCondition(
+				observabilityv1alpha1.ChecksMatchAccount, corev1.ConditionTrue,
+				"AccountMatches", "All selected Checks use the Pingdom account of the window",
+			)
+		}
+	}
+
+	return sync.run(
+		ctx, &window, &window.Status, &window.Status.ObservedGeneration,
+		maintenancereconciler.New(&maintenancereconciler.Config{
+			Logger:   log,
+			Recorder: r.Recorder,
+			PdClient: pdClient.Client,
+			Window:   &window,
+			CheckIDs: checkIDs,
+		}),
+	)
+}
+
+/*
+selectCheckIDs returns sorted identifiers of Pingdom checks of the Checks
+selected by the MaintenanceWindow, either by label selector or by name.
+Checks which haven't been created in Pingdom yet are skipped, the window is
+reconciled again once they are as it watches Checks.
+
+Checks using different Pingdom credentials than the window are skipped too,
+as their IDs refer to checks of another account; their names are returned
+sorted.
+*/
+func (r *MaintenanceWindowReconciler) selectCheckIDs(
+	ctx context.Context,
+	window *observabilityv1alpha1.MaintenanceWindow,
+) ([]int32, []string, error) {
+	selector, err := windowCheckSelector(window)
+	if err != nil {
+		return nil, nil, err
+	}
+	var checks observabilityv1alpha1.CheckList
+	if err := r.List(ctx, &checks, client.InNamespace(window.GetNamespace())); err != nil {
+		return nil, nil, microerror.Maskf(err, "unable to list Checks")
+	}
+	defaults, err := listCheckDefaults(ctx, r.Client, window.GetNamespace())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := []int32{}
+	skipped := []string{}
+	for i := range checks.Items {
+		check := &checks.Items[i]
+		if check.Status.ID == 0 || !windowSelects(window, selector, &check.ObjectMeta) {
+			continue
+		}
+		applyCheckDefaults(check, defaults)
+		if !sameAccount(&window.Spec, &check.Spec) {
+			skipped = append(skipped, check.GetName())
+			continue
+		}
+		ids = append(ids, check.Status.ID)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	sort.Strings(skipped)
+	return ids, skipped, nil
+}
+
+/*
+sameAccount returns true if a Check uses the same Pingdom credentials as a
+MaintenanceWindow, the same account or the same Secret; the Check's spec
+should have CheckDefaults applied, as the Check controller does.
+*/
+func sameAccount(
+	window *observabilityv1alpha1.MaintenanceWindowSpec,
+	check *observabilityv1alpha1.CheckSpec,
+) bool {
+	if window.AccountRef != nil || check.AccountRef != nil {
+		return (window.AccountRef != nil && check.AccountRef != nil &&
+			accountIndexKey(window.AccountRef.Kind, window.AccountRef.Name) ==
+				accountIndexKey(check.AccountRef.Kind, check.AccountRef.Name))
+	}
+	return window.CredentialsSecret.Name == check.CredentialsSecret.Name
+}
+
+// windowCheckSelector returns the label selector of Checks of a
+// MaintenanceWindow, which selects nothing if it's not set.
+func windowCheckSelector(window *observabilityv1alpha1.MaintenanceWindow) (labels.Selector, error) {
+	if window.Spec.CheckSelector == nil {
+		return labels.Nothing(), nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(window.Spec.CheckSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid `CheckSelector`: %v", err)
+	}
+	return selector, nil
+}
+
+// windowSelects returns true if a MaintenanceWindow selects the Check with
+// given metadata, by selector or name.
+func windowSelects(
+	window *observabilityv1alpha1.MaintenanceWindow,
+	selector labels.Selector,
+	check metav1.Object,
+) bool {
+	if check.GetNamespace() != window.GetNamespace() {
+		return false
+	}
+	for _, ref := range window.Spec.Checks {
+		if ref.Name == check.GetName() {
+			return true
+		}
+	}
+	return selector.Matches(labels.Set(check.GetLabels()))
+}
+
+// requestsForCheck maps a Check to reconcile requests for MaintenanceWindows
+// in its namespace which select it.
+func (r *MaintenanceWindowReconciler) requestsForCheck(obj handler.MapObject) []reconcile.Request {
+	ctx := context.Background()
+	var windows observabilityv1alpha1.MaintenanceWindowList
+	if err := r.List(ctx, &windows, client.InNamespace(obj.Meta.GetNamespace())); err != nil {
+		r.Log.Error(err, "unable to list MaintenanceWindows", "namespace", obj.Meta.GetNamespace())
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for i := range windows.Items {
+		window := &windows.Items[i]
+		selector, err := windowCheckSelector(window)
+		if err != nil {
+			continue
+		}
+		if windowSelects(window, selector, obj.Meta) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: window.GetNamespace(),
+					Name:      window.GetName(),
+				},
+			})
+		}
+	}
+	return requests
+}
+
+/*
+SetupWithManager configures this reconciler to be triggered for events
+pertaining to specified resource kinds.
+
+Besides MaintenanceWindows it watches Checks, so windows are kept in sync as
+Checks they select come and go.
+*/
+func (r *MaintenanceWindowReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&observabilityv1alpha1.MaintenanceWindow{}).
+		Watches(
+			&source.Kind{Type: &observabilityv1alpha1.Check{}},
+			&handler.EnqueueRequestsFromMapFunc{
+				ToRequests: handler.ToRequestsFunc(r.requestsForCheck),
+			},
+		).
+		Complete(r)
+}
@@ -0,0 +1,132 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+)
+
+var _ = Describe("windowSelects", func() {
+	window := &observabilityv1alpha1.MaintenanceWindow{
+		ObjectMeta: metav1.ObjectMeta{Name: "window", Namespace: "default"},
+		Spec: observabilityv1alpha1.MaintenanceWindowSpec{
+			CheckSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"team": "web"},
+			},
+			Checks: []corev1.LocalObjectReference{{Name: "named"}},
+		},
+	}
+	checkMeta := func(namespace, name string, labels map[string]string) *metav1.ObjectMeta {
+		return &metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels}
+	}
+
+	DescribeTable("selects Checks",
+		func(check *metav1.ObjectMeta, expected bool) {
+			selector, err := windowCheckSelector(window)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(windowSelects(window, selector, check)).To(Equal(expected))
+		},
+		Entry("by name", checkMeta("default", "named", nil), true),
+		Entry("by labels", checkMeta("default", "other", map[string]string{"team": "web"}), true),
+		Entry("not matching", checkMeta("default", "other", map[string]string{"team": "db"}), false),
+		Entry("in another namespace", checkMeta("other", "named", map[string]string{"team": "web"}), false),
+	)
+
+	It("selects nothing by labels without a selector", func() {
+		window := window.DeepCopy()
+		window.Spec.CheckSelector = nil
+		selector, err := windowCheckSelector(window)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(windowSelects(window, selector, checkMeta("default", "other", nil))).To(BeFalse())
+		Expect(windowSelects(window, selector, checkMeta("default", "named", nil))).To(BeTrue())
+	})
+})
+
+var _ = Describe("selectCheckIDs", func() {
+	window := &observabilityv1alpha1.MaintenanceWindow{
+		ObjectMeta: metav1.ObjectMeta{Name: "window", Namespace: "default"},
+		Spec: observabilityv1alpha1.MaintenanceWindowSpec{
+			Checks: []corev1.LocalObjectReference{
+				{Name: "same"}, {Name: "defaulted"}, {Name: "other"}, {Name: "account"},
+			},
+			CredentialsSecret: corev1.LocalObjectReference{Name: "creds"},
+		},
+	}
+	check := func(name string, id int32, spec observabilityv1alpha1.CheckSpec) runtime.Object {
+		return &observabilityv1alpha1.Check{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       spec,
+			Status:     observabilityv1alpha1.CheckStatus{ID: id},
+		}
+	}
+
+	It("skips Checks using a different account", func() {
+		scheme := runtime.NewScheme()
+		Expect(observabilityv1alpha1.AddToScheme(scheme)).To(Succeed())
+		r := &MaintenanceWindowReconciler{Client: fake.NewFakeClientWithScheme(scheme,
+			check("same", 1, observabilityv1alpha1.CheckSpec{
+				CredentialsSecret: corev1.LocalObjectReference{Name: "creds"},
+			}),
+			check("defaulted", 2, observabilityv1alpha1.CheckSpec{}),
+			check("other", 3, observabilityv1alpha1.CheckSpec{
+				CredentialsSecret: corev1.LocalObjectReference{Name: "other"},
+			}),
+			check("account", 4, observabilityv1alpha1.CheckSpec{
+				AccountRef: &observabilityv1alpha1.AccountReference{Name: "creds"},
+			}),
+			&observabilityv1alpha1.CheckDefaults{
+				ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "default"},
+				Spec: observabilityv1alpha1.CheckDefaultsSpec{
+					CredentialsSecret: &corev1.LocalObjectReference{Name: "creds"},
+				},
+			},
+		)}
+
+		ids, skipped, err := r.selectCheckIDs(context.Background(), window)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ids).To(Equal([]int32{1, 2}))
+		Expect(skipped).To(Equal([]string{"account", "other"}))
+	})
+
+	DescribeTable("sameAccount",
+		func(windowRef, checkRef *observabilityv1alpha1.AccountReference, expected bool) {
+			Expect(sameAccount(
+				&observabilityv1alpha1.MaintenanceWindowSpec{AccountRef: windowRef},
+				&observabilityv1alpha1.CheckSpec{AccountRef: checkRef},
+			)).To(Equal(expected))
+		},
+		Entry("with the default kind",
+			&observabilityv1alpha1.AccountReference{Name: "acc"},
+			&observabilityv1alpha1.AccountReference{Kind: observabilityv1alpha1.PingdomAccountKind, Name: "acc"},
+			true,
+		),
+		Entry("with a different kind",
+			&observabilityv1alpha1.AccountReference{Name: "acc"},
+			&observabilityv1alpha1.AccountReference{Kind: observabilityv1alpha1.ClusterPingdomAccountKind, Name: "acc"},
+			false,
+		),
+	)
+})
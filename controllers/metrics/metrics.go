@@ -0,0 +1,165 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package metrics defines Prometheus metrics exported by the operator, about the
+state of Checks and the operator's use of the Pingdom API.
+
+All metrics are registered with the controller-runtime metrics registry, so
+they're served on the manager's metrics endpoint.
+*/
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+)
+
+const namespace = "pingdom_operator"
+
+// Outcomes of Pingdom API requests, used in the `outcome` label
+const (
+	OutcomeSuccess     = "success"
+	OutcomeClientError = "client_error"
+	OutcomeServerError = "server_error"
+	OutcomeRateLimited = "rate_limited"
+	OutcomeError       = "error"
+)
+
+// checkResults are all possible results of a check, each Check has a series
+// of CheckStatus for each of them
+var checkResults = []observabilityv1alpha1.CheckResult{
+	observabilityv1alpha1.Up,
+	observabilityv1alpha1.Down,
+	observabilityv1alpha1.UnconfirmedDown,
+	observabilityv1alpha1.Unknown,
+	observabilityv1alpha1.Paused,
+}
+
+var (
+	// CheckStatus is 1 for the current status of each Check and 0 for the
+	// other statuses
+	CheckStatus = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "check_status",
+			Help:      "Current status of the Pingdom check, 1 for the current status and 0 otherwise.",
+		},
+		[]string{"namespace", "name", "status"},
+	)
+
+	// CheckLastResponseTime is the response time of the last test of each
+	// Check
+	CheckLastResponseTime = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "check_last_response_time_seconds",
+			Help:      "Response time of the last test of the Pingdom check.",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// CheckLastTestTime is the time of the last test of each Check
+	CheckLastTestTime = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "check_last_test_timestamp_seconds",
+			Help:      "Unix time of the last test of the Pingdom check.",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// APIRequests counts requests made to the Pingdom API
+	APIRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "api_requests_total",
+			Help:      "Number of requests made to the Pingdom API by HTTP method and outcome.",
+		},
+		[]string{"verb", "outcome"},
+	)
+
+	// APIRequestDuration observes durations of requests made to the Pingdom
+	// API
+	APIRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "api_request_duration_seconds",
+			Help:      "Duration of requests made to the Pingdom API by HTTP method and outcome.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"verb", "outcome"},
+	)
+
+	// ReconcileErrors counts failed reconciles by the reason of the failure
+	ReconcileErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reconcile_errors_total",
+			Help:      "Number of failed reconciles by controller and reason.",
+		},
+		[]string{"controller", "reason"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		CheckStatus,
+		CheckLastResponseTime,
+		CheckLastTestTime,
+		APIRequests,
+		APIRequestDuration,
+		ReconcileErrors,
+	)
+}
+
+// RecordCheck updates the metrics of a Check from its status.
+func RecordCheck(check *observabilityv1alpha1.Check) {
+	ns, name := check.GetNamespace(), check.GetName()
+	status := &check.Status
+	for _, result := range checkResults {
+		value := 0.0
+		if status.Status == result {
+			value = 1
+		}
+		CheckStatus.WithLabelValues(ns, name, string(result)).Set(value)
+	}
+	if status.LastResponseTimeMilis != nil {
+		CheckLastResponseTime.WithLabelValues(ns, name).Set(
+			float64(*status.LastResponseTimeMilis) / 1000,
+		)
+	}
+	if status.LastTestTime != nil && !status.LastTestTime.IsZero() {
+		CheckLastTestTime.WithLabelValues(ns, name).Set(
+			float64(status.LastTestTime.Unix()),
+		)
+	}
+}
+
+// ForgetCheck removes the metrics of a Check which no longer exists.
+func ForgetCheck(ns, name string) {
+	for _, result := range checkResults {
+		CheckStatus.DeleteLabelValues(ns, name, string(result))
+	}
+	CheckLastResponseTime.DeleteLabelValues(ns, name)
+	CheckLastTestTime.DeleteLabelValues(ns, name)
+}
+
+// RecordReconcileError counts a failed reconcile of given controller.
+func RecordReconcileError(controller, reason string) {
+	ReconcileErrors.WithLabelValues(controller, reason).Inc()
+}
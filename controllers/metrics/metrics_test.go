@@ -0,0 +1,82 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/metrics"
+)
+
+var _ = Describe("Check metrics", func() {
+	var check *observabilityv1alpha1.Check
+
+	BeforeEach(func() {
+		responseTime := int64(250)
+		check = &observabilityv1alpha1.Check{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "metrics"},
+			Status: observabilityv1alpha1.CheckStatus{
+				Status:                observabilityv1alpha1.Down,
+				LastResponseTimeMilis: &responseTime,
+				LastTestTime:          &metav1.Time{Time: time.Unix(1500000000, 0)},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		metrics.ForgetCheck("default", "metrics")
+	})
+
+	It("records status of the check", func() {
+		metrics.RecordCheck(check)
+
+		Expect(testutil.ToFloat64(
+			metrics.CheckStatus.WithLabelValues("default", "metrics", "down"),
+		)).To(Equal(1.0))
+		Expect(testutil.ToFloat64(
+			metrics.CheckStatus.WithLabelValues("default", "metrics", "up"),
+		)).To(Equal(0.0))
+		Expect(testutil.ToFloat64(
+			metrics.CheckLastResponseTime.WithLabelValues("default", "metrics"),
+		)).To(Equal(0.25))
+		Expect(testutil.ToFloat64(
+			metrics.CheckLastTestTime.WithLabelValues("default", "metrics"),
+		)).To(Equal(1500000000.0))
+	})
+
+	It("removes metrics of a forgotten check", func() {
+		metrics.RecordCheck(check)
+		metrics.ForgetCheck("default", "metrics")
+
+		Expect(metrics.CheckStatus.DeleteLabelValues("default", "metrics", "down")).To(BeFalse())
+		Expect(metrics.CheckLastResponseTime.DeleteLabelValues("default", "metrics")).To(BeFalse())
+	})
+})
+
+var _ = Describe("RecordReconcileError", func() {
+	It("counts errors by controller and reason", func() {
+		counter := metrics.ReconcileErrors.WithLabelValues("check", "SecretInvalid")
+		before := testutil.ToFloat64(counter)
+		metrics.RecordReconcileError("check", "SecretInvalid")
+		Expect(testutil.ToFloat64(counter)).To(Equal(before + 1))
+	})
+})
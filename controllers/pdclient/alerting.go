@@ -0,0 +1,180 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+// Severities of notification targets of alerting contacts
+const (
+	SeverityHigh = "HIGH"
+	SeverityLow  = "LOW"
+)
+
+/*
+NotificationTarget is an email address or a phone number an alerting contact
+is notified at. Only the fields of the type of target are set.
+*/
+type NotificationTarget struct {
+	Severity    string `json:"severity"`
+	Address     string `json:"address,omitempty"`
+	CountryCode string `json:"country_code,omitempty"`
+	Number      string `json:"number,omitempty"`
+	Provider    string `json:"provider,omitempty"`
+}
+
+// NotificationTargets are all targets of an alerting contact by type.
+type NotificationTargets struct {
+	Email []NotificationTarget `json:"email,omitempty"`
+	SMS   []NotificationTarget `json:"sms,omitempty"`
+}
+
+/*
+Contact is an alerting contact of the Pingdom API 3.1 `alerting/contacts`
+endpoints (not supported by go-pingdom); checks alert contacts listed in their
+`userids`.
+*/
+type Contact struct {
+	ID                  int                 `json:"id,omitempty"`
+	Name                string              `json:"name"`
+	Paused              bool                `json:"paused"`
+	NotificationTargets NotificationTargets `json:"notification_targets"`
+}
+
+// TeamMember is a contact which is a member of a Team.
+type TeamMember struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+/*
+Team is an alerting team of the Pingdom API 3.1 `alerting/teams` endpoints
+(not supported by go-pingdom); checks alert teams listed in their `teamids`.
+
+MemberIDs are sent when creating or updating a team, always, as a team
+without `member_ids` keeps its members; Members are returned when reading it.
+*/
+type Team struct {
+	ID        int          `json:"id,omitempty"`
+	Name      string       `json:"name"`
+	MemberIDs []int        `json:"member_ids"`
+	Members   []TeamMember `json:"members,omitempty"`
+}
+
+// teamRequest returns the team to send in a request, with MemberIDs set to
+// an empty list rather than null if there are none.
+func teamRequest(team *Team) *Team {
+	if team.MemberIDs != nil {
+		return team
+	}
+	request := *team
+	request.MemberIDs = []int{}
+	return &request
+}
+
+// ReadContact returns the alerting contact with given ID.
+func ReadContact(client *pingdom.Client, id int) (*Contact, error) {
+	resp := &struct {
+		Contact *Contact `json:"contact"`
+	}{}
+	if err := doJSON(client, "GET", "/alerting/contacts/"+strconv.Itoa(id), nil, resp); err != nil {
+		return nil, err
+	}
+	return resp.Contact, nil
+}
+
+// CreateContact creates an alerting contact and returns its ID.
+func CreateContact(client *pingdom.Client, contact *Contact) (int, error) {
+	resp := &struct {
+		Contact Contact `json:"contact"`
+	}{}
+	if err := doJSON(client, "POST", "/alerting/contacts", contact, resp); err != nil {
+		return 0, err
+	}
+	return resp.Contact.ID, nil
+}
+
+// UpdateContact replaces the alerting contact with given ID.
+func UpdateContact(client *pingdom.Client, id int, contact *Contact) error {
+	return doJSON(client, "PUT", "/alerting/contacts/"+strconv.Itoa(id), contact, &pingdom.PingdomResponse{})
+}
+
+// DeleteContact deletes the alerting contact with given ID.
+func DeleteContact(client *pingdom.Client, id int) error {
+	return doJSON(client, "DELETE", "/alerting/contacts/"+strconv.Itoa(id), nil, &pingdom.PingdomResponse{})
+}
+
+// ReadTeam returns the alerting team with given ID.
+func ReadTeam(client *pingdom.Client, id int) (*Team, error) {
+	resp := &struct {
+		Team *Team `json:"team"`
+	}{}
+	if err := doJSON(client, "GET", "/alerting/teams/"+strconv.Itoa(id), nil, resp); err != nil {
+		return nil, err
+	}
+	return resp.Team, nil
+}
+
+// CreateTeam creates an alerting team and returns its ID.
+func CreateTeam(client *pingdom.Client, team *Team) (int, error) {
+	resp := &struct {
+		Team Team `json:"team"`
+	}{}
+	if err := doJSON(client, "POST", "/alerting/teams", teamRequest(team), resp); err != nil {
+		return 0, err
+	}
+	return resp.Team.ID, nil
+}
+
+// UpdateTeam replaces the alerting team with given ID.
+func UpdateTeam(client *pingdom.Client, id int, team *Team) error {
+	return doJSON(client, "PUT", "/alerting/teams/"+strconv.Itoa(id), teamRequest(team), &struct{}{})
+}
+
+// DeleteTeam deletes the alerting team with given ID.
+func DeleteTeam(client *pingdom.Client, id int) error {
+	return doJSON(client, "DELETE", "/alerting/teams/"+strconv.Itoa(id), nil, &struct{}{})
+}
+
+/*
+doJSON makes a request to the Pingdom API with a JSON encoded body (if not
+nil), as expected by the API 3.1 alerting and TMS endpoints, and decodes the
+response into v.
+*/
+func doJSON(client *pingdom.Client, method, rsc string, body, v interface{}) error {
+	req, err := client.NewRequest(method, rsc, nil)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(encoded))
+		req.ContentLength = int64(len(encoded))
+		req.Header.Set("Content-Type", "application/json")
+	}
+	_, err = client.Do(req, v)
+	return err
+}
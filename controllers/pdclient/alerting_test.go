@@ -0,0 +1,122 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdclient_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+)
+
+var _ = Describe("Alerting", func() {
+	var (
+		server   *httptest.Server
+		client   *pingdom.Client
+		request  *http.Request
+		body     map[string]interface{}
+		status   int
+		response string
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				request = r
+				body = nil
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(status)
+				_, _ = w.Write([]byte(response))
+			},
+		))
+		status = http.StatusOK
+		var err error
+		client, err = pdclient.New(&pdclient.Credentials{
+			APIVersion: pdclient.APIVersion31, APIToken: "t",
+		}, server.URL+"/api/", nil)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("creates a contact with a JSON body", func() {
+		response = `{"contact": {"id": 42}}`
+		id, err := pdclient.CreateContact(client, &pdclient.Contact{
+			Name: "ops",
+			NotificationTargets: pdclient.NotificationTargets{
+				Email: []pdclient.NotificationTarget{
+					{Severity: pdclient.SeverityHigh, Address: "ops@example.com"},
+				},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(Equal(42))
+
+		Expect(request.Method).To(Equal(http.MethodPost))
+		Expect(request.URL.Path).To(Equal("/api/3.1/alerting/contacts"))
+		Expect(request.URL.RawQuery).To(BeEmpty())
+		Expect(request.Header.Get("Content-Type")).To(Equal("application/json"))
+		Expect(body).To(Equal(map[string]interface{}{
+			"name":   "ops",
+			"paused": false,
+			"notification_targets": map[string]interface{}{
+				"email": []interface{}{
+					map[string]interface{}{"severity": "HIGH", "address": "ops@example.com"},
+				},
+			},
+		}))
+	})
+
+	It("removes all members of a team", func() {
+		response = `{}`
+		Expect(pdclient.UpdateTeam(client, 7, &pdclient.Team{Name: "on-call"})).To(Succeed())
+
+		Expect(request.Method).To(Equal(http.MethodPut))
+		Expect(request.URL.Path).To(Equal("/api/3.1/alerting/teams/7"))
+		Expect(body).To(Equal(map[string]interface{}{
+			"name":       "on-call",
+			"member_ids": []interface{}{},
+		}))
+	})
+
+	It("reads a team with its members", func() {
+		response = `{"team": {"id": 7, "name": "on-call", "members": [{"id": 42, "name": "ops", "type": "contact"}]}}`
+		team, err := pdclient.ReadTeam(client, 7)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(request.URL.Path).To(Equal("/api/3.1/alerting/teams/7"))
+		Expect(team).To(Equal(&pdclient.Team{
+			ID:      7,
+			Name:    "on-call",
+			Members: []pdclient.TeamMember{{ID: 42, Name: "ops", Type: "contact"}},
+		}))
+	})
+
+	It("returns Pingdom errors", func() {
+		response = `{"error": {"statuscode": 404, "statusdesc": "Not Found", "errormessage": "Not found"}}`
+		status = http.StatusNotFound
+		_, err := pdclient.ReadContact(client, 1)
+		Expect(err).To(BeAssignableToTypeOf(&pingdom.PingdomError{}))
+	})
+})
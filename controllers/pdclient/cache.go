@@ -0,0 +1,259 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// CacheConfig is the configuration of a Cache
+type CacheConfig struct {
+	// BaseURL is the base URL of the Pingdom API passed to New
+	BaseURL string
+
+	// RequestsPerSecond and Burst configure the Limiter of each account, see
+	// NewLimiter
+	RequestsPerSecond float64
+	Burst             int
+
+	// PollInterval is how often all checks of each account are listed to
+	// refresh its Snapshot; 0 disables polling
+	PollInterval time.Duration
+
+	// DetailsInterval is how often each check is read individually to
+	// refresh details not included in the list of checks, when polling; 0
+	// only reads them when a check changes
+	DetailsInterval time.Duration
+
+	Logger logr.Logger
+}
+
+/*
+Cache holds Pingdom API clients keyed by the Secret their credentials come
+from, so they are shared between reconciles of all resources using the same
+Secret.
+
+A client is rebuilt when the Secret changes (its resourceVersion differs) or
+the credentials derived from it do, e.g. because an account overrides the API
+version; Invalidate drops it straight away and Remove drops it along with
+the account state once the Secret is deleted.
+
+All clients using credentials of the same Pingdom account, even from
+different Secrets, share a Limiter and a Snapshot, as Pingdom rate limits
+apply to the account. They outlive the clients, so rate limits still apply
+when a Secret changes, and are dropped with the last client using them. When
+started (it implements manager.Runnable) the Cache polls checks of every
+account with a client, keeping the Snapshots up to date.
+*/
+type Cache struct {
+	config CacheConfig
+
+	mu       sync.Mutex
+	clients  map[types.UID]*cacheEntry
+	accounts map[string]*account
+}
+
+type cacheEntry struct {
+	resourceVersion string
+	creds           Credentials
+	account         string
+	client          *Client
+}
+
+// account is the state shared by all clients using credentials of one
+// Pingdom account, keyed by accountKey
+type account struct {
+	limiter  *Limiter
+	snapshot *Snapshot
+}
+
+// Client is a Pingdom API client along with the state shared by all users of
+// the same credentials
+type Client struct {
+	*pingdom.Client
+
+	// APIVersion is the version of Pingdom API the client uses
+	APIVersion string
+
+	// Snapshot of all checks of the account from the latest poll; nil if
+	// polling is disabled
+	Snapshot *Snapshot
+}
+
+// NewCache returns an empty client cache with given configuration.
+func NewCache(config CacheConfig) *Cache {
+	return &Cache{
+		config:   config,
+		clients:  map[types.UID]*cacheEntry{},
+		accounts: map[string]*account{},
+	}
+}
+
+// Get returns a client for given credentials read from the Secret, reusing
+// a cached one if the Secret and credentials haven't changed.
+func (c *Cache) Get(secret *corev1.Secret, creds *Credentials) (*Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	uid := secret.GetUID()
+	if entry, ok := c.clients[uid]; ok && entry.resourceVersion == secret.GetResourceVersion() && entry.creds == *creds {
+		return entry.client, nil
+	}
+
+	key := accountKey(creds)
+	acc, ok := c.accounts[key]
+	if !ok {
+		acc = &account{
+			limiter: NewLimiter(c.config.RequestsPerSecond, c.config.Burst),
+		}
+		if c.config.PollInterval > 0 {
+			acc.snapshot = NewSnapshot(2*c.config.PollInterval, c.config.DetailsInterval)
+		}
+		c.accounts[key] = acc
+	}
+	pdClient, err := New(creds, c.config.BaseURL, acc.limiter)
+	if err != nil {
+		if !ok {
+			delete(c.accounts, key)
+		}
+		return nil, err
+	}
+	client := &Client{Client: pdClient, APIVersion: creds.APIVersion, Snapshot: acc.snapshot}
+	c.clients[uid] = &cacheEntry{
+		resourceVersion: secret.GetResourceVersion(),
+		creds:           *creds,
+		account:         key,
+		client:          client,
+	}
+	if !ok {
+		// credentials of the Secret may have been rotated
+		c.dropUnused()
+	}
+	return client, nil
+}
+
+/*
+accountKey returns the key of the Pingdom account given credentials belong
+to: a hash of the API token, or of the user and application key for API 2.1,
+so the credentials aren't kept in the Cache keys.
+*/
+func accountKey(creds *Credentials) string {
+	id := "token:" + creds.APIToken
+	if creds.APIToken == "" {
+		id = "user:" + creds.User + ":" + creds.AppKey
+	}
+	sum := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(sum[:])
+}
+
+// dropUnused removes accounts no client uses any more, e.g. after their
+// credentials were rotated; the caller must hold the lock.
+func (c *Cache) dropUnused() {
+	used := make(map[string]bool, len(c.clients))
+	for _, entry := range c.clients {
+		used[entry.account] = true
+	}
+	for key := range c.accounts {
+		if !used[key] {
+			delete(c.accounts, key)
+		}
+	}
+}
+
+// Invalidate removes a client for the Secret with given UID from the cache,
+// discarding the account's Snapshot too as checks may have changed along
+// with the credentials.
+func (c *Cache) Invalidate(uid types.UID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry, ok := c.clients[uid]; ok {
+		delete(c.clients, uid)
+		if acc, ok := c.accounts[entry.account]; ok {
+			acc.snapshot.Reset()
+		}
+	}
+}
+
+// Remove removes a client for the deleted Secret with given UID from the
+// cache, along with the account's Limiter and Snapshot unless another Secret
+// has credentials of the same account, so the account isn't polled any more.
+func (c *Cache) Remove(uid types.UID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.clients, uid)
+	c.dropUnused()
+}
+
+// Len returns the number of cached clients.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.clients)
+}
+
+// Start polls checks of all accounts every PollInterval until the stop
+// channel is closed; it implements manager.Runnable.
+func (c *Cache) Start(stop <-chan struct{}) error {
+	if c.config.PollInterval <= 0 {
+		<-stop
+		return nil
+	}
+	ticker := time.NewTicker(c.config.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-ticker.C:
+			c.Poll()
+		}
+	}
+}
+
+/*
+Poll lists checks of all accounts with a client, one request per account, and
+updates their Snapshots.
+
+Failures are only logged, the Snapshot of the account then expires and checks
+are read individually until a poll succeeds again.
+*/
+func (c *Cache) Poll() {
+	c.mu.Lock()
+	clients := make(map[string]*Client, len(c.accounts))
+	for _, entry := range c.clients {
+		if entry.client.Snapshot != nil {
+			clients[entry.account] = entry.client
+		}
+	}
+	c.mu.Unlock()
+
+	for _, client := range clients {
+		checks, err := client.ListChecks(nil)
+		if err != nil {
+			c.config.Logger.Error(err, "unable to list checks from Pingdom")
+			continue
+		}
+		client.Snapshot.Update(checks)
+	}
+}
@@ -0,0 +1,199 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdclient_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+)
+
+var _ = Describe("Cache", func() {
+	var (
+		cache  *pdclient.Cache
+		secret *corev1.Secret
+		creds  *pdclient.Credentials
+	)
+
+	BeforeEach(func() {
+		cache = pdclient.NewCache(pdclient.CacheConfig{})
+		secret = &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			UID: "uid-1", ResourceVersion: "1",
+		}}
+		creds = &pdclient.Credentials{APIVersion: "3.1", APIToken: "t"}
+	})
+
+	It("reuses the client for unchanged secret", func() {
+		first, err := cache.Get(secret, creds)
+		Expect(err).NotTo(HaveOccurred())
+		second, err := cache.Get(secret, creds)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second).To(BeIdenticalTo(first))
+		Expect(cache.Len()).To(Equal(1))
+	})
+
+	It("rebuilds the client when the secret changes", func() {
+		first, _ := cache.Get(secret, creds)
+		secret.ResourceVersion = "2"
+		second, _ := cache.Get(secret, creds)
+		Expect(second).NotTo(BeIdenticalTo(first))
+		Expect(cache.Len()).To(Equal(1))
+	})
+
+	It("rebuilds the client when credentials change", func() {
+		first, _ := cache.Get(secret, creds)
+		second, _ := cache.Get(secret, &pdclient.Credentials{APIVersion: "3.1", APIToken: "u"})
+		Expect(second).NotTo(BeIdenticalTo(first))
+	})
+
+	It("keeps separate clients per secret", func() {
+		first, _ := cache.Get(secret, creds)
+		other := secret.DeepCopy()
+		other.UID = "uid-2"
+		second, _ := cache.Get(other, creds)
+		Expect(second).NotTo(BeIdenticalTo(first))
+		Expect(cache.Len()).To(Equal(2))
+	})
+
+	It("drops invalidated clients", func() {
+		first, _ := cache.Get(secret, creds)
+		cache.Invalidate(secret.UID)
+		Expect(cache.Len()).To(BeZero())
+		second, _ := cache.Get(secret, creds)
+		Expect(second).NotTo(BeIdenticalTo(first))
+	})
+
+	It("drops clients of removed secrets", func() {
+		first, _ := cache.Get(secret, creds)
+		cache.Remove(secret.UID)
+		Expect(cache.Len()).To(BeZero())
+		second, _ := cache.Get(secret, creds)
+		Expect(second).NotTo(BeIdenticalTo(first))
+	})
+
+	It("has no snapshot when polling is disabled", func() {
+		client, _ := cache.Get(secret, creds)
+		Expect(client.Snapshot).To(BeNil())
+	})
+
+	Context("with polling", func() {
+		var (
+			server   *httptest.Server
+			requests int
+		)
+
+		BeforeEach(func() {
+			requests = 0
+			server = httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					requests++
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = w.Write([]byte(`{"checks": [{"id": 42, "name": "polled", "type": "http", "status": "up"}]}`))
+				},
+			))
+			cache = pdclient.NewCache(pdclient.CacheConfig{
+				BaseURL:      server.URL + "/api/",
+				PollInterval: time.Minute,
+				Logger:       zap.Logger(true),
+			})
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("fills the snapshots of all accounts", func() {
+			client, _ := cache.Get(secret, creds)
+			Expect(client.Snapshot).NotTo(BeNil())
+			_, ok := client.Snapshot.Get(42)
+			Expect(ok).To(BeFalse())
+
+			cache.Poll()
+			check, ok := client.Snapshot.Get(42)
+			Expect(ok).To(BeTrue())
+			Expect(check.Name).To(Equal("polled"))
+			Expect(check.Type.Name).To(Equal("http"))
+		})
+
+		It("keeps the snapshot when the client is rebuilt", func() {
+			first, _ := cache.Get(secret, creds)
+			secret.ResourceVersion = "2"
+			second, _ := cache.Get(secret, creds)
+			Expect(second.Snapshot).To(BeIdenticalTo(first.Snapshot))
+		})
+
+		It("shares the snapshot between secrets with credentials of the same account", func() {
+			other := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				UID: "uid-2", ResourceVersion: "1",
+			}}
+			first, _ := cache.Get(secret, creds)
+			second, _ := cache.Get(other, &pdclient.Credentials{APIVersion: "3.1", APIToken: "t"})
+			Expect(second).NotTo(BeIdenticalTo(first))
+			Expect(second.Snapshot).To(BeIdenticalTo(first.Snapshot))
+
+			cache.Poll()
+			Expect(requests).To(Equal(1))
+
+			cache.Remove(secret.UID)
+			cache.Poll()
+			Expect(requests).To(Equal(2))
+		})
+
+		It("keeps separate snapshots of different accounts", func() {
+			other := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				UID: "uid-2", ResourceVersion: "1",
+			}}
+			first, _ := cache.Get(secret, creds)
+			second, _ := cache.Get(other, &pdclient.Credentials{APIVersion: "3.1", APIToken: "u"})
+			Expect(second.Snapshot).NotTo(BeIdenticalTo(first.Snapshot))
+
+			cache.Poll()
+			Expect(requests).To(Equal(2))
+		})
+
+		It("uses a new snapshot when credentials are rotated", func() {
+			first, _ := cache.Get(secret, creds)
+			secret.ResourceVersion = "2"
+			second, _ := cache.Get(secret, &pdclient.Credentials{APIVersion: "3.1", APIToken: "u"})
+			Expect(second.Snapshot).NotTo(BeIdenticalTo(first.Snapshot))
+		})
+
+		It("stops polling accounts of removed secrets", func() {
+			client, _ := cache.Get(secret, creds)
+			cache.Remove(secret.UID)
+			cache.Poll()
+			_, ok := client.Snapshot.Get(42)
+			Expect(ok).To(BeFalse())
+			Expect(requests).To(BeZero())
+		})
+
+		It("resets the snapshot when invalidated", func() {
+			client, _ := cache.Get(secret, creds)
+			cache.Poll()
+			cache.Invalidate(secret.UID)
+			_, ok := client.Snapshot.Get(42)
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
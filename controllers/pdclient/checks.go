@@ -0,0 +1,170 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdclient
+
+import (
+	"strconv"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+/*
+check31 is a check as returned by the Pingdom API 3.1 `checks` endpoints.
+
+Type is the name of the type in the list of checks and an object keyed by the
+name of the type with its details when reading a single check. Teams alerted
+by the check are only returned as objects, there are no `teamids`, and whether
+the check is paused is only reported in its status.
+*/
+type check31 struct {
+	ID                    int                         `json:"id"`
+	Name                  string                      `json:"name"`
+	Hostname              string                      `json:"hostname"`
+	Resolution            int                         `json:"resolution"`
+	Status                string                      `json:"status"`
+	Created               int64                       `json:"created"`
+	LastErrorTime         int64                       `json:"lasterrortime"`
+	LastTestTime          int64                       `json:"lasttesttime"`
+	LastResponseTime      int64                       `json:"lastresponsetime"`
+	ResponseTimeThreshold int                         `json:"responsetime_threshold"`
+	IntegrationIds        []int                       `json:"integrationids"`
+	ProbeFilters          []string                    `json:"probe_filters"`
+	Type                  pingdom.CheckResponseType   `json:"type"`
+	Tags                  []pingdom.CheckResponseTag  `json:"tags"`
+	UserIds               []int                       `json:"userids"`
+	Teams                 []pingdom.CheckTeamResponse `json:"teams"`
+}
+
+// counts31 are the numbers of checks returned with the 3.1 list of checks.
+type counts31 struct {
+	Total    int `json:"total"`
+	Limited  int `json:"limited"`
+	Filtered int `json:"filtered"`
+}
+
+type listChecks31Response struct {
+	Checks []check31 `json:"checks"`
+	Counts counts31  `json:"counts"`
+}
+
+type checkDetails31Response struct {
+	Check check31 `json:"check"`
+}
+
+// response converts the check to the type go-pingdom uses for both versions.
+func (c *check31) response() pingdom.CheckResponse {
+	resp := pingdom.CheckResponse{
+		ID:                    c.ID,
+		Name:                  c.Name,
+		Hostname:              c.Hostname,
+		Resolution:            c.Resolution,
+		Status:                c.Status,
+		Created:               c.Created,
+		LastErrorTime:         c.LastErrorTime,
+		LastTestTime:          c.LastTestTime,
+		LastResponseTime:      c.LastResponseTime,
+		ResponseTimeThreshold: c.ResponseTimeThreshold,
+		IntegrationIds:        c.IntegrationIds,
+		ProbeFilters:          c.ProbeFilters,
+		Paused:                c.Status == "paused",
+		Type:                  c.Type,
+		UserIds:               c.UserIds,
+		Tags:                  c.Tags,
+		Teams:                 c.Teams,
+	}
+	if c.Teams != nil {
+		resp.TeamIds = make([]int, len(c.Teams))
+		for i := range c.Teams {
+			resp.TeamIds[i] = c.Teams[i].ID
+		}
+	}
+	return resp
+}
+
+/*
+ListChecks lists checks of the account filtered by params.
+
+With API version 3.1 the payload is decoded explicitly and the list is read
+page by page until all checks matching params are returned, other versions
+are handled by go-pingdom.
+*/
+func ListChecks(client *pingdom.Client, apiVersion string, params map[string]string) ([]pingdom.CheckResponse, error) {
+	if apiVersion != APIVersion31 {
+		if params == nil {
+			return client.Checks.List()
+		}
+		return client.Checks.List(params)
+	}
+
+	var checks []pingdom.CheckResponse
+	for {
+		page := map[string]string{}
+		for k, v := range params {
+			page[k] = v
+		}
+		if len(checks) > 0 {
+			page["offset"] = strconv.Itoa(len(checks))
+		}
+		req, err := client.NewRequest("GET", "/checks", page)
+		if err != nil {
+			return nil, err
+		}
+		resp := &listChecks31Response{}
+		if _, err := client.Do(req, resp); err != nil {
+			return nil, err
+		}
+		for i := range resp.Checks {
+			checks = append(checks, resp.Checks[i].response())
+		}
+		if len(resp.Checks) == 0 || params["limit"] != "" || len(checks) >= resp.Counts.Filtered {
+			return checks, nil
+		}
+	}
+}
+
+/*
+ReadCheck reads a check with all its details, including IDs of teams it
+alerts.
+
+With API version 3.1 the payload is decoded explicitly, other versions are
+handled by go-pingdom.
+*/
+func ReadCheck(client *pingdom.Client, apiVersion string, id int) (*pingdom.CheckResponse, error) {
+	if apiVersion != APIVersion31 {
+		return client.Checks.Read(id)
+	}
+
+	req, err := client.NewRequest("GET", "/checks/"+strconv.Itoa(id), map[string]string{
+		"include_teams": "true",
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := &checkDetails31Response{}
+	if _, err := client.Do(req, resp); err != nil {
+		return nil, err
+	}
+	check := resp.Check.response()
+	if check.TeamIds == nil {
+		check.TeamIds = []int{}
+	}
+	return &check, nil
+}
+
+// ListChecks lists checks of the account filtered by params.
+func (c *Client) ListChecks(params map[string]string) ([]pingdom.CheckResponse, error) {
+	return ListChecks(c.Client, c.APIVersion, params)
+}
@@ -0,0 +1,148 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package pdclient initialises Pingdom API clients from credentials stored in
+Kubernetes Secrets, supporting both the deprecated 2.1 API (user, password and
+application key) and the 3.1 API (bearer token).
+*/
+package pdclient
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Supported versions of the Pingdom API
+const (
+	APIVersion21 = "2.1"
+	APIVersion31 = "3.1"
+)
+
+// DefaultBaseURL is the base URL of Pingdom API, without the version
+const DefaultBaseURL = "https://api.pingdom.com/api/"
+
+// Keys in the credentials secret
+const (
+	UserKey       = "user"
+	PasswordKey   = "password"
+	AppKeyKey     = "appKey"
+	APITokenKey   = "apiToken"
+	APIVersionKey = "apiVersion"
+)
+
+/*
+Credentials hold everything needed to authenticate with the Pingdom API.
+
+For API version 2.1 User, Password and AppKey are used, for 3.1 only APIToken.
+*/
+type Credentials struct {
+	APIVersion string
+	User       string
+	Password   string
+	AppKey     string
+	APIToken   string
+}
+
+/*
+FromSecret reads Pingdom API credentials from a Secret.
+
+The API version is apiVersion if it's not empty, otherwise it's taken from the
+`apiVersion` key if set, otherwise it's 3.1 if the Secret contains an
+`apiToken` and 2.1 if not. For version 2.1 the application key is taken from
+`appKey` in the Secret if it's there, otherwise defaultAppKey is used.
+*/
+func FromSecret(secret *corev1.Secret, apiVersion, defaultAppKey string) (*Credentials, error) {
+	creds := &Credentials{APIVersion: APIVersion21}
+	if secret.Data[APITokenKey] != nil {
+		creds.APIVersion = APIVersion31
+	}
+	if secret.Data[APIVersionKey] != nil {
+		creds.APIVersion = string(secret.Data[APIVersionKey])
+	}
+	if apiVersion != "" {
+		creds.APIVersion = apiVersion
+	}
+
+	switch creds.APIVersion {
+	case APIVersion21:
+		if secret.Data[UserKey] == nil {
+			return nil, fmt.Errorf("Pingdom API username not found in secret")
+		}
+		if secret.Data[PasswordKey] == nil {
+			return nil, fmt.Errorf("Pingdom API password not found in secret")
+		}
+		creds.User = string(secret.Data[UserKey])
+		creds.Password = string(secret.Data[PasswordKey])
+		creds.AppKey = defaultAppKey
+		if secret.Data[AppKeyKey] != nil {
+			creds.AppKey = string(secret.Data[AppKeyKey])
+		}
+		if creds.AppKey == "" {
+			return nil, appKeyMissingError
+		}
+	case APIVersion31:
+		if secret.Data[APITokenKey] == nil {
+			return nil, fmt.Errorf("Pingdom API token not found in secret")
+		}
+		creds.APIToken = string(secret.Data[APITokenKey])
+	default:
+		return nil, fmt.Errorf(
+			"unsupported Pingdom API version %q, must be one of: %s, %s",
+			creds.APIVersion, APIVersion21, APIVersion31,
+		)
+	}
+	return creds, nil
+}
+
+/*
+New returns a Pingdom API client for given credentials.
+
+baseURL is the base URL of the API without the version, DefaultBaseURL is used
+if it's empty. Requests are throttled with the limiter, unless it's nil.
+*/
+func New(creds *Credentials, baseURL string, limiter *Limiter) (*pingdom.Client, error) {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	config := pingdom.ClientConfig{
+		BaseURL: baseURL + creds.APIVersion,
+	}
+	transport := http.DefaultTransport
+
+	switch creds.APIVersion {
+	case APIVersion21:
+		config.User = creds.User
+		config.Password = creds.Password
+		config.APIKey = creds.AppKey
+	case APIVersion31:
+		transport = &bearerTransport{
+			token: creds.APIToken,
+			base:  transport,
+		}
+	default:
+		return nil, fmt.Errorf("unsupported Pingdom API version %q", creds.APIVersion)
+	}
+
+	transport = &metricsTransport{base: transport}
+	if limiter != nil {
+		transport = &limitTransport{limiter: limiter, base: transport}
+	}
+	config.HTTPClient = &http.Client{Transport: transport}
+	return pingdom.NewClientWithConfig(config)
+}
@@ -0,0 +1,149 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdclient_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+
+	"gitlab.com/mig4/pingdom-operator/controllers/metrics"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+)
+
+func secretWith(data map[string]string) *corev1.Secret {
+	secret := &corev1.Secret{Data: map[string][]byte{}}
+	for key, value := range data {
+		secret.Data[key] = []byte(value)
+	}
+	return secret
+}
+
+var _ = Describe("FromSecret", func() {
+	DescribeTable("reads credentials",
+		func(data map[string]string, apiVersion, defaultAppKey string, expected *pdclient.Credentials) {
+			Expect(pdclient.FromSecret(secretWith(data), apiVersion, defaultAppKey)).
+				To(Equal(expected))
+		},
+		Entry("2.1 with default app key",
+			map[string]string{"user": "u", "password": "p"}, "", "key",
+			&pdclient.Credentials{APIVersion: "2.1", User: "u", Password: "p", AppKey: "key"},
+		),
+		Entry("2.1 with app key from secret",
+			map[string]string{"user": "u", "password": "p", "appKey": "own"}, "", "key",
+			&pdclient.Credentials{APIVersion: "2.1", User: "u", Password: "p", AppKey: "own"},
+		),
+		Entry("3.1 inferred from token",
+			map[string]string{"apiToken": "t"}, "", "",
+			&pdclient.Credentials{APIVersion: "3.1", APIToken: "t"},
+		),
+		Entry("explicit version",
+			map[string]string{"apiToken": "t", "apiVersion": "3.1", "user": "u"}, "", "",
+			&pdclient.Credentials{APIVersion: "3.1", APIToken: "t"},
+		),
+		Entry("version override",
+			map[string]string{"apiToken": "t", "user": "u", "password": "p"}, "2.1", "key",
+			&pdclient.Credentials{APIVersion: "2.1", User: "u", Password: "p", AppKey: "key"},
+		),
+	)
+
+	DescribeTable("rejects invalid secrets",
+		func(data map[string]string, defaultAppKey string) {
+			_, err := pdclient.FromSecret(secretWith(data), "", defaultAppKey)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("2.1 without user", map[string]string{"password": "p"}, "key"),
+		Entry("2.1 without password", map[string]string{"user": "u"}, "key"),
+		Entry("3.1 without token",
+			map[string]string{"apiVersion": "3.1", "user": "u", "password": "p"}, "key",
+		),
+		Entry("unknown version", map[string]string{"apiToken": "t", "apiVersion": "3.0"}, ""),
+	)
+
+	It("reports a missing app key", func() {
+		_, err := pdclient.FromSecret(
+			secretWith(map[string]string{"user": "u", "password": "p"}), "", "",
+		)
+		Expect(pdclient.IsAppKeyMissing(err)).To(BeTrue())
+	})
+})
+
+var _ = Describe("New", func() {
+	var (
+		server  *httptest.Server
+		request *http.Request
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				request = r
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"checks": []}`))
+			},
+		))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("uses basic auth and app key for 2.1", func() {
+		client, err := pdclient.New(&pdclient.Credentials{
+			APIVersion: "2.1", User: "u", Password: "p", AppKey: "key",
+		}, server.URL+"/api/", nil)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.Checks.List()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(request.URL.Path).To(Equal("/api/2.1/checks"))
+		user, password, ok := request.BasicAuth()
+		Expect(ok).To(BeTrue())
+		Expect(user).To(Equal("u"))
+		Expect(password).To(Equal("p"))
+		Expect(request.Header.Get("App-Key")).To(Equal("key"))
+	})
+
+	It("uses a bearer token for 3.1", func() {
+		client, err := pdclient.New(&pdclient.Credentials{
+			APIVersion: "3.1", APIToken: "t",
+		}, server.URL+"/api/", nil)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.Checks.List()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(request.URL.Path).To(Equal("/api/3.1/checks"))
+		Expect(request.Header.Get("Authorization")).To(Equal("Bearer t"))
+		Expect(request.Header.Get("App-Key")).To(BeEmpty())
+	})
+	It("records metrics of requests", func() {
+		counter := metrics.APIRequests.WithLabelValues("GET", metrics.OutcomeSuccess)
+		before := testutil.ToFloat64(counter)
+		client, err := pdclient.New(&pdclient.Credentials{
+			APIVersion: "3.1", APIToken: "t",
+		}, server.URL+"/api/", nil)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.Checks.List()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(testutil.ToFloat64(counter)).To(Equal(before + 1))
+	})
+})
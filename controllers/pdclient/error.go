@@ -0,0 +1,31 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdclient
+
+import "github.com/giantswarm/microerror"
+
+// An error returned when no Pingdom application key is configured, either
+// operator-wide or in the credentials secret
+var appKeyMissingError = &microerror.Error{
+	Kind: "appKeyMissingError",
+	Desc: "Pingdom application key is not configured, set it with --pingdom-app-key, PINGDOM_APP_KEY or `appKey` in the credentials secret",
+}
+
+// IsAppKeyMissing returns true if given error indicates no Pingdom
+// application key is configured.
+func IsAppKeyMissing(err error) bool {
+	return microerror.Cause(err) == appKeyMissingError
+}
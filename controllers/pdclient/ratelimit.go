@@ -0,0 +1,176 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdclient
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Headers in which Pingdom API reports the remaining number of requests in
+// the short and long rate limiting windows, in the format:
+// `Remaining: 394 Time until reset: 3589`
+const (
+	reqLimitShortHeader = "Req-Limit-Short"
+	reqLimitLongHeader  = "Req-Limit-Long"
+)
+
+// How long to back off after a 429 response which doesn't say how long to
+// wait
+const defaultRetryAfter = time.Minute
+
+// The longest a request waits for the token bucket before failing with a
+// RateLimitError instead, so reconciles don't hold up workers
+const maxLimiterWait = 5 * time.Second
+
+// RateLimitError is returned when a request to the Pingdom API is not made
+// because the request limit for the credentials is reached.
+type RateLimitError struct {
+	// RetryAfter is how long to wait before retrying
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("Pingdom API request limit reached, retry after %v", e.RetryAfter)
+}
+
+/*
+Limiter throttles requests to the Pingdom API made with one set of
+credentials (i.e. one Pingdom account).
+
+Requests are limited with a token bucket and, once the API reports no
+requests remain (in rate limiting headers or with a 429 response), blocked
+altogether until the limit resets.
+*/
+type Limiter struct {
+	bucket *rate.Limiter
+
+	mu           sync.Mutex
+	blockedUntil time.Time
+	now          func() time.Time
+}
+
+// NewLimiter returns a limiter allowing given number of requests per second
+// on average with bursts of given size; requestsPerSecond <= 0 disables the
+// token bucket, leaving only the limits reported by the API.
+func NewLimiter(requestsPerSecond float64, burst int) *Limiter {
+	limit := rate.Limit(requestsPerSecond)
+	if requestsPerSecond <= 0 {
+		limit = rate.Inf
+	}
+	return &Limiter{
+		bucket: rate.NewLimiter(limit, burst),
+		now:    time.Now,
+	}
+}
+
+/*
+acquire waits until a request can be made; it returns a RateLimitError if the
+API limit was reached or the wait would take too long.
+*/
+func (l *Limiter) acquire() error {
+	l.mu.Lock()
+	now := l.now()
+	blockedFor := l.blockedUntil.Sub(now)
+	l.mu.Unlock()
+	if blockedFor > 0 {
+		return &RateLimitError{RetryAfter: blockedFor}
+	}
+
+	reservation := l.bucket.ReserveN(now, 1)
+	delay := reservation.DelayFrom(now)
+	if delay > maxLimiterWait {
+		reservation.CancelAt(now)
+		return &RateLimitError{RetryAfter: delay}
+	}
+	time.Sleep(delay)
+	return nil
+}
+
+/*
+observe updates the limiter with the rate limits reported in a response,
+returning true and the delay to wait before retrying if the request was
+rejected with 429 Too Many Requests.
+*/
+func (l *Limiter) observe(resp *http.Response) (time.Duration, bool) {
+	var blockFor time.Duration
+	for _, header := range []string{reqLimitShortHeader, reqLimitLongHeader} {
+		remaining, reset, ok := parseReqLimit(resp.Header.Get(header))
+		if ok && remaining <= 0 && reset > blockFor {
+			blockFor = reset
+		}
+	}
+
+	limited := resp.StatusCode == http.StatusTooManyRequests
+	if limited {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			blockFor = time.Duration(seconds) * time.Second
+		} else if blockFor == 0 {
+			blockFor = defaultRetryAfter
+		}
+	}
+
+	if blockFor > 0 {
+		l.mu.Lock()
+		if until := l.now().Add(blockFor); until.After(l.blockedUntil) {
+			l.blockedUntil = until
+		}
+		l.mu.Unlock()
+	}
+	return blockFor, limited
+}
+
+// parseReqLimit parses a value of a Req-Limit-* header returning the number
+// of remaining requests and time until the limit is reset.
+func parseReqLimit(value string) (int, time.Duration, bool) {
+	var remaining, reset int
+	if value == "" {
+		return 0, 0, false
+	}
+	if _, err := fmt.Sscanf(
+		value, "Remaining: %d Time until reset: %d", &remaining, &reset,
+	); err != nil {
+		return 0, 0, false
+	}
+	return remaining, time.Duration(reset) * time.Second, true
+}
+
+// limitTransport applies a Limiter to requests, turning 429 responses into a
+// RateLimitError.
+type limitTransport struct {
+	limiter *Limiter
+	base    http.RoundTripper
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.acquire(); err != nil {
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if retryAfter, limited := t.limiter.observe(resp); limited {
+		resp.Body.Close()
+		return nil, &RateLimitError{RetryAfter: retryAfter}
+	}
+	return resp, nil
+}
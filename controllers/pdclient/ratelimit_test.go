@@ -0,0 +1,116 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdclient_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+)
+
+var _ = Describe("Limiter", func() {
+	var (
+		server   *httptest.Server
+		requests int
+		respond  func(w http.ResponseWriter)
+	)
+
+	newClient := func(limiter *pdclient.Limiter) *pingdom.Client {
+		client, err := pdclient.New(&pdclient.Credentials{
+			APIVersion: "3.1", APIToken: "t",
+		}, server.URL+"/api/", limiter)
+		Expect(err).NotTo(HaveOccurred())
+		return client
+	}
+	rateLimitError := func(err error) *pdclient.RateLimitError {
+		Expect(err).To(BeAssignableToTypeOf(&url.Error{}))
+		Expect(err.(*url.Error).Err).To(BeAssignableToTypeOf(&pdclient.RateLimitError{}))
+		return err.(*url.Error).Err.(*pdclient.RateLimitError)
+	}
+
+	BeforeEach(func() {
+		requests = 0
+		respond = func(w http.ResponseWriter) {
+			_, _ = w.Write([]byte(`{"checks": []}`))
+		}
+		server = httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.Header().Set("Content-Type", "application/json")
+				respond(w)
+			},
+		))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("allows requests within the limit", func() {
+		client := newClient(pdclient.NewLimiter(0, 1))
+		for i := 0; i < 3; i++ {
+			_, err := client.Checks.List()
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(requests).To(Equal(3))
+	})
+
+	It("fails requests which would wait too long for the token bucket", func() {
+		client := newClient(pdclient.NewLimiter(0.01, 1))
+		_, err := client.Checks.List()
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.Checks.List()
+		Expect(rateLimitError(err).RetryAfter).To(BeNumerically(">", 90*time.Second))
+		Expect(requests).To(Equal(1))
+	})
+
+	It("blocks requests once API reports no remaining requests", func() {
+		respond = func(w http.ResponseWriter) {
+			w.Header().Set("Req-Limit-Short", "Remaining: 0 Time until reset: 120")
+			w.Header().Set("Req-Limit-Long", "Remaining: 1000 Time until reset: 3600")
+			_, _ = w.Write([]byte(`{"checks": []}`))
+		}
+		client := newClient(pdclient.NewLimiter(0, 1))
+		_, err := client.Checks.List()
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.Checks.List()
+		Expect(rateLimitError(err).RetryAfter).To(BeNumerically("~", 120*time.Second, time.Second))
+		Expect(requests).To(Equal(1))
+	})
+
+	It("turns 429 responses into rate limit errors", func() {
+		respond = func(w http.ResponseWriter) {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+		client := newClient(pdclient.NewLimiter(0, 1))
+		_, err := client.Checks.List()
+		Expect(rateLimitError(err).RetryAfter).To(Equal(30 * time.Second))
+
+		_, err = client.Checks.List()
+		Expect(rateLimitError(err).RetryAfter).To(BeNumerically("~", 30*time.Second, time.Second))
+		Expect(requests).To(Equal(1))
+	})
+})
@@ -0,0 +1,138 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdclient
+
+import (
+	"sync"
+	"time"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+/*
+Snapshot holds all checks of a Pingdom account as returned by a single
+Checks.List request, so the state of many checks can be refreshed without a
+request for each.
+
+The list endpoint only returns a summary of each check (e.g. no HTTP check
+details), so users fall back to reading a check individually when they need
+more. A check is also read individually after it's modified, until a user
+reports it was read (see MarkChanged, MarkRead), and when it wasn't read
+individually for longer than detailsMaxAge, so changes to its details made
+outside of the operator are picked up eventually.
+
+All methods are safe to call on a nil Snapshot, which never has any checks.
+*/
+type Snapshot struct {
+	maxAge        time.Duration
+	detailsMaxAge time.Duration
+	now           func() time.Time
+
+	mu       sync.Mutex
+	checks   map[int]pingdom.CheckResponse
+	polledAt time.Time
+	changed  map[int]bool
+	readAt   map[int]time.Time
+}
+
+// NewSnapshot returns an empty Snapshot whose contents expire after maxAge
+// if not updated. Checks not read individually within detailsMaxAge are not
+// returned, 0 means details never expire.
+func NewSnapshot(maxAge, detailsMaxAge time.Duration) *Snapshot {
+	return &Snapshot{
+		maxAge:        maxAge,
+		detailsMaxAge: detailsMaxAge,
+		now:           time.Now,
+		checks:        map[int]pingdom.CheckResponse{},
+		changed:       map[int]bool{},
+		readAt:        map[int]time.Time{},
+	}
+}
+
+// Update replaces contents of the Snapshot with given checks.
+func (s *Snapshot) Update(checks []pingdom.CheckResponse) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checks = make(map[int]pingdom.CheckResponse, len(checks))
+	for _, check := range checks {
+		s.checks[check.ID] = check
+	}
+	s.polledAt = s.now()
+}
+
+// Reset discards contents of the Snapshot.
+func (s *Snapshot) Reset() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checks = map[int]pingdom.CheckResponse{}
+	s.polledAt = time.Time{}
+}
+
+/*
+Get returns the summary of the check with given ID from the latest poll.
+
+It returns false if the check is not in the Snapshot, the Snapshot expired,
+the check was changed since it was last read individually or its details
+expired.
+*/
+func (s *Snapshot) Get(id int) (*pingdom.CheckResponse, bool) {
+	if s == nil {
+		return nil, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.polledAt.IsZero() || s.now().Sub(s.polledAt) > s.maxAge || s.changed[id] {
+		return nil, false
+	}
+	if readAt, ok := s.readAt[id]; s.detailsMaxAge > 0 &&
+		(!ok || s.now().Sub(readAt) > s.detailsMaxAge) {
+		return nil, false
+	}
+	check, ok := s.checks[id]
+	if !ok {
+		return nil, false
+	}
+	return &check, true
+}
+
+// MarkChanged records the check with given ID was modified, so it's not
+// returned from the Snapshot until MarkRead is called.
+func (s *Snapshot) MarkChanged(id int) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.changed[id] = true
+}
+
+// MarkRead records the check with given ID was read individually, so it can
+// be returned from the Snapshot again.
+func (s *Snapshot) MarkRead(id int) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.changed, id)
+	s.readAt[id] = s.now()
+}
@@ -0,0 +1,92 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdclient_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+)
+
+var _ = Describe("Snapshot", func() {
+	var snapshot *pdclient.Snapshot
+
+	BeforeEach(func() {
+		snapshot = pdclient.NewSnapshot(time.Minute, 0)
+		snapshot.Update([]pingdom.CheckResponse{{ID: 1, Name: "one"}})
+	})
+
+	It("returns checks from the latest update", func() {
+		check, ok := snapshot.Get(1)
+		Expect(ok).To(BeTrue())
+		Expect(check.Name).To(Equal("one"))
+
+		snapshot.Update([]pingdom.CheckResponse{{ID: 2, Name: "two"}})
+		_, ok = snapshot.Get(1)
+		Expect(ok).To(BeFalse())
+		_, ok = snapshot.Get(2)
+		Expect(ok).To(BeTrue())
+	})
+
+	It("doesn't return changed checks until read", func() {
+		snapshot.MarkChanged(1)
+		_, ok := snapshot.Get(1)
+		Expect(ok).To(BeFalse())
+
+		snapshot.Update([]pingdom.CheckResponse{{ID: 1, Name: "one"}})
+		_, ok = snapshot.Get(1)
+		Expect(ok).To(BeFalse())
+
+		snapshot.MarkRead(1)
+		_, ok = snapshot.Get(1)
+		Expect(ok).To(BeTrue())
+	})
+
+	It("doesn't return checks whose details expired", func() {
+		snapshot = pdclient.NewSnapshot(time.Minute, time.Millisecond)
+		snapshot.Update([]pingdom.CheckResponse{{ID: 1, Name: "one"}})
+		_, ok := snapshot.Get(1)
+		Expect(ok).To(BeFalse())
+
+		snapshot.MarkRead(1)
+		_, ok = snapshot.Get(1)
+		Expect(ok).To(BeTrue())
+
+		time.Sleep(2 * time.Millisecond)
+		_, ok = snapshot.Get(1)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("expires", func() {
+		snapshot = pdclient.NewSnapshot(time.Nanosecond, 0)
+		snapshot.Update([]pingdom.CheckResponse{{ID: 1, Name: "one"}})
+		time.Sleep(time.Millisecond)
+		_, ok := snapshot.Get(1)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("is empty when nil", func() {
+		var nilSnapshot *pdclient.Snapshot
+		nilSnapshot.Update([]pingdom.CheckResponse{{ID: 1}})
+		nilSnapshot.MarkChanged(1)
+		_, ok := nilSnapshot.Get(1)
+		Expect(ok).To(BeFalse())
+	})
+})
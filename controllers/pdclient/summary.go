@@ -0,0 +1,161 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdclient
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+// Statuses of a check reported in its outage summary
+const (
+	OutageStatusUp      = "up"
+	OutageStatusDown    = "down"
+	OutageStatusUnknown = "unknown"
+)
+
+/*
+AverageSummary is the summary of a check's response time and uptime over a
+period, as returned by the `summary.average` endpoint (not supported by
+go-pingdom).
+*/
+type AverageSummary struct {
+	// AvgResponse is the average response time in milliseconds
+	AvgResponse int `json:"avgresponse"`
+
+	// TotalUp, TotalDown and TotalUnknown are the number of seconds the
+	// check was up, down and in an unknown state during the period
+	TotalUp      int `json:"totalup"`
+	TotalDown    int `json:"totaldown"`
+	TotalUnknown int `json:"totalunknown"`
+}
+
+/*
+Uptime returns the percentage of time the check was up during the period,
+excluding the time its status was unknown, or false if the check was never
+tested during the period.
+*/
+func (s *AverageSummary) Uptime() (float64, bool) {
+	total := s.TotalUp + s.TotalDown
+	if total == 0 {
+		return 0, false
+	}
+	return float64(s.TotalUp) * 100 / float64(total), true
+}
+
+type averageSummaryResponse struct {
+	Summary struct {
+		ResponseTime struct {
+			AvgResponse int `json:"avgresponse"`
+		} `json:"responsetime"`
+		Status struct {
+			TotalUp      int `json:"totalup"`
+			TotalDown    int `json:"totaldown"`
+			TotalUnknown int `json:"totalunknown"`
+		} `json:"status"`
+	} `json:"summary"`
+}
+
+// OutageState is a period during which a check had the same status, as
+// returned by the `summary.outage` endpoint (not supported by go-pingdom).
+type OutageState struct {
+	Status   string `json:"status"`
+	TimeFrom int64  `json:"timefrom"`
+	TimeTo   int64  `json:"timeto"`
+}
+
+type outageSummaryResponse struct {
+	Summary struct {
+		States []OutageState `json:"states"`
+	} `json:"summary"`
+}
+
+/*
+OutageUptime returns the percentage of time between given times a check was
+up according to given states, excluding the time its status was unknown, or
+false if the check was never tested during the period.
+
+It allows computing uptime over several periods from a single outage summary
+of the longest one, rather than reading an average summary of each.
+*/
+func OutageUptime(states []OutageState, from, to time.Time) (float64, bool) {
+	var up, down int64
+	for _, state := range states {
+		start, end := state.TimeFrom, state.TimeTo
+		if start < from.Unix() {
+			start = from.Unix()
+		}
+		if end > to.Unix() {
+			end = to.Unix()
+		}
+		if end <= start {
+			continue
+		}
+		switch state.Status {
+		case OutageStatusUp:
+			up += end - start
+		case OutageStatusDown:
+			down += end - start
+		}
+	}
+	if up+down == 0 {
+		return 0, false
+	}
+	return float64(up) * 100 / float64(up+down), true
+}
+
+// SummaryAverage returns the average response time and uptime of a check
+// between given times.
+func SummaryAverage(client *pingdom.Client, checkID int, from, to time.Time) (*AverageSummary, error) {
+	req, err := client.NewRequest("GET", "/summary.average/"+strconv.Itoa(checkID), map[string]string{
+		"from":          strconv.FormatInt(from.Unix(), 10),
+		"to":            strconv.FormatInt(to.Unix(), 10),
+		"includeuptime": "true",
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := &averageSummaryResponse{}
+	if _, err := client.Do(req, resp); err != nil {
+		return nil, err
+	}
+	return &AverageSummary{
+		AvgResponse:  resp.Summary.ResponseTime.AvgResponse,
+		TotalUp:      resp.Summary.Status.TotalUp,
+		TotalDown:    resp.Summary.Status.TotalDown,
+		TotalUnknown: resp.Summary.Status.TotalUnknown,
+	}, nil
+}
+
+// SummaryOutage returns the periods of up, down and unknown status of a check
+// between given times, oldest first.
+func SummaryOutage(client *pingdom.Client, checkID int, from, to time.Time) ([]OutageState, error) {
+	req, err := client.NewRequest("GET", "/summary.outage/"+strconv.Itoa(checkID), map[string]string{
+		"from":  strconv.FormatInt(from.Unix(), 10),
+		"to":    strconv.FormatInt(to.Unix(), 10),
+		"order": "asc",
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := &outageSummaryResponse{}
+	if _, err := client.Do(req, resp); err != nil {
+		return nil, err
+	}
+	return resp.Summary.States, nil
+}
@@ -0,0 +1,85 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdclient
+
+import (
+	"strconv"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+// TMSStep is a step of the script of a transaction check.
+type TMSStep struct {
+	// Fn is the function of the step, e.g. `go_to`, `click`, `fill`,
+	// `exists` or `contains_text`
+	Fn string `json:"fn"`
+
+	// Args are arguments of the function, e.g. `url`, `element`, `input`
+	// and `value`
+	Args map[string]string `json:"args"`
+}
+
+/*
+TMSCheck is a transaction check of the Pingdom API 3.1 `tms/check` endpoints
+(not supported by go-pingdom).
+
+ID, Status and the times (Unix timestamps) are only returned when reading a
+check, the other fields are sent when creating or updating it as well.
+*/
+type TMSCheck struct {
+	ID         int       `json:"id,omitempty"`
+	Name       string    `json:"name"`
+	Active     bool      `json:"active"`
+	Interval   int       `json:"interval"`
+	Region     string    `json:"region"`
+	ContactIDs []int     `json:"contact_ids"`
+	TeamIDs    []int     `json:"team_ids"`
+	Steps      []TMSStep `json:"steps"`
+
+	Status            string `json:"status,omitempty"`
+	CreatedAt         int64  `json:"created_at,omitempty"`
+	ModifiedAt        int64  `json:"modified_at,omitempty"`
+	LastDowntimeStart int64  `json:"last_downtime_start,omitempty"`
+	LastDowntimeEnd   int64  `json:"last_downtime_end,omitempty"`
+}
+
+// ReadTMSCheck returns the transaction check with given ID.
+func ReadTMSCheck(client *pingdom.Client, id int) (*TMSCheck, error) {
+	check := &TMSCheck{}
+	if err := doJSON(client, "GET", "/tms/check/"+strconv.Itoa(id), nil, check); err != nil {
+		return nil, err
+	}
+	return check, nil
+}
+
+// CreateTMSCheck creates a transaction check and returns its ID.
+func CreateTMSCheck(client *pingdom.Client, check *TMSCheck) (int, error) {
+	created := &TMSCheck{}
+	if err := doJSON(client, "POST", "/tms/check", check, created); err != nil {
+		return 0, err
+	}
+	return created.ID, nil
+}
+
+// UpdateTMSCheck replaces the transaction check with given ID.
+func UpdateTMSCheck(client *pingdom.Client, id int, check *TMSCheck) error {
+	return doJSON(client, "PUT", "/tms/check/"+strconv.Itoa(id), check, &TMSCheck{})
+}
+
+// DeleteTMSCheck deletes the transaction check with given ID.
+func DeleteTMSCheck(client *pingdom.Client, id int) error {
+	return doJSON(client, "DELETE", "/tms/check/"+strconv.Itoa(id), nil, &pingdom.PingdomResponse{})
+}
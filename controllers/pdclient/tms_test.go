@@ -0,0 +1,99 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdclient_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+)
+
+var _ = Describe("TMS", func() {
+	var (
+		server   *httptest.Server
+		client   *pingdom.Client
+		request  *http.Request
+		body     map[string]interface{}
+		response string
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				request = r
+				body = nil
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(response))
+			},
+		))
+		var err error
+		client, err = pdclient.New(&pdclient.Credentials{
+			APIVersion: pdclient.APIVersion31, APIToken: "t",
+		}, server.URL+"/api/", nil)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("creates a transaction check with a JSON body", func() {
+		response = `{"id": 42, "name": "login"}`
+		id, err := pdclient.CreateTMSCheck(client, &pdclient.TMSCheck{
+			Name: "login", Active: true, Interval: 10, Region: "eu",
+			ContactIDs: []int{}, TeamIDs: []int{7},
+			Steps: []pdclient.TMSStep{
+				{Fn: "go_to", Args: map[string]string{"url": "https://example.com"}},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(Equal(42))
+
+		Expect(request.Method).To(Equal(http.MethodPost))
+		Expect(request.URL.Path).To(Equal("/api/3.1/tms/check"))
+		Expect(body).To(Equal(map[string]interface{}{
+			"name": "login", "active": true, "interval": 10.0, "region": "eu",
+			"contact_ids": []interface{}{}, "team_ids": []interface{}{7.0},
+			"steps": []interface{}{map[string]interface{}{
+				"fn": "go_to", "args": map[string]interface{}{"url": "https://example.com"},
+			}},
+		}))
+	})
+
+	It("reads a transaction check with its status", func() {
+		response = `{"id": 42, "name": "login", "active": true, "status": "failing",
+			"interval": 10, "region": "eu", "contact_ids": [1], "team_ids": [],
+			"created_at": 1500000000, "last_downtime_start": 1600000000,
+			"steps": [{"fn": "click", "args": {"element": "#login"}}]}`
+		check, err := pdclient.ReadTMSCheck(client, 42)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(request.URL.Path).To(Equal("/api/3.1/tms/check/42"))
+		Expect(check).To(Equal(&pdclient.TMSCheck{
+			ID: 42, Name: "login", Active: true, Status: "failing",
+			Interval: 10, Region: "eu", ContactIDs: []int{1}, TeamIDs: []int{},
+			CreatedAt: 1500000000, LastDowntimeStart: 1600000000,
+			Steps: []pdclient.TMSStep{{Fn: "click", Args: map[string]string{"element": "#login"}}},
+		}))
+	})
+})
@@ -0,0 +1,86 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdclient
+
+import (
+	"net/http"
+	"time"
+
+	"gitlab.com/mig4/pingdom-operator/controllers/metrics"
+)
+
+/*
+bearerTransport authenticates requests with an API token as required by the
+Pingdom API 3.1.
+
+The go-pingdom client always sets basic authentication and the `App-Key`
+header used by the API 2.1, these are replaced with the bearer token.
+*/
+type bearerTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (t *bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrippers must not modify the request, so work on a copy
+	authReq := new(http.Request)
+	*authReq = *req
+	authReq.Header = make(http.Header, len(req.Header))
+	for key, values := range req.Header {
+		authReq.Header[key] = append([]string(nil), values...)
+	}
+
+	authReq.Header.Del("App-Key")
+	authReq.Header.Set("Authorization", "Bearer "+t.token)
+	return t.base.RoundTrip(authReq)
+}
+
+/*
+metricsTransport records the number, duration and outcome of requests made to
+the Pingdom API.
+
+It sits below the rate limiter so only requests actually sent are recorded.
+*/
+type metricsTransport struct {
+	base http.RoundTripper
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	outcome := requestOutcome(resp, err)
+	metrics.APIRequests.WithLabelValues(req.Method, outcome).Inc()
+	metrics.APIRequestDuration.WithLabelValues(req.Method, outcome).Observe(
+		time.Since(start).Seconds(),
+	)
+	return resp, err
+}
+
+// requestOutcome classifies the result of a request for metrics
+func requestOutcome(resp *http.Response, err error) string {
+	switch {
+	case err != nil:
+		return metrics.OutcomeError
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return metrics.OutcomeRateLimited
+	case resp.StatusCode >= 500:
+		return metrics.OutcomeServerError
+	case resp.StatusCode >= 400:
+		return metrics.OutcomeClientError
+	default:
+		return metrics.OutcomeSuccess
+	}
+}
@@ -0,0 +1,117 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+	alertingreconciler "gitlab.com/mig4/pingdom-operator/controllers/resources/alerting"
+)
+
+// How often alerting contacts and teams are re-synced, besides when they
+// change
+const alertingResyncInterval = 10 * time.Minute
+
+// contactControllerName labels metrics of the PingdomContact controller
+const contactControllerName = "pingdomcontact"
+
+// PingdomContactReconciler reconciles a PingdomContact object
+type PingdomContactReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Recorder record.EventRecorder
+
+	// PdAPIKey is the operator-wide Pingdom application key, see
+	// CheckReconciler
+	PdAPIKey string
+
+	// PdClients is the cache of Pingdom API clients shared by all
+	// reconcilers
+	PdClients *pdclient.Cache
+}
+
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=pingdomcontacts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=pingdomcontacts/status,verbs=get;update;patch
+
+/*
+Reconcile ensures the Pingdom alerting contact of the PingdomContact specified
+in the given request matches its spec.
+*/
+func (r *PingdomContactReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	log := r.Log.WithValues("resource", "pingdomcontact", "namespacedName", req.NamespacedName)
+	sync := &externalSync{
+		Client:         r.Client,
+		log:            log,
+		controller:     contactControllerName,
+		resyncInterval: alertingResyncInterval,
+	}
+
+	var contact observabilityv1alpha1.PingdomContact
+	if err := r.Get(ctx, req.NamespacedName, &contact); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	defer sync.updateStatus(
+		ctx, &contact, &contact.Status, "PingdomContact", "Pingdom alerting contact is in sync",
+	)
+
+	if !contact.GetDeletionTimestamp().IsZero() {
+		contact.Status.SetCondition(
+			observabilityv1alpha1.Deleting, corev1.ConditionTrue,
+			"Deleting", "PingdomContact is being deleted",
+		)
+	}
+
+	pdClient, err := sync.credentialsClient(
+		ctx, &contact, &contact.Status, contact.Spec.CredentialsSecret.Name,
+		contact.Spec.AccountRef, r.PdAPIKey, r.PdClients,
+	)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !requireAPIVersion(pdClient, &contact.Status, pdclient.APIVersion31, "PingdomContact") {
+		// retrying won't help until the credentials change
+		return ctrl.Result{}, nil
+	}
+
+	return sync.run(
+		ctx, &contact, &contact.Status, &contact.Status.ObservedGeneration,
+		alertingreconciler.NewContact(&alertingreconciler.ContactConfig{
+			Logger:   log,
+			Recorder: r.Recorder,
+			PdClient: pdClient.Client,
+			Contact:  &contact,
+		}),
+	)
+}
+
+// SetupWithManager configures this reconciler to be triggered for events
+// pertaining to specified resource kinds.
+func (r *PingdomContactReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&observabilityv1alpha1.PingdomContact{}).
+		Complete(r)
+}
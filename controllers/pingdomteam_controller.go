@@ -0,0 +1,245 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/giantswarm/microerror"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/metrics"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+	alertingreconciler "gitlab.com/mig4/pingdom-operator/controllers/resources/alerting"
+)
+
+// teamControllerName labels metrics of the PingdomTeam controller
+const teamControllerName = "pingdomteam"
+
+// PingdomTeamReconciler reconciles a PingdomTeam object
+type PingdomTeamReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Recorder record.EventRecorder
+
+	// PdAPIKey is the operator-wide Pingdom application key, see
+	// CheckReconciler
+	PdAPIKey string
+
+	// PdClients is the cache of Pingdom API clients shared by all
+	// reconcilers
+	PdClients *pdclient.Cache
+}
+
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=pingdomteams,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=pingdomteams/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=pingdomcontacts,verbs=get;list;watch
+
+/*
+Reconcile ensures the Pingdom alerting team of the PingdomTeam specified in
+the given request matches its spec and has the Pingdom contacts of its member
+PingdomContacts as members.
+*/
+func (r *PingdomTeamReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	log := r.Log.WithValues("resource", "pingdomteam", "namespacedName", req.NamespacedName)
+	sync := &externalSync{
+		Client:         r.Client,
+		log:            log,
+		controller:     teamControllerName,
+		resyncInterval: alertingResyncInterval,
+	}
+
+	var team observabilityv1alpha1.PingdomTeam
+	if err := r.Get(ctx, req.NamespacedName, &team); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	defer func() {
+		sync.updateStatus(ctx, &team, &team.Status, "PingdomTeam", fmt.Sprintf(
+			"Pingdom alerting team has %d members", len(team.Status.MemberIDs),
+		))
+	}()
+
+	deleting := !team.GetDeletionTimestamp().IsZero()
+	if deleting {
+		team.Status.SetCondition(
+			observabilityv1alpha1.Deleting, corev1.ConditionTrue,
+			"Deleting", "PingdomTeam is being deleted",
+		)
+	}
+
+	pdClient, err := sync.credentialsClient(
+		ctx, &team, &team.Status, team.Spec.CredentialsSecret.Name,
+		team.Spec.AccountRef, r.PdAPIKey, r.PdClients,
+	)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !requireAPIVersion(pdClient, &team.Status, pdclient.APIVersion31, "PingdomTeam") {
+		// retrying won't help until the credentials change
+		return ctrl.Result{}, nil
+	}
+
+	var memberIDs []int32
+	if !deleting {
+		memberIDs, err = contactIDs(ctx, r.Client, team.GetNamespace(), team.Spec.Members)
+		if IsContactNotReady(err) {
+			// the team is reconciled again once the contact is ready, as it
+			// watches PingdomContacts
+			log.Info("member contact is not ready", "reason", err.Error())
+			team.Status.SetCondition(
+				observabilityv1alpha1.Synced, corev1.ConditionFalse,
+				"ContactNotReady", err.Error(),
+			)
+			return ctrl.Result{}, nil
+		}
+		if err != nil {
+			metrics.RecordReconcileError(teamControllerName, "ContactLookupFailed")
+			return ctrl.Result{}, err
+		}
+	}
+
+	return sync.run(
+		ctx, &team, &team.Status, &team.Status.ObservedGeneration,
+		alertingreconciler.NewTeam(&alertingreconciler.TeamConfig{
+			Logger:    log,
+			Recorder:  r.Recorder,
+			PdClient:  pdClient.Client,
+			Team:      &team,
+			MemberIDs: memberIDs,
+		}),
+	)
+}
+
+/*
+contactIDs returns identifiers of Pingdom contacts of the PingdomContacts with
+given names in a namespace, in the same order, or a contactNotReadyError if
+any of them doesn't exist or hasn't been created in Pingdom yet.
+*/
+func contactIDs(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	refs []corev1.LocalObjectReference,
+) ([]int32, error) {
+	return referencedIDs(namespace, refs, "PingdomContact",
+		func(nsName types.NamespacedName) (int32, error) {
+			var contact observabilityv1alpha1.PingdomContact
+			err := c.Get(ctx, nsName, &contact)
+			return contact.Status.ID, err
+		},
+	)
+}
+
+// teamIDs returns identifiers of Pingdom teams of the PingdomTeams with given
+// names in a namespace, see contactIDs.
+func teamIDs(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	refs []corev1.LocalObjectReference,
+) ([]int32, error) {
+	return referencedIDs(namespace, refs, "PingdomTeam",
+		func(nsName types.NamespacedName) (int32, error) {
+			var team observabilityv1alpha1.PingdomTeam
+			err := c.Get(ctx, nsName, &team)
+			return team.Status.ID, err
+		},
+	)
+}
+
+// referencedIDs returns Pingdom identifiers of objects of given kind read
+// with getID, see contactIDs.
+func referencedIDs(
+	namespace string,
+	refs []corev1.LocalObjectReference,
+	kind string,
+	getID func(types.NamespacedName) (int32, error),
+) ([]int32, error) {
+	ids := make([]int32, 0, len(refs))
+	for _, ref := range refs {
+		nsName := types.NamespacedName{Namespace: namespace, Name: ref.Name}
+		id, err := getID(nsName)
+		if apierrors.IsNotFound(err) {
+			return nil, microerror.Maskf(contactNotReadyError, "%s %v not found", kind, nsName)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if id == 0 {
+			return nil, microerror.Maskf(
+				contactNotReadyError, "%s %v is not created in Pingdom yet", kind, nsName,
+			)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// requestsForContact maps a PingdomContact to reconcile requests for
+// PingdomTeams in its namespace it's a member of.
+func (r *PingdomTeamReconciler) requestsForContact(obj handler.MapObject) []reconcile.Request {
+	ctx := context.Background()
+	var teams observabilityv1alpha1.PingdomTeamList
+	if err := r.List(ctx, &teams, client.InNamespace(obj.Meta.GetNamespace())); err != nil {
+		r.Log.Error(err, "unable to list PingdomTeams", "namespace", obj.Meta.GetNamespace())
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, team := range teams.Items {
+		if hasRef(team.Spec.Members, obj.Meta.GetName()) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: team.GetNamespace(),
+					Name:      team.GetName(),
+				},
+			})
+		}
+	}
+	return requests
+}
+
+/*
+SetupWithManager configures this reconciler to be triggered for events
+pertaining to specified resource kinds.
+
+Besides PingdomTeams it watches PingdomContacts, so teams pick up their
+members' contacts once they are created in Pingdom.
+*/
+func (r *PingdomTeamReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&observabilityv1alpha1.PingdomTeam{}).
+		Watches(
+			&source.Kind{Type: &observabilityv1alpha1.PingdomContact{}},
+			&handler.EnqueueRequestsFromMapFunc{
+				ToRequests: handler.ToRequestsFunc(r.requestsForContact),
+			},
+		).
+		Complete(r)
+}
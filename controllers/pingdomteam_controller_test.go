@@ -0,0 +1,114 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/finalizer"
+)
+
+var _ = Describe("resolveAlerting", func() {
+	var (
+		c     client.Client
+		check *observabilityv1alpha1.Check
+	)
+
+	contact := func(name string, id int32) *observabilityv1alpha1.PingdomContact {
+		return &observabilityv1alpha1.PingdomContact{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Status:     observabilityv1alpha1.PingdomContactStatus{ID: id},
+		}
+	}
+	team := func(name string, id int32) *observabilityv1alpha1.PingdomTeam {
+		return &observabilityv1alpha1.PingdomTeam{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Status:     observabilityv1alpha1.PingdomTeamStatus{ID: id},
+		}
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(observabilityv1alpha1.AddToScheme(scheme)).To(Succeed())
+		c = fake.NewFakeClientWithScheme(
+			scheme, contact("ops", 11), contact("dev", 12), contact("new", 0), team("on-call", 21),
+		)
+		check = &observabilityv1alpha1.Check{
+			ObjectMeta: metav1.ObjectMeta{Name: "check", Namespace: "default"},
+		}
+	})
+
+	It("adds IDs of referenced contacts and teams", func() {
+		check.Spec.UserIds = &[]int{1}
+		check.Spec.Contacts = []corev1.LocalObjectReference{{Name: "dev"}, {Name: "ops"}}
+		check.Spec.Teams = []corev1.LocalObjectReference{{Name: "on-call"}}
+
+		Expect(resolveAlerting(context.Background(), c, check)).To(Succeed())
+		Expect(*check.Spec.UserIds).To(Equal([]int{1, 11, 12}))
+		Expect(*check.Spec.TeamIds).To(Equal([]int{21}))
+	})
+
+	It("doesn't persist IDs of removed contacts", func() {
+		ctx := context.Background()
+		nsName := types.NamespacedName{Namespace: "default", Name: "check"}
+		check.Spec.Contacts = []corev1.LocalObjectReference{{Name: "ops"}}
+		Expect(c.Create(ctx, check)).To(Succeed())
+		Expect(c.Get(ctx, nsName, check)).To(Succeed())
+		stored := check.DeepCopy()
+		Expect(resolveAlerting(ctx, c, check)).To(Succeed())
+		finalizerMgr := finalizer.New(zap.Logger(true), c, &finalizingReconciler{})
+		Expect(updateFinalizers(ctx, finalizerMgr.EnsureAttached, check, stored)).To(Succeed())
+
+		check = &observabilityv1alpha1.Check{}
+		Expect(c.Get(ctx, nsName, check)).To(Succeed())
+		Expect(check.Spec.UserIds).To(BeNil())
+		check.Spec.Contacts = nil
+		Expect(resolveAlerting(ctx, c, check)).To(Succeed())
+		Expect(check.Spec.UserIds).To(BeNil())
+	})
+
+	It("leaves IDs alone without references", func() {
+		Expect(resolveAlerting(context.Background(), c, check)).To(Succeed())
+		Expect(check.Spec.UserIds).To(BeNil())
+		Expect(check.Spec.TeamIds).To(BeNil())
+	})
+
+	It("reports missing contacts as not ready", func() {
+		check.Spec.Contacts = []corev1.LocalObjectReference{{Name: "missing"}}
+		Expect(IsContactNotReady(resolveAlerting(context.Background(), c, check))).To(BeTrue())
+	})
+
+	It("reports contacts not created in Pingdom as not ready", func() {
+		check.Spec.Contacts = []corev1.LocalObjectReference{{Name: "new"}}
+		Expect(IsContactNotReady(resolveAlerting(context.Background(), c, check))).To(BeTrue())
+	})
+
+	It("reports missing teams as not ready", func() {
+		check.Spec.Teams = []corev1.LocalObjectReference{{Name: "missing"}}
+		Expect(IsContactNotReady(resolveAlerting(context.Background(), c, check))).To(BeTrue())
+	})
+})
@@ -0,0 +1,147 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package alerting implements ResourceReconcilers for Pingdom alerting contacts
+and teams, maintained for PingdomContact and PingdomTeam objects.
+
+Both are only supported by the Pingdom API 3.1.
+*/
+package alerting
+
+import (
+	"sort"
+
+	"github.com/giantswarm/microerror"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+)
+
+// Reasons used in events recorded and conditions set by these reconcilers
+const (
+	ReasonCreated       = "Created"
+	ReasonCreateFailed  = "CreateFailed"
+	ReasonUpdated       = "Updated"
+	ReasonUpdateFailed  = "UpdateFailed"
+	ReasonDeleted       = "Deleted"
+	ReasonDeleteFailed  = "DeleteFailed"
+	ReasonUpToDate      = "UpToDate"
+	ReasonRefreshFailed = "RefreshFailed"
+)
+
+// conditionsStatus is a status with conditions
+type conditionsStatus interface {
+	SetCondition(observabilityv1alpha1.ConditionType, corev1.ConditionStatus, string, string)
+}
+
+/*
+external holds the parts of ensuring state of an alerting contact or team
+common to both: which operation to run and recording its outcome.
+*/
+type external struct {
+	log      logr.Logger
+	recorder record.EventRecorder
+	obj      runtime.Object
+	status   conditionsStatus
+	kind     string
+
+	didWork bool
+}
+
+/*
+ensure creates, updates or deletes the external resource with given ID using
+given functions, recording events and setting the Synced condition.
+*/
+func (e *external) ensure(
+	deleting bool,
+	id *int32,
+	needsUpdate bool,
+	create func() (int, error),
+	update func(int) error,
+	delete func(int) error,
+	isNotFound func(error) bool,
+) error {
+	log := e.log.WithValues("action", "ensureState", "id", *id)
+	var err error
+	var successReason, failureReason string
+	switch {
+	case deleting:
+		successReason, failureReason = ReasonDeleted, ReasonDeleteFailed
+		if *id != 0 {
+			if err = delete(int(*id)); err == nil || isNotFound(err) {
+				err = nil
+				e.event(corev1.EventTypeNormal, ReasonDeleted, "Deleted Pingdom %s %d", e.kind, *id)
+				*id = 0
+				e.didWork = true
+			}
+		}
+	case *id == 0:
+		successReason, failureReason = ReasonCreated, ReasonCreateFailed
+		var created int
+		if created, err = create(); err == nil {
+			*id = int32(created)
+			e.event(corev1.EventTypeNormal, ReasonCreated, "Created Pingdom %s %d", e.kind, created)
+			e.didWork = true
+		}
+	case needsUpdate:
+		successReason, failureReason = ReasonUpdated, ReasonUpdateFailed
+		if err = update(int(*id)); err == nil {
+			e.event(corev1.EventTypeNormal, ReasonUpdated, "Updated Pingdom %s %d", e.kind, *id)
+			e.didWork = true
+		}
+	default:
+		successReason = ReasonUpToDate
+		log.V(1).Info("external resource is up-to-date with its spec")
+	}
+
+	if err != nil {
+		log.Error(err, "unable to reconcile external resource", "reason", failureReason)
+		e.event(corev1.EventTypeWarning, failureReason, "Failed to reconcile Pingdom %s: %v", e.kind, err)
+		e.status.SetCondition(
+			observabilityv1alpha1.Synced, corev1.ConditionFalse,
+			failureReason, err.Error(),
+		)
+		return microerror.Maskf(err, "unable to reconcile Pingdom %s", e.kind)
+	}
+	e.status.SetCondition(
+		observabilityv1alpha1.Synced, corev1.ConditionTrue,
+		successReason, "Pingdom "+e.kind+" matches the spec",
+	)
+	return nil
+}
+
+// refreshFailed records a failure to read the external resource
+func (e *external) refreshFailed(err error) error {
+	e.status.SetCondition(
+		observabilityv1alpha1.Synced, corev1.ConditionFalse,
+		ReasonRefreshFailed, err.Error(),
+	)
+	return microerror.Maskf(err, "unable to fetch %s from Pingdom", e.kind)
+}
+
+func (e *external) event(eventType, reason, messageFmt string, args ...interface{}) {
+	e.recorder.Eventf(e.obj, eventType, reason, messageFmt, args...)
+}
+
+// sortedInts returns a sorted copy of given identifiers
+func sortedInts(ids []int) []int {
+	sorted := append([]int{}, ids...)
+	sort.Ints(sorted)
+	return sorted
+}
@@ -0,0 +1,184 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerting
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"k8s.io/client-go/tools/record"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+	"gitlab.com/mig4/pingdom-operator/controllers/resources"
+	checkreconciler "gitlab.com/mig4/pingdom-operator/controllers/resources/check"
+)
+
+// ContactName describes the object the contact reconciler maintains
+var ContactName = "contact-resource"
+
+/*
+ContactConfig is a structure holding data needed to create a new
+ResourceReconciller for PingdomContact objects.
+*/
+type ContactConfig struct {
+	Logger   logr.Logger
+	Recorder record.EventRecorder
+	Contact  *observabilityv1alpha1.PingdomContact
+
+	// PdClient is the Pingdom API client for the contact's credentials; it's
+	// shared with other reconciles so must not be modified
+	PdClient *pingdom.Client
+}
+
+type contactReconciler struct {
+	external
+	pdClient *pingdom.Client
+	contact  *observabilityv1alpha1.PingdomContact
+
+	// remote is the contact as read from Pingdom by RefreshState
+	remote *pdclient.Contact
+}
+
+/*
+NewContact returns a new ResourceReconciller for a Pingdom alerting contact
+external resource.
+*/
+func NewContact(config *ContactConfig) resources.ResourceReconciler {
+	return &contactReconciler{
+		external: external{
+			log: config.Logger.WithName("resource-reconciler").WithValues(
+				"name", config.Contact.GetName(),
+			),
+			recorder: config.Recorder,
+			obj:      config.Contact,
+			status:   &config.Contact.Status,
+			kind:     "contact",
+		},
+		pdClient: config.PdClient,
+		contact:  config.Contact,
+	}
+}
+
+func (cr *contactReconciler) RefreshState(ctx context.Context) error {
+	status := &cr.contact.Status
+	if status.ID == 0 {
+		return nil
+	}
+	remote, err := pdclient.ReadContact(cr.pdClient, int(status.ID))
+	if checkreconciler.IsInvalidIdentifierError(err) {
+		cr.log.Info("contact no longer exists in Pingdom", "id", status.ID)
+		status.ID = 0
+		return nil
+	}
+	if err != nil {
+		return cr.refreshFailed(err)
+	}
+	cr.remote = remote
+	return nil
+}
+
+func (cr *contactReconciler) EnsureState(ctx context.Context) error {
+	request := cr.request()
+	return cr.ensure(
+		!cr.contact.GetDeletionTimestamp().IsZero(),
+		&cr.contact.Status.ID,
+		cr.needsUpdate(request),
+		func() (int, error) { return pdclient.CreateContact(cr.pdClient, request) },
+		func(id int) error { return pdclient.UpdateContact(cr.pdClient, id, request) },
+		func(id int) error { return pdclient.DeleteContact(cr.pdClient, id) },
+		checkreconciler.IsInvalidIdentifierError,
+	)
+}
+
+func (cr *contactReconciler) FinalizerName() *string {
+	return &ContactName
+}
+
+func (cr *contactReconciler) DidWork() bool {
+	return cr.didWork
+}
+
+// request returns the Pingdom contact described by the spec.
+func (cr *contactReconciler) request() *pdclient.Contact {
+	spec := &cr.contact.Spec
+	contact := &pdclient.Contact{Name: cr.contact.GetName()}
+	if spec.Name != nil && *spec.Name != "" {
+		contact.Name = *spec.Name
+	}
+	if spec.Paused != nil {
+		contact.Paused = *spec.Paused
+	}
+	for _, target := range spec.Email {
+		contact.NotificationTargets.Email = append(contact.NotificationTargets.Email, pdclient.NotificationTarget{
+			Severity: severity(target.Severity),
+			Address:  target.Address,
+		})
+	}
+	for _, target := range spec.SMS {
+		sms := pdclient.NotificationTarget{
+			Severity:    severity(target.Severity),
+			CountryCode: target.CountryCode,
+			Number:      target.Number,
+		}
+		if target.Provider != nil {
+			sms.Provider = *target.Provider
+		}
+		contact.NotificationTargets.SMS = append(contact.NotificationTargets.SMS, sms)
+	}
+	return contact
+}
+
+/*
+needsUpdate returns true if the contact read from Pingdom differs from the
+request. SMS providers not set in the spec are ignored, as Pingdom fills in
+its default.
+*/
+func (cr *contactReconciler) needsUpdate(request *pdclient.Contact) bool {
+	if cr.remote == nil {
+		return true
+	}
+	remote := *cr.remote
+	remote.ID = 0
+	remote.NotificationTargets.SMS = append([]pdclient.NotificationTarget(nil), remote.NotificationTargets.SMS...)
+	for i := range remote.NotificationTargets.SMS {
+		if i < len(request.NotificationTargets.SMS) && request.NotificationTargets.SMS[i].Provider == "" {
+			remote.NotificationTargets.SMS[i].Provider = ""
+		}
+	}
+	return !reflect.DeepEqual(normalizeTargets(remote), normalizeTargets(*request))
+}
+
+// normalizeTargets returns the contact with empty lists of targets as nil
+func normalizeTargets(contact pdclient.Contact) pdclient.Contact {
+	if len(contact.NotificationTargets.Email) == 0 {
+		contact.NotificationTargets.Email = nil
+	}
+	if len(contact.NotificationTargets.SMS) == 0 {
+		contact.NotificationTargets.SMS = nil
+	}
+	return contact
+}
+
+// severity returns given severity or the default, HIGH
+func severity(s *observabilityv1alpha1.NotificationSeverity) string {
+	if s == nil {
+		return pdclient.SeverityHigh
+	}
+	return string(*s)
+}
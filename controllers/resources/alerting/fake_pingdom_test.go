@@ -0,0 +1,152 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerting
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gitlab.com/mig4/pingdom-operator/controllers/internal/pdfake"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+)
+
+/*
+fakePingdom is an in-memory fake of the alerting endpoints of the Pingdom API
+3.1.
+*/
+type fakePingdom struct {
+	*pdfake.Server
+
+	contacts map[int]*pdclient.Contact
+	teams    map[int]*pdclient.Team
+}
+
+func newFakePingdom() *fakePingdom {
+	fp := &fakePingdom{
+		contacts: map[int]*pdclient.Contact{},
+		teams:    map[int]*pdclient.Team{},
+	}
+	fp.Server = pdfake.NewServer(100, fp.handle)
+	return fp
+}
+
+// Contact returns a contact with given ID or nil if it doesn't exist.
+func (fp *fakePingdom) Contact(id int) *pdclient.Contact {
+	fp.Lock()
+	defer fp.Unlock()
+	return fp.contacts[id]
+}
+
+// Team returns a team with given ID or nil if it doesn't exist.
+func (fp *fakePingdom) Team(id int) *pdclient.Team {
+	fp.Lock()
+	defer fp.Unlock()
+	return fp.teams[id]
+}
+
+func (fp *fakePingdom) handle(w http.ResponseWriter, r *http.Request, path string) {
+	switch {
+	case strings.HasPrefix(path, "/alerting/contacts"):
+		fp.handleContact(w, r, strings.TrimPrefix(path, "/alerting/contacts"))
+	case strings.HasPrefix(path, "/alerting/teams"):
+		fp.handleTeam(w, r, strings.TrimPrefix(path, "/alerting/teams"))
+	default:
+		pdfake.WriteNotFound(w)
+	}
+}
+
+func (fp *fakePingdom) handleContact(w http.ResponseWriter, r *http.Request, path string) {
+	if path == "" && r.Method == http.MethodPost {
+		contact := &pdclient.Contact{}
+		_ = json.NewDecoder(r.Body).Decode(contact)
+		contact.ID = fp.NextID()
+		fp.contacts[contact.ID] = contact
+		pdfake.WriteJSON(w, http.StatusOK, map[string]interface{}{
+			"contact": map[string]interface{}{"id": contact.ID},
+		})
+		return
+	}
+
+	id, err := strconv.Atoi(strings.TrimPrefix(path, "/"))
+	contact, ok := fp.contacts[id]
+	if err != nil || !ok {
+		pdfake.WriteNotFound(w)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		// Pingdom fills in the default SMS provider
+		read := *contact
+		read.NotificationTargets.SMS = nil
+		for _, sms := range contact.NotificationTargets.SMS {
+			if sms.Provider == "" {
+				sms.Provider = "nexmo"
+			}
+			read.NotificationTargets.SMS = append(read.NotificationTargets.SMS, sms)
+		}
+		pdfake.WriteJSON(w, http.StatusOK, map[string]interface{}{"contact": read})
+	case http.MethodPut:
+		updated := &pdclient.Contact{}
+		_ = json.NewDecoder(r.Body).Decode(updated)
+		updated.ID = id
+		fp.contacts[id] = updated
+		pdfake.WriteJSON(w, http.StatusOK, map[string]string{"message": "Modification of contact was successful!"})
+	case http.MethodDelete:
+		delete(fp.contacts, id)
+		pdfake.WriteJSON(w, http.StatusOK, map[string]string{"message": "Deletion of contact was successful!"})
+	}
+}
+
+func (fp *fakePingdom) handleTeam(w http.ResponseWriter, r *http.Request, path string) {
+	if path == "" && r.Method == http.MethodPost {
+		team := &pdclient.Team{}
+		_ = json.NewDecoder(r.Body).Decode(team)
+		team.ID = fp.NextID()
+		fp.teams[team.ID] = team
+		pdfake.WriteJSON(w, http.StatusOK, map[string]interface{}{
+			"team": map[string]interface{}{"id": team.ID},
+		})
+		return
+	}
+
+	id, err := strconv.Atoi(strings.TrimPrefix(path, "/"))
+	team, ok := fp.teams[id]
+	if err != nil || !ok {
+		pdfake.WriteNotFound(w)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		members := []pdclient.TeamMember{}
+		for _, memberID := range team.MemberIDs {
+			members = append(members, pdclient.TeamMember{ID: memberID, Type: "user"})
+		}
+		pdfake.WriteJSON(w, http.StatusOK, map[string]interface{}{
+			"team": map[string]interface{}{"id": id, "name": team.Name, "members": members},
+		})
+	case http.MethodPut:
+		updated := &pdclient.Team{}
+		_ = json.NewDecoder(r.Body).Decode(updated)
+		updated.ID = id
+		fp.teams[id] = updated
+		pdfake.WriteJSON(w, http.StatusOK, map[string]interface{}{"team": map[string]interface{}{"id": id}})
+	case http.MethodDelete:
+		delete(fp.teams, id)
+		pdfake.WriteJSON(w, http.StatusOK, map[string]string{"message": "Deletion of team was successful!"})
+	}
+}
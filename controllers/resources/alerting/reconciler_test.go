@@ -0,0 +1,201 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerting
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+)
+
+var _ = Describe("ContactReconciler", func() {
+	var (
+		ctx     = context.Background()
+		fake    *fakePingdom
+		contact *observabilityv1alpha1.PingdomContact
+	)
+
+	reconcile := func() bool {
+		reconciler := NewContact(&ContactConfig{
+			Logger:   zap.Logger(true),
+			Recorder: record.NewFakeRecorder(10),
+			PdClient: fake.Client(),
+			Contact:  contact,
+		})
+		Expect(reconciler.RefreshState(ctx)).To(Succeed())
+		Expect(reconciler.EnsureState(ctx)).To(Succeed())
+		return reconciler.DidWork()
+	}
+
+	BeforeEach(func() {
+		fake = newFakePingdom()
+		low := observabilityv1alpha1.SeverityLow
+		contact = &observabilityv1alpha1.PingdomContact{
+			ObjectMeta: metav1.ObjectMeta{Name: "ops", Namespace: "default"},
+			Spec: observabilityv1alpha1.PingdomContactSpec{
+				Email: []observabilityv1alpha1.EmailTarget{
+					{Address: "ops@example.com"},
+				},
+				SMS: []observabilityv1alpha1.SMSTarget{
+					{Number: "5550100", CountryCode: "1", Severity: &low},
+				},
+				CredentialsSecret: corev1.LocalObjectReference{Name: "creds"},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		fake.Close()
+	})
+
+	It("creates a contact named after the object", func() {
+		Expect(reconcile()).To(BeTrue())
+
+		Expect(contact.Status.ID).NotTo(BeZero())
+		Expect(fake.Contact(int(contact.Status.ID))).To(Equal(&pdclient.Contact{
+			ID:   int(contact.Status.ID),
+			Name: "ops",
+			NotificationTargets: pdclient.NotificationTargets{
+				Email: []pdclient.NotificationTarget{
+					{Severity: pdclient.SeverityHigh, Address: "ops@example.com"},
+				},
+				SMS: []pdclient.NotificationTarget{
+					{Severity: pdclient.SeverityLow, CountryCode: "1", Number: "5550100"},
+				},
+			},
+		}))
+		Expect(contact.Status.GetCondition(observabilityv1alpha1.Synced).Reason).
+			To(Equal(ReasonCreated))
+	})
+
+	It("leaves an up-to-date contact alone", func() {
+		reconcile()
+		Expect(reconcile()).To(BeFalse())
+		Expect(fake.Requests()).NotTo(ContainElement(HavePrefix("PUT")))
+	})
+
+	It("updates a contact when the spec changes", func() {
+		reconcile()
+		name := "Operations"
+		contact.Spec.Name = &name
+		Expect(reconcile()).To(BeTrue())
+
+		Expect(fake.Contact(int(contact.Status.ID)).Name).To(Equal("Operations"))
+	})
+
+	It("recreates a contact deleted in Pingdom", func() {
+		reconcile()
+		deleted := contact.Status.ID
+		Expect(pdclient.DeleteContact(fake.Client(), int(deleted))).To(Succeed())
+
+		Expect(reconcile()).To(BeTrue())
+		Expect(contact.Status.ID).NotTo(Equal(deleted))
+		Expect(fake.Contact(int(contact.Status.ID))).NotTo(BeNil())
+	})
+
+	It("deletes the contact when the object is deleted", func() {
+		reconcile()
+		id := contact.Status.ID
+		now := metav1.Now()
+		contact.DeletionTimestamp = &now
+
+		Expect(reconcile()).To(BeTrue())
+		Expect(contact.Status.ID).To(BeZero())
+		Expect(fake.Contact(int(id))).To(BeNil())
+	})
+})
+
+var _ = Describe("TeamReconciler", func() {
+	var (
+		ctx       = context.Background()
+		fake      *fakePingdom
+		team      *observabilityv1alpha1.PingdomTeam
+		memberIDs []int32
+	)
+
+	reconcile := func() bool {
+		reconciler := NewTeam(&TeamConfig{
+			Logger:    zap.Logger(true),
+			Recorder:  record.NewFakeRecorder(10),
+			PdClient:  fake.Client(),
+			Team:      team,
+			MemberIDs: memberIDs,
+		})
+		Expect(reconciler.RefreshState(ctx)).To(Succeed())
+		Expect(reconciler.EnsureState(ctx)).To(Succeed())
+		return reconciler.DidWork()
+	}
+
+	BeforeEach(func() {
+		fake = newFakePingdom()
+		memberIDs = []int32{12, 11}
+		name := "On-call"
+		team = &observabilityv1alpha1.PingdomTeam{
+			ObjectMeta: metav1.ObjectMeta{Name: "on-call", Namespace: "default"},
+			Spec: observabilityv1alpha1.PingdomTeamSpec{
+				Name:              &name,
+				CredentialsSecret: corev1.LocalObjectReference{Name: "creds"},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		fake.Close()
+	})
+
+	It("creates a team with the members", func() {
+		Expect(reconcile()).To(BeTrue())
+
+		Expect(team.Status.ID).NotTo(BeZero())
+		Expect(team.Status.MemberIDs).To(Equal(memberIDs))
+		created := fake.Team(int(team.Status.ID))
+		Expect(created.Name).To(Equal("On-call"))
+		Expect(created.MemberIDs).To(Equal([]int{12, 11}))
+	})
+
+	It("leaves an up-to-date team alone regardless of member order", func() {
+		reconcile()
+		memberIDs = []int32{11, 12}
+		Expect(reconcile()).To(BeFalse())
+	})
+
+	It("updates a team when members change", func() {
+		reconcile()
+		memberIDs = []int32{11}
+		Expect(reconcile()).To(BeTrue())
+
+		Expect(fake.Team(int(team.Status.ID)).MemberIDs).To(Equal([]int{11}))
+		Expect(team.Status.MemberIDs).To(Equal([]int32{11}))
+	})
+
+	It("deletes the team when the object is deleted", func() {
+		reconcile()
+		id := team.Status.ID
+		now := metav1.Now()
+		team.DeletionTimestamp = &now
+
+		Expect(reconcile()).To(BeTrue())
+		Expect(fake.Team(int(id))).To(BeNil())
+	})
+})
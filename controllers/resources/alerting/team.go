@@ -0,0 +1,150 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerting
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"k8s.io/client-go/tools/record"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+	"gitlab.com/mig4/pingdom-operator/controllers/resources"
+	checkreconciler "gitlab.com/mig4/pingdom-operator/controllers/resources/check"
+)
+
+// TeamName describes the object the team reconciler maintains
+var TeamName = "team-resource"
+
+/*
+TeamConfig is a structure holding data needed to create a new
+ResourceReconciller for PingdomTeam objects.
+*/
+type TeamConfig struct {
+	Logger   logr.Logger
+	Recorder record.EventRecorder
+	Team     *observabilityv1alpha1.PingdomTeam
+
+	// PdClient is the Pingdom API client for the team's credentials; it's
+	// shared with other reconciles so must not be modified
+	PdClient *pingdom.Client
+
+	// MemberIDs are identifiers of Pingdom contacts of the members
+	MemberIDs []int32
+}
+
+type teamReconciler struct {
+	external
+	pdClient  *pingdom.Client
+	team      *observabilityv1alpha1.PingdomTeam
+	memberIDs []int32
+
+	// remote is the team as read from Pingdom by RefreshState
+	remote *pdclient.Team
+}
+
+/*
+NewTeam returns a new ResourceReconciller for a Pingdom alerting team external
+resource.
+*/
+func NewTeam(config *TeamConfig) resources.ResourceReconciler {
+	return &teamReconciler{
+		external: external{
+			log: config.Logger.WithName("resource-reconciler").WithValues(
+				"name", config.Team.GetName(),
+			),
+			recorder: config.Recorder,
+			obj:      config.Team,
+			status:   &config.Team.Status,
+			kind:     "team",
+		},
+		pdClient:  config.PdClient,
+		team:      config.Team,
+		memberIDs: config.MemberIDs,
+	}
+}
+
+func (tr *teamReconciler) RefreshState(ctx context.Context) error {
+	status := &tr.team.Status
+	if status.ID == 0 {
+		return nil
+	}
+	remote, err := pdclient.ReadTeam(tr.pdClient, int(status.ID))
+	if checkreconciler.IsInvalidIdentifierError(err) {
+		tr.log.Info("team no longer exists in Pingdom", "id", status.ID)
+		status.ID = 0
+		return nil
+	}
+	if err != nil {
+		return tr.refreshFailed(err)
+	}
+	tr.remote = remote
+	return nil
+}
+
+func (tr *teamReconciler) EnsureState(ctx context.Context) error {
+	request := tr.request()
+	err := tr.ensure(
+		!tr.team.GetDeletionTimestamp().IsZero(),
+		&tr.team.Status.ID,
+		tr.needsUpdate(request),
+		func() (int, error) { return pdclient.CreateTeam(tr.pdClient, request) },
+		func(id int) error { return pdclient.UpdateTeam(tr.pdClient, id, request) },
+		func(id int) error { return pdclient.DeleteTeam(tr.pdClient, id) },
+		checkreconciler.IsInvalidIdentifierError,
+	)
+	if err == nil {
+		tr.team.Status.MemberIDs = tr.memberIDs
+	}
+	return err
+}
+
+func (tr *teamReconciler) FinalizerName() *string {
+	return &TeamName
+}
+
+func (tr *teamReconciler) DidWork() bool {
+	return tr.didWork
+}
+
+// request returns the Pingdom team described by the spec.
+func (tr *teamReconciler) request() *pdclient.Team {
+	team := &pdclient.Team{Name: tr.team.GetName()}
+	if name := tr.team.Spec.Name; name != nil && *name != "" {
+		team.Name = *name
+	}
+	for _, id := range tr.memberIDs {
+		team.MemberIDs = append(team.MemberIDs, int(id))
+	}
+	return team
+}
+
+// needsUpdate returns true if the team read from Pingdom differs from the
+// request.
+func (tr *teamReconciler) needsUpdate(request *pdclient.Team) bool {
+	if tr.remote == nil {
+		return true
+	}
+	remoteIDs := make([]int, 0, len(tr.remote.Members))
+	for _, member := range tr.remote.Members {
+		remoteIDs = append(remoteIDs, member.ID)
+	}
+	return (tr.remote.Name != request.Name ||
+		!reflect.DeepEqual(sortedInts(remoteIDs), sortedInts(request.MemberIDs)))
+}
@@ -0,0 +1,149 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"fmt"
+
+	"github.com/giantswarm/microerror"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	corev1 "k8s.io/api/core/v1"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+)
+
+/*
+adopt looks up an existing Pingdom check for a Check which has no ID yet and,
+if one is found, stores its ID in the Status and refreshes the Status from it.
+
+The check is looked up by `checkID` from the Spec if set, otherwise according
+to the adoption policy. If no check is found Status.ID stays unset and a new
+check should be created.
+
+A check tagged with the ownership tag of a different Check is never adopted
+by the lookup, as it's managed by that Check. Setting `checkID` explicitly
+takes such a check over and the update following the adoption replaces the
+ownership tag of the other Check, like it adds the missing one to an untagged
+check.
+*/
+func (cr *checkReconciler) adopt() error {
+	spec := &cr.check.Spec
+	log := cr.log.WithValues("action", "adopt")
+
+	var checkID int
+	if spec.CheckID != nil {
+		checkID = int(*spec.CheckID)
+		log.Info("adopting check with ID from spec", "id", checkID)
+	} else {
+		policy := observabilityv1alpha1.AdoptByTag
+		if spec.AdoptionPolicy != nil {
+			policy = *spec.AdoptionPolicy
+		}
+		if policy == observabilityv1alpha1.AdoptNone {
+			return nil
+		}
+
+		log.V(1).Info("looking up an existing check on Pingdom", "policy", policy)
+		var err error
+		checkID, err = cr.findExisting(policy)
+		if err != nil {
+			log.Error(err, "unable to look up an existing check on Pingdom")
+			return err
+		}
+		if checkID == 0 {
+			log.V(1).Info("no existing check found on Pingdom")
+			return nil
+		}
+	}
+
+	cr.check.Status.ID = int32(checkID)
+	if err := cr.readDetails(); err != nil {
+		cr.check.Status.ID = 0
+		return microerror.Maskf(err, "unable to adopt check %d", checkID)
+	}
+	if cr.foreignTag != "" && spec.CheckID == nil {
+		cr.check.Status.ID = 0
+		return microerror.Maskf(
+			checkOwnedError,
+			"unable to adopt check %d, it's tagged %s by a different Check",
+			checkID, cr.foreignTag,
+		)
+	}
+	if cr.foreignTag != "" {
+		log.Info("taking over check tagged by a different Check", "id", checkID, "tag", cr.foreignTag)
+		cr.recorder.Eventf(
+			cr.check, corev1.EventTypeWarning, ReasonTakenOver,
+			"Took over Pingdom check %d tagged %s by a different Check", checkID, cr.foreignTag,
+		)
+	}
+	log.Info("adopted existing check resource on Pingdom", "id", checkID)
+	cr.recorder.Eventf(
+		cr.check, corev1.EventTypeNormal, ReasonAdopted,
+		"Adopted existing Pingdom check %d", checkID,
+	)
+	cr.didWork = true
+	return nil
+}
+
+/*
+findExisting lists checks on Pingdom and returns the ID of the one matching
+given adoption policy or 0 if there's none.
+
+It's an error if more than one check matches as there's no way to tell which
+one should be adopted.
+*/
+func (cr *checkReconciler) findExisting(policy observabilityv1alpha1.AdoptionPolicy) (int, error) {
+	tag := cr.check.OwnershipTag()
+	tagged, err := pdclient.ListChecks(cr.pdClient, cr.apiVersion, map[string]string{"tags": tag})
+	if err != nil {
+		return 0, err
+	}
+	if id, err := singleMatch(tagged, "tag "+tag); err != nil || id != 0 {
+		return id, err
+	}
+
+	if policy != observabilityv1alpha1.AdoptByName {
+		return 0, nil
+	}
+
+	all, err := pdclient.ListChecks(cr.pdClient, cr.apiVersion, nil)
+	if err != nil {
+		return 0, err
+	}
+	spec := &cr.check.Spec
+	var named []pingdom.CheckResponse
+	for _, pdCheck := range all {
+		if pdCheck.Name == *spec.Name && pdCheck.Type.Name == string(spec.Type) {
+			named = append(named, pdCheck)
+		}
+	}
+	return singleMatch(named, "name "+*spec.Name)
+}
+
+func singleMatch(checks []pingdom.CheckResponse, desc string) (int, error) {
+	switch len(checks) {
+	case 0:
+		return 0, nil
+	case 1:
+		return checks[0].ID, nil
+	default:
+		return 0, fmt.Errorf(
+			"found %d Pingdom checks with %s, cannot decide which one to adopt",
+			len(checks), desc,
+		)
+	}
+}
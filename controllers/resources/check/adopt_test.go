@@ -0,0 +1,228 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+)
+
+var _ = Describe("Adopt", func() {
+	var (
+		fake  *fakePingdom
+		check *observabilityv1alpha1.Check
+	)
+
+	ensureState := func() error {
+		reconciler := New(&Config{
+			Logger:   zap.Logger(true),
+			Recorder: record.NewFakeRecorder(10),
+			PdClient: fake.Client(),
+			Check:    check,
+		})
+		return reconciler.EnsureState(context.Background())
+	}
+
+	BeforeEach(func() {
+		fake = newFakePingdom()
+		check = &observabilityv1alpha1.Check{
+			ObjectMeta: metav1.ObjectMeta{Name: "adopt", Namespace: "default"},
+			Spec: observabilityv1alpha1.CheckSpec{
+				CheckParameters: observabilityv1alpha1.CheckParameters{
+					Host: "adopt.example.com",
+					Type: observabilityv1alpha1.Ping,
+				},
+				CredentialsSecret: corev1.LocalObjectReference{Name: "creds"},
+			},
+		}
+		check.Default()
+	})
+
+	AfterEach(func() {
+		fake.Close()
+	})
+
+	It("creates a new tagged check when none exists", func() {
+		Expect(ensureState()).To(Succeed())
+		Expect(check.Status.ID).NotTo(BeZero())
+		created := fake.Get(int(check.Status.ID))
+		Expect(created).NotTo(BeNil())
+		Expect(created.Params["tags"]).To(Equal(check.OwnershipTag()))
+	})
+
+	It("tags the check with tags from the spec besides the ownership tag", func() {
+		check.Spec.Tags = []string{"web"}
+		Expect(ensureState()).To(Succeed())
+		created := fake.Get(int(check.Status.ID))
+		Expect(created.Params["tags"]).To(Equal(check.OwnershipTag() + ",web"))
+
+		reconciler := New(&Config{
+			Logger:   zap.Logger(true),
+			Recorder: record.NewFakeRecorder(10),
+			PdClient: fake.Client(),
+			Check:    check,
+		})
+		Expect(reconciler.RefreshState(context.Background())).To(Succeed())
+		Expect(check.Status.Tags).To(Equal([]string{"web"}))
+		Expect(check.NeedsUpdate()).To(BeFalse())
+
+		check.Spec.Tags = []string{"db"}
+		Expect(check.NeedsUpdate()).To(BeTrue())
+		Expect(ensureState()).To(Succeed())
+		Expect(created.Params["tags"]).To(Equal(check.OwnershipTag() + ",db"))
+	})
+
+	It("adopts a check with the ownership tag", func() {
+		id := fake.Add(map[string]string{
+			"name": "adopt", "host": "adopt.example.com", "type": "ping",
+			"tags": check.OwnershipTag(),
+		})
+		Expect(ensureState()).To(Succeed())
+		Expect(check.Status.ID).To(BeEquivalentTo(id))
+		Expect(fake.Requests()).NotTo(ContainElement("POST /checks"))
+	})
+
+	It("doesn't adopt a check with the same name by default", func() {
+		id := fake.Add(map[string]string{
+			"name": "adopt", "host": "adopt.example.com", "type": "ping",
+		})
+		Expect(ensureState()).To(Succeed())
+		Expect(check.Status.ID).NotTo(BeEquivalentTo(id))
+		Expect(fake.Requests()).To(ContainElement("POST /checks"))
+	})
+
+	It("adopts a check with the same name when requested", func() {
+		fake.Add(map[string]string{
+			"name": "adopt", "host": "other.example.com", "type": "http",
+		})
+		id := fake.Add(map[string]string{
+			"name": "adopt", "host": "old.example.com", "type": "ping",
+		})
+		policy := observabilityv1alpha1.AdoptByName
+		check.Spec.AdoptionPolicy = &policy
+
+		Expect(ensureState()).To(Succeed())
+		Expect(check.Status.ID).To(BeEquivalentTo(id))
+		Expect(fake.Requests()).NotTo(ContainElement("POST /checks"))
+		// adopted check gets updated to match the spec
+		Expect(fake.Get(id).Params["host"]).To(Equal("adopt.example.com"))
+		Expect(fake.Get(id).Params["tags"]).To(Equal(check.OwnershipTag()))
+	})
+
+	It("adds the ownership tag to an adopted check matching the spec", func() {
+		id := fake.Add(map[string]string{
+			"name": "adopt", "host": "adopt.example.com", "type": "ping",
+			"resolution": "5", "tags": "web",
+		})
+		checkID := int32(id)
+		check.Spec.CheckID = &checkID
+
+		Expect(ensureState()).To(Succeed())
+		Expect(check.Status.ID).To(BeEquivalentTo(id))
+		Expect(fake.Requests()).To(ContainElement(fmt.Sprintf("PUT /checks/%d", id)))
+		Expect(fake.Get(id).Params["tags"]).To(Equal("web," + check.OwnershipTag()))
+	})
+
+	It("refuses to adopt a check with the same name tagged by a different Check", func() {
+		other := &observabilityv1alpha1.Check{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+		}
+		id := fake.Add(map[string]string{
+			"name": "adopt", "host": "adopt.example.com", "type": "ping",
+			"tags": other.OwnershipTag(),
+		})
+		policy := observabilityv1alpha1.AdoptByName
+		check.Spec.AdoptionPolicy = &policy
+
+		err := ensureState()
+		Expect(IsCheckOwned(err)).To(BeTrue())
+		Expect(check.Status.ID).To(BeZero())
+		Expect(fake.Requests()).NotTo(ContainElement(fmt.Sprintf("PUT /checks/%d", id)))
+	})
+
+	It("takes over a check with ID from the spec tagged by a different Check", func() {
+		other := &observabilityv1alpha1.Check{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+		}
+		id := fake.Add(map[string]string{
+			"name": "adopt", "host": "adopt.example.com", "type": "ping",
+			"tags": "web," + other.OwnershipTag(),
+		})
+		checkID := int32(id)
+		check.Spec.CheckID = &checkID
+		recorder := record.NewFakeRecorder(10)
+		reconciler := New(&Config{
+			Logger:   zap.Logger(true),
+			Recorder: recorder,
+			PdClient: fake.Client(),
+			Check:    check,
+		})
+
+		Expect(reconciler.EnsureState(context.Background())).To(Succeed())
+		Expect(check.Status.ID).To(BeEquivalentTo(id))
+		Expect(check.Status.Tags).To(Equal([]string{"web"}))
+		Expect(fake.Get(id).Params["tags"]).To(Equal(check.OwnershipTag() + ",web"))
+		Expect(recorder.Events).To(Receive(ContainSubstring(ReasonTakenOver)))
+	})
+
+	It("adopts a check with ID from the spec", func() {
+		id := fake.Add(map[string]string{
+			"name": "explicit", "host": "adopt.example.com", "type": "ping",
+		})
+		checkID := int32(id)
+		check.Spec.CheckID = &checkID
+
+		Expect(ensureState()).To(Succeed())
+		Expect(check.Status.ID).To(BeEquivalentTo(id))
+		Expect(fake.Get(id).Params["name"]).To(Equal("adopt"))
+	})
+
+	It("fails when the check with ID from the spec doesn't exist", func() {
+		checkID := int32(42)
+		check.Spec.CheckID = &checkID
+
+		Expect(ensureState()).To(MatchError(ContainSubstring("unable to adopt")))
+		Expect(check.Status.ID).To(BeZero())
+	})
+
+	It("fails when more than one check matches", func() {
+		for i := 0; i < 2; i++ {
+			fake.Add(map[string]string{
+				"name": "adopt", "host": "adopt.example.com", "type": "ping",
+				"tags": check.OwnershipTag(),
+			})
+		}
+		Expect(ensureState()).To(MatchError(ContainSubstring("cannot decide")))
+		Expect(check.Status.ID).To(BeZero())
+	})
+
+	It("doesn't look up checks when adoption is disabled", func() {
+		policy := observabilityv1alpha1.AdoptNone
+		check.Spec.AdoptionPolicy = &policy
+
+		Expect(ensureState()).To(Succeed())
+		Expect(fake.Requests()).To(Equal([]string{"POST /checks"}))
+	})
+})
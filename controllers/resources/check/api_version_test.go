@@ -0,0 +1,194 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"context"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+	"gitlab.com/mig4/pingdom-operator/controllers/resources"
+)
+
+var _ = Describe("API versions", func() {
+	newCheck := func() *observabilityv1alpha1.Check {
+		check := &observabilityv1alpha1.Check{
+			ObjectMeta: metav1.ObjectMeta{Name: "versioned", Namespace: "default"},
+			Spec: observabilityv1alpha1.CheckSpec{
+				CheckParameters: observabilityv1alpha1.CheckParameters{
+					Host: "versioned.example.com",
+					Type: observabilityv1alpha1.HTTP,
+				},
+				CredentialsSecret: corev1.LocalObjectReference{Name: "creds"},
+			},
+		}
+		check.Default()
+		check.DefaultPort()
+		return check
+	}
+
+	reconcilerFor := func(
+		fake *fakePingdom, check *observabilityv1alpha1.Check,
+	) resources.ResourceReconciler {
+		return New(&Config{
+			Logger:     zap.Logger(true),
+			Recorder:   record.NewFakeRecorder(10),
+			PdClient:   fake.Client(),
+			APIVersion: fake.apiVersion,
+			Check:      check,
+		})
+	}
+
+	DescribeTable("manages the full check lifecycle",
+		func(apiVersion string) {
+			fake := newFakePingdomVersion(apiVersion)
+			defer fake.Close()
+			check := newCheck()
+			ctx := context.Background()
+
+			By("creating the check")
+			Expect(reconcilerFor(fake, check).EnsureState(ctx)).To(Succeed())
+			id := int(check.Status.ID)
+			Expect(fake.Get(id)).NotTo(BeNil())
+
+			By("reading it back")
+			Expect(reconcilerFor(fake, check).RefreshState(ctx)).To(Succeed())
+			Expect(check.Status.Host).To(Equal("versioned.example.com"))
+			Expect(*check.Status.Port).To(BeEquivalentTo(80))
+
+			By("updating it")
+			check.Spec.Host = "moved.example.com"
+			Expect(reconcilerFor(fake, check).EnsureState(ctx)).To(Succeed())
+			Expect(fake.Get(id).Params["host"]).To(Equal("moved.example.com"))
+
+			By("deleting it")
+			now := metav1.Now()
+			check.DeletionTimestamp = &now
+			Expect(reconcilerFor(fake, check).EnsureState(ctx)).To(Succeed())
+			Expect(fake.Get(id)).To(BeNil())
+		},
+		Entry("2.1", pdclient.APIVersion21),
+		Entry("3.1", pdclient.APIVersion31),
+	)
+
+	DescribeTable("reads contacts and teams the check alerts",
+		func(apiVersion string) {
+			fake := newFakePingdomVersion(apiVersion)
+			defer fake.Close()
+			check := newCheck()
+			check.Spec.UserIds = &[]int{11, 12}
+			check.Spec.TeamIds = &[]int{21}
+			ctx := context.Background()
+
+			Expect(reconcilerFor(fake, check).EnsureState(ctx)).To(Succeed())
+			Expect(reconcilerFor(fake, check).RefreshState(ctx)).To(Succeed())
+			Expect(*check.Status.UserIds).To(Equal([]int{11, 12}))
+			Expect(*check.Status.TeamIds).To(Equal([]int{21}))
+			Expect(check.NeedsUpdate()).To(BeFalse())
+		},
+		Entry("2.1", pdclient.APIVersion21),
+		Entry("3.1", pdclient.APIVersion31),
+	)
+
+	It("lists all pages of 3.1 checks", func() {
+		fake := newFakePingdomVersion(pdclient.APIVersion31)
+		defer fake.Close()
+		fake.PageSize(2)
+		for i := 0; i < 5; i++ {
+			fake.Add(map[string]string{"name": "paged", "host": "paged.example.com", "type": "http"})
+		}
+
+		checks, err := pdclient.ListChecks(fake.Client(), pdclient.APIVersion31, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(checks).To(HaveLen(5))
+		Expect(checks[4].Type.Name).To(Equal("http"))
+		Expect(fake.Requests()).To(Equal([]string{"GET /checks", "GET /checks", "GET /checks"}))
+	})
+
+	It("follows changes to encryption with the default port", func() {
+		fake := newFakePingdomVersion(pdclient.APIVersion31)
+		defer fake.Close()
+		check := newCheck()
+		ctx := context.Background()
+		Expect(reconcilerFor(fake, check).EnsureState(ctx)).To(Succeed())
+		Expect(reconcilerFor(fake, check).RefreshState(ctx)).To(Succeed())
+		Expect(fake.Get(int(check.Status.ID)).Params["port"]).To(Equal("80"))
+
+		// the port isn't stored in the spec, so it's defaulted again
+		check.Spec.Port = nil
+		encryption := true
+		check.Spec.Encryption = &encryption
+		check.DefaultPort()
+		Expect(check.NeedsUpdate()).To(BeTrue())
+		Expect(reconcilerFor(fake, check).EnsureState(ctx)).To(Succeed())
+		Expect(fake.Get(int(check.Status.ID)).Params["port"]).To(Equal("443"))
+	})
+
+	DescribeTable("treats a missing check as not found",
+		func(apiVersion string) {
+			fake := newFakePingdomVersion(apiVersion)
+			defer fake.Close()
+
+			_, err := pdclient.ReadCheck(fake.Client(), apiVersion, 42)
+			Expect(IsInvalidIdentifierError(err)).To(BeTrue())
+		},
+		Entry("2.1", pdclient.APIVersion21),
+		Entry("3.1", pdclient.APIVersion31),
+	)
+
+	DescribeTable("reports invalid credentials as unauthorized",
+		func(creds *pdclient.Credentials) {
+			fake := newFakePingdomVersion(creds.APIVersion)
+			defer fake.Close()
+
+			_, err := fake.ClientWith(creds).Checks.List()
+			Expect(IsUnauthorizedError(err)).To(BeTrue())
+		},
+		Entry("2.1", &pdclient.Credentials{
+			APIVersion: pdclient.APIVersion21,
+			User:       fakeUser, Password: "wrong", AppKey: fakeAppKey,
+		}),
+		Entry("3.1", &pdclient.Credentials{
+			APIVersion: pdclient.APIVersion31, APIToken: "wrong",
+		}),
+	)
+
+	DescribeTable("reports rate limiting with the advised delay",
+		func(apiVersion string) {
+			fake := newFakePingdomVersion(apiVersion)
+			defer fake.Close()
+			fake.RateLimit()
+
+			_, err := fake.Client().Checks.Read(42)
+			Expect(IsRateLimitError(err)).To(BeTrue())
+			Expect(RetryAfter(err)).To(Equal(42 * time.Second))
+			Expect(IsRateLimitError(microerror.Maskf(err, "masked"))).To(BeTrue())
+			Expect(IsInvalidIdentifierError(err)).To(BeFalse())
+		},
+		Entry("2.1", pdclient.APIVersion21),
+		Entry("3.1", pdclient.APIVersion31),
+	)
+})
@@ -0,0 +1,99 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+/*
+Credentials are basic authentication credentials for HTTP checks, resolved
+from the Secret referenced in CheckSpec.Auth.
+*/
+type Credentials struct {
+	Username string
+	Password string
+}
+
+/*
+authChecksum returns a checksum of given basic authentication credentials
+which can be safely stored in the Status to detect changes.
+
+The checksum is an HMAC keyed with the operator's secret key, so credentials
+can't be guessed from it by anyone able to read the Status. Returns an empty
+string if username is empty, i.e. no authentication is configured.
+*/
+func (cr *checkReconciler) authChecksum(username, password string) string {
+	if username == "" {
+		return ""
+	}
+	return cr.checksum(username + ":" + password)
+}
+
+/*
+authNeedsUpdate returns true if the basic authentication credentials
+configured on the Pingdom check differ from the ones resolved from the Secret.
+
+If the Spec doesn't request authentication it doesn't affect the comparison.
+*/
+func (cr *checkReconciler) authNeedsUpdate() bool {
+	if cr.auth == nil {
+		return false
+	}
+	return cr.check.Status.AuthChecksum != cr.authChecksum(cr.auth.Username, cr.auth.Password)
+}
+
+/*
+headerChecksums returns checksums of values of given HTTP headers, keyed by
+header name, which can be safely stored in the Status to detect changes, as
+header values can be secrets like API keys.
+*/
+func (cr *checkReconciler) headerChecksums(headers map[string]string) map[string]string {
+	if len(headers) == 0 {
+		return nil
+	}
+	checksums := make(map[string]string, len(headers))
+	for name, value := range headers {
+		checksums[name] = cr.checksum(name + ":" + value)
+	}
+	return checksums
+}
+
+/*
+headersNeedUpdate returns true if any HTTP header in the Spec is missing from
+the Pingdom check or has a different value.
+
+Pingdom returns its own default headers (like `User-Agent`) alongside the
+ones we've set, so only the ones present in the Spec are compared.
+*/
+func (cr *checkReconciler) headersNeedUpdate() bool {
+	checksums := cr.check.Status.RequestHeaderChecksums
+	for name, value := range cr.check.Spec.RequestHeaders {
+		if checksum, ok := checksums[name]; !ok || checksum != cr.checksum(name+":"+value) {
+			return true
+		}
+	}
+	return false
+}
+
+// checksum returns an HMAC of data keyed with the operator's secret key.
+func (cr *checkReconciler) checksum(data string) string {
+	mac := hmac.New(sha256.New, cr.authKey)
+	_, _ = mac.Write([]byte(data))
+	return hex.EncodeToString(mac.Sum(nil))
+}
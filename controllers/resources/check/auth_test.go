@@ -0,0 +1,137 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+)
+
+var _ = Describe("Basic authentication", func() {
+	var (
+		fake  *fakePingdom
+		check *observabilityv1alpha1.Check
+		creds = &Credentials{Username: "admin", Password: "hunter2"}
+	)
+
+	reconcilerFor := func(key string) *checkReconciler {
+		return New(&Config{
+			Logger:   zap.Logger(true),
+			Recorder: record.NewFakeRecorder(10),
+			PdClient: fake.Client(),
+			Check:    check,
+			HTTPAuth: creds,
+			AuthKey:  []byte(key),
+		}).(*checkReconciler)
+	}
+
+	BeforeEach(func() {
+		fake = newFakePingdom()
+		check = &observabilityv1alpha1.Check{
+			ObjectMeta: metav1.ObjectMeta{Name: "auth", Namespace: "default"},
+			Spec: observabilityv1alpha1.CheckSpec{
+				CheckParameters: observabilityv1alpha1.CheckParameters{
+					Host: "auth.example.com",
+					Type: observabilityv1alpha1.HTTP,
+				},
+				CredentialsSecret: corev1.LocalObjectReference{Name: "creds"},
+			},
+		}
+		check.Default()
+		check.DefaultPort()
+		ctx := context.Background()
+		Expect(reconcilerFor("key").EnsureState(ctx)).To(Succeed())
+		Expect(reconcilerFor("key").RefreshState(ctx)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		fake.Close()
+	})
+
+	It("stores a keyed checksum of the credentials", func() {
+		unkeyed := sha256.Sum256([]byte("admin:hunter2"))
+		Expect(check.Status.AuthChecksum).NotTo(BeEmpty())
+		Expect(check.Status.AuthChecksum).NotTo(Equal(hex.EncodeToString(unkeyed[:])))
+		Expect(reconcilerFor("key").authNeedsUpdate()).To(BeFalse())
+	})
+
+	It("updates the check when the key changes", func() {
+		Expect(reconcilerFor("other").authNeedsUpdate()).To(BeTrue())
+	})
+})
+
+var _ = Describe("Request headers", func() {
+	var check *observabilityv1alpha1.Check
+
+	reconcilerFor := func(key string) *checkReconciler {
+		return New(&Config{
+			Logger:   zap.Logger(true),
+			Recorder: record.NewFakeRecorder(10),
+			Check:    check,
+			AuthKey:  []byte(key),
+		}).(*checkReconciler)
+	}
+
+	BeforeEach(func() {
+		check = &observabilityv1alpha1.Check{
+			ObjectMeta: metav1.ObjectMeta{Name: "headers", Namespace: "default"},
+			Spec: observabilityv1alpha1.CheckSpec{
+				CheckParameters: observabilityv1alpha1.CheckParameters{
+					Host:           "headers.example.com",
+					Type:           observabilityv1alpha1.HTTP,
+					RequestHeaders: map[string]string{"X-Key": "secret"},
+				},
+			},
+		}
+		check.Status.RequestHeaderChecksums = reconcilerFor("key").headerChecksums(map[string]string{
+			"X-Key": "secret", "User-Agent": "Pingdom.com_bot",
+		})
+	})
+
+	It("stores keyed checksums instead of header values", func() {
+		Expect(check.Status.RequestHeaderChecksums).To(HaveKey("X-Key"))
+		Expect(check.Status.RequestHeaderChecksums["X-Key"]).NotTo(ContainSubstring("secret"))
+		Expect(check.Status.RequestHeaders).To(BeNil())
+	})
+
+	It("ignores extra headers Pingdom sends", func() {
+		Expect(reconcilerFor("key").headersNeedUpdate()).To(BeFalse())
+	})
+
+	It("updates the check when a header value differs", func() {
+		check.Spec.RequestHeaders["X-Key"] = "rotated"
+		Expect(reconcilerFor("key").headersNeedUpdate()).To(BeTrue())
+	})
+
+	It("updates the check when a header is missing", func() {
+		check.Spec.RequestHeaders["X-Other"] = "value"
+		Expect(reconcilerFor("key").headersNeedUpdate()).To(BeTrue())
+	})
+
+	It("updates the check when the key changes", func() {
+		Expect(reconcilerFor("other").headersNeedUpdate()).To(BeTrue())
+	})
+})
@@ -15,17 +15,31 @@ limitations under the License.
 
 package check
 
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
 func (cr *checkReconciler) create() error {
 	log := cr.log.WithValues("action", "create")
 	log.Info("creating check resource on Pingdom")
-	resp, err := cr.pdClient.Checks.Create(&cr.check.Spec)
+	resp, err := cr.pdClient.Checks.Create(cr.request())
 	log.V(1).Info(
 		"Pingdom Checks.Create() response", "response", resp, "error", err,
 	)
-	if err == nil {
-		cr.check.Status.ID = int32(resp.ID)
-		log.Info("created check resource on Pingdom", "id", resp.ID)
-		cr.didWork = true
+	if err != nil {
+		cr.recorder.Eventf(
+			cr.check, corev1.EventTypeWarning, ReasonCreateFailed,
+			"Failed to create Pingdom check: %v", err,
+		)
+		return err
 	}
-	return err
+	cr.check.Status.ID = int32(resp.ID)
+	cr.snapshot.MarkChanged(resp.ID)
+	log.Info("created check resource on Pingdom", "id", resp.ID)
+	cr.recorder.Eventf(
+		cr.check, corev1.EventTypeNormal, ReasonCreated,
+		"Created Pingdom check %d", resp.ID,
+	)
+	cr.didWork = true
+	return nil
 }
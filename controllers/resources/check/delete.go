@@ -15,19 +15,155 @@ limitations under the License.
 
 package check
 
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+)
+
+/*
+delete handles the Pingdom check when the Check object is deleted, according
+to its deletion policy: deletes it, pauses it or leaves it as is.
+
+A check which is retained or paused has the ownership tag removed, so it can
+be adopted by a different Check.
+*/
 func (cr *checkReconciler) delete() error {
+	switch cr.deletionPolicy() {
+	case observabilityv1alpha1.DeletionPolicyRetain:
+		if err := cr.release(false); err != nil {
+			return err
+		}
+		cr.log.Info(
+			"retaining check resource on Pingdom as per deletion policy",
+			"id", cr.check.Status.ID,
+		)
+		cr.recorder.Eventf(
+			cr.check, corev1.EventTypeNormal, ReasonRetained,
+			"Retained Pingdom check %d", cr.check.Status.ID,
+		)
+		return nil
+	case observabilityv1alpha1.DeletionPolicyPause:
+		if err := cr.release(true); err != nil {
+			return err
+		}
+		cr.log.Info("paused check resource on Pingdom", "id", cr.check.Status.ID)
+		cr.recorder.Eventf(
+			cr.check, corev1.EventTypeNormal, ReasonPaused,
+			"Paused Pingdom check %d", cr.check.Status.ID,
+		)
+		return nil
+	}
+
 	log := cr.log.WithValues("action", "delete", "id", cr.check.Status.ID)
 	log.Info("deleting check resource from Pingdom")
 	resp, err := cr.pdClient.Checks.Delete(int(cr.check.Status.ID))
 	if err != nil {
 		log.Error(err, "unable to delete the Pingdom check resource")
-		return ignoreNotFound(err)
+		if err = ignoreNotFound(err); err != nil {
+			cr.recorder.Eventf(
+				cr.check, corev1.EventTypeWarning, ReasonDeleteFailed,
+				"Failed to delete Pingdom check %d: %v", cr.check.Status.ID, err,
+			)
+		}
+		return err
 	}
 	log.Info("deleted check resource from Pingdom", "message", resp.Message)
+	cr.recorder.Eventf(
+		cr.check, corev1.EventTypeNormal, ReasonDeleted,
+		"Deleted Pingdom check %d", cr.check.Status.ID,
+	)
+	cr.didWork = true
+	return nil
+}
+
+/*
+release removes the ownership tag from the Pingdom check, keeping its other
+tags, and pauses it if requested, leaving other parameters unchanged.
+
+Tags of the check are read from Pingdom rather than taken from the Status, as
+the update replaces all of them. The check isn't updated if it's neither
+tagged nor to be paused.
+*/
+func (cr *checkReconciler) release(pause bool) error {
+	checkID := cr.check.Status.ID
+	log := cr.log.WithValues("action", "release", "id", checkID, "pause", pause)
+
+	log.V(1).Info("fetching check resource from Pingdom")
+	pdCheck, err := pdclient.ReadCheck(cr.pdClient, cr.apiVersion, int(checkID))
+	if err != nil {
+		log.Error(err, "unable to fetch check resource from Pingdom")
+		if err = ignoreNotFound(err); err != nil {
+			cr.recorder.Eventf(
+				cr.check, corev1.EventTypeWarning, ReasonDeleteFailed,
+				"Failed to read Pingdom check %d: %v", checkID, err,
+			)
+		}
+		return err
+	}
+	cr.readOwnership(pdCheck.Tags)
+	if cr.untagged && !pause {
+		return nil
+	}
+
+	log.Info("releasing check resource on Pingdom")
+	cr.snapshot.MarkChanged(int(checkID))
+	req := &releaseRequest{tags: cr.userTags(pdCheck.Tags), paused: pause}
+	resp, err := cr.pdClient.Checks.Update(int(checkID), req)
+	if err != nil {
+		log.Error(err, "unable to release the Pingdom check resource")
+		if err = ignoreNotFound(err); err != nil {
+			cr.recorder.Eventf(
+				cr.check, corev1.EventTypeWarning, ReasonDeleteFailed,
+				"Failed to release Pingdom check %d: %v", checkID, err,
+			)
+		}
+		return err
+	}
+	log.Info("released check resource on Pingdom", "message", resp.Message)
 	cr.didWork = true
 	return nil
 }
 
+/*
+deletionPolicy returns the deletion policy from the Spec or the default one if
+it's not set.
+*/
+func (cr *checkReconciler) deletionPolicy() observabilityv1alpha1.DeletionPolicy {
+	if cr.check.Spec.DeletionPolicy == nil {
+		return observabilityv1alpha1.DeletionPolicyDelete
+	}
+	return *cr.check.Spec.DeletionPolicy
+}
+
+/*
+releaseRequest is a pingdom.Check request which only replaces tags of a check
+and optionally pauses it.
+*/
+type releaseRequest struct {
+	tags   []string
+	paused bool
+}
+
+func (r *releaseRequest) PutParams() map[string]string {
+	params := map[string]string{"tags": strings.Join(r.tags, ",")}
+	if r.paused {
+		params["paused"] = "true"
+	}
+	return params
+}
+
+func (r *releaseRequest) PostParams() map[string]string {
+	return r.PutParams()
+}
+
+func (*releaseRequest) Valid() error {
+	return nil
+}
+
 func ignoreNotFound(err error) error {
 	if IsInvalidIdentifierError(err) {
 		return nil
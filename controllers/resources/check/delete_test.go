@@ -0,0 +1,144 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+)
+
+var _ = Describe("Delete", func() {
+	var (
+		fake  *fakePingdom
+		check *observabilityv1alpha1.Check
+		id    int
+	)
+
+	ensureState := func(policy observabilityv1alpha1.DeletionPolicy) error {
+		check.Spec.DeletionPolicy = &policy
+		reconciler := New(&Config{
+			Logger:   zap.Logger(true),
+			Recorder: record.NewFakeRecorder(10),
+			PdClient: fake.Client(),
+			Check:    check,
+		})
+		return reconciler.EnsureState(context.Background())
+	}
+
+	BeforeEach(func() {
+		fake = newFakePingdom()
+		id = fake.Add(map[string]string{
+			"name": "delete", "host": "delete.example.com", "type": "ping",
+		})
+		now := metav1.Now()
+		check = &observabilityv1alpha1.Check{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "delete", Namespace: "default", DeletionTimestamp: &now,
+			},
+			Spec: observabilityv1alpha1.CheckSpec{
+				CheckParameters: observabilityv1alpha1.CheckParameters{
+					Host: "delete.example.com",
+					Type: observabilityv1alpha1.Ping,
+				},
+				CredentialsSecret: corev1.LocalObjectReference{Name: "creds"},
+			},
+			Status: observabilityv1alpha1.CheckStatus{ID: int32(id)},
+		}
+		check.Default()
+	})
+
+	AfterEach(func() {
+		fake.Close()
+	})
+
+	It("deletes the check with Delete policy", func() {
+		Expect(ensureState(observabilityv1alpha1.DeletionPolicyDelete)).To(Succeed())
+		Expect(fake.Get(id)).To(BeNil())
+	})
+
+	It("ignores a check that's already gone", func() {
+		check.Status.ID = 42
+		Expect(ensureState(observabilityv1alpha1.DeletionPolicyDelete)).To(Succeed())
+	})
+
+	It("leaves the check as is with Retain policy", func() {
+		Expect(ensureState(observabilityv1alpha1.DeletionPolicyRetain)).To(Succeed())
+		Expect(fake.Get(id)).NotTo(BeNil())
+		Expect(fake.Requests()).NotTo(ContainElement(fmt.Sprintf("PUT /checks/%d", id)))
+		Expect(check.Status.GetCondition(observabilityv1alpha1.Synced).Reason).
+			To(Equal(ReasonRetained))
+	})
+
+	It("removes the ownership tag from the check with Retain policy", func() {
+		fake.Get(id).Params["tags"] = "web," + check.OwnershipTag()
+		Expect(ensureState(observabilityv1alpha1.DeletionPolicyRetain)).To(Succeed())
+		Expect(fake.Get(id).Params["tags"]).To(Equal("web"))
+		Expect(fake.Get(id).Params["paused"]).To(BeEmpty())
+	})
+
+	It("pauses the check with Pause policy", func() {
+		fake.Get(id).Params["tags"] = check.OwnershipTag()
+		Expect(ensureState(observabilityv1alpha1.DeletionPolicyPause)).To(Succeed())
+		Expect(fake.Get(id)).NotTo(BeNil())
+		Expect(fake.Get(id).Params["paused"]).To(Equal("true"))
+		Expect(fake.Get(id).Params["host"]).To(Equal("delete.example.com"))
+		Expect(fake.Get(id).Params["tags"]).To(BeEmpty())
+	})
+
+	It("lets a Check in another namespace adopt a retained check", func() {
+		check.DeletionTimestamp = nil
+		check.Status = observabilityv1alpha1.CheckStatus{}
+		checkID := int32(id)
+		check.Spec.CheckID = &checkID
+		Expect(ensureState(observabilityv1alpha1.DeletionPolicyRetain)).To(Succeed())
+		Expect(fake.Get(id).Params["tags"]).To(Equal(check.OwnershipTag()))
+
+		now := metav1.Now()
+		check.DeletionTimestamp = &now
+		Expect(ensureState(observabilityv1alpha1.DeletionPolicyRetain)).To(Succeed())
+		Expect(fake.Get(id).Params["tags"]).To(BeEmpty())
+
+		moved := check.DeepCopy()
+		moved.Namespace = "other"
+		moved.DeletionTimestamp = nil
+		moved.Status = observabilityv1alpha1.CheckStatus{}
+		recorder := record.NewFakeRecorder(10)
+		reconciler := New(&Config{
+			Logger:   zap.Logger(true),
+			Recorder: recorder,
+			PdClient: fake.Client(),
+			Check:    moved,
+		})
+		Expect(reconciler.EnsureState(context.Background())).To(Succeed())
+		Expect(moved.Status.ID).To(BeEquivalentTo(id))
+		Expect(fake.Get(id).Params["tags"]).To(Equal(moved.OwnershipTag()))
+		events := []string{}
+		for len(recorder.Events) > 0 {
+			events = append(events, <-recorder.Events)
+		}
+		Expect(events).To(ContainElement(ContainSubstring(ReasonAdopted)))
+		Expect(events).NotTo(ContainElement(ContainSubstring(ReasonTakenOver)))
+	})
+})
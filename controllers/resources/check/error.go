@@ -15,7 +15,16 @@ limitations under the License.
 
 package check
 
-import "github.com/russellcardullo/go-pingdom/pingdom"
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+)
 
 // An error the Pingdom API returns when a given ID is not found
 var invalidIdentifierError = pingdom.PingdomError{
@@ -25,13 +34,17 @@ var invalidIdentifierError = pingdom.PingdomError{
 }
 
 // IsInvalidIdentifierError returns true if given error returned by the Pingdom
-// API indicates a given ID was not found.
+// API indicates a given ID was not found. API 2.1 responds with a 403 invalid
+// identifier error in that case, while 3.1 responds with 404.
 func IsInvalidIdentifierError(err error) bool {
 	if err == nil {
 		return false
 	}
-	switch t := err.(type) {
+	switch t := microerror.Cause(err).(type) {
 	case *pingdom.PingdomError:
+		if t.StatusCode == http.StatusNotFound {
+			return true
+		}
 		return (t.StatusCode == invalidIdentifierError.StatusCode &&
 			t.StatusDesc == invalidIdentifierError.StatusDesc &&
 			t.Message == invalidIdentifierError.Message)
@@ -39,3 +52,56 @@ func IsInvalidIdentifierError(err error) bool {
 		return false
 	}
 }
+
+// IsUnauthorizedError returns true if given error returned by the Pingdom API
+// indicates the credentials used were rejected.
+func IsUnauthorizedError(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch t := microerror.Cause(err).(type) {
+	case *pingdom.PingdomError:
+		return t.StatusCode == http.StatusUnauthorized
+	default:
+		return false
+	}
+}
+
+// rateLimitError returns the RateLimitError which caused given error or nil
+// if it wasn't caused by one. The Pingdom client returns errors from the
+// transport wrapped in a url.Error.
+func rateLimitError(err error) *pdclient.RateLimitError {
+	cause := microerror.Cause(err)
+	if urlErr, ok := cause.(*url.Error); ok {
+		cause = urlErr.Err
+	}
+	rlErr, _ := cause.(*pdclient.RateLimitError)
+	return rlErr
+}
+
+// IsRateLimitError returns true if given error indicates the Pingdom API
+// request limit is reached.
+func IsRateLimitError(err error) bool {
+	return rateLimitError(err) != nil
+}
+
+// RetryAfter returns how long to wait before retrying after a rate limit
+// error, or zero if given error is not one.
+func RetryAfter(err error) time.Duration {
+	if rlErr := rateLimitError(err); rlErr != nil {
+		return rlErr.RetryAfter
+	}
+	return 0
+}
+
+// An error returned when the Pingdom check to adopt is tagged with the
+// ownership tag of a different Check
+var checkOwnedError = &microerror.Error{
+	Kind: "checkOwnedError",
+}
+
+// IsCheckOwned returns true if given error indicates the Pingdom check is
+// managed by a different Check.
+func IsCheckOwned(err error) bool {
+	return microerror.Cause(err) == checkOwnedError
+}
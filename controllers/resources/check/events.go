@@ -0,0 +1,76 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+)
+
+// Reasons used in events recorded and conditions set by this reconciler
+const (
+	ReasonCreated       = "Created"
+	ReasonCreateFailed  = "CreateFailed"
+	ReasonUpdated       = "Updated"
+	ReasonUpdateFailed  = "UpdateFailed"
+	ReasonDeleted       = "Deleted"
+	ReasonDeleteFailed  = "DeleteFailed"
+	ReasonRetained      = "Retained"
+	ReasonPaused        = "Paused"
+	ReasonUpToDate      = "UpToDate"
+	ReasonAdopted       = "Adopted"
+	ReasonAdoptFailed   = "AdoptFailed"
+	ReasonTakenOver     = "TakenOver"
+	ReasonRefreshFailed = "RefreshFailed"
+	ReasonSummaryFailed = "SummaryFailed"
+	ReasonCheckUp       = "CheckUp"
+	ReasonCheckDown     = "CheckDown"
+	ReasonCheckChanged  = "CheckStatusChanged"
+)
+
+/*
+recordStatusTransition records an event if the status of the Pingdom check
+changed between reconciles, e.g. from `up` to `down`.
+
+Nothing is recorded for the initial status of a check.
+*/
+func (cr *checkReconciler) recordStatusTransition(
+	previous, current observabilityv1alpha1.CheckResult,
+) {
+	if previous == current ||
+		previous == "" || previous == observabilityv1alpha1.Unknown {
+		return
+	}
+
+	switch current {
+	case observabilityv1alpha1.Up:
+		cr.recorder.Eventf(
+			cr.check, corev1.EventTypeNormal, ReasonCheckUp,
+			"Check status changed from %s to %s", previous, current,
+		)
+	case observabilityv1alpha1.Down:
+		cr.recorder.Eventf(
+			cr.check, corev1.EventTypeWarning, ReasonCheckDown,
+			"Check status changed from %s to %s", previous, current,
+		)
+	default:
+		cr.recorder.Eventf(
+			cr.check, corev1.EventTypeNormal, ReasonCheckChanged,
+			"Check status changed from %s to %s", previous, current,
+		)
+	}
+}
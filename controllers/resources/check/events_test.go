@@ -0,0 +1,62 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+)
+
+var _ = Describe("Events", func() {
+	DescribeTable("recording status transitions",
+		func(previous, current observabilityv1alpha1.CheckResult, match types.GomegaMatcher) {
+			recorder := record.NewFakeRecorder(1)
+			cr := New(&Config{
+				Logger:   zap.Logger(true),
+				Recorder: recorder,
+				Check:    &observabilityv1alpha1.Check{},
+			}).(*checkReconciler)
+
+			cr.recordStatusTransition(previous, current)
+			close(recorder.Events)
+
+			var events []string
+			for event := range recorder.Events {
+				events = append(events, event)
+			}
+			Expect(events).To(match)
+		},
+		Entry("with unchanged status",
+			observabilityv1alpha1.Up, observabilityv1alpha1.Up, BeEmpty()),
+		Entry("with initial status",
+			observabilityv1alpha1.Unknown, observabilityv1alpha1.Up, BeEmpty()),
+		Entry("when going down",
+			observabilityv1alpha1.Up, observabilityv1alpha1.Down,
+			ConsistOf("Warning CheckDown Check status changed from up to down")),
+		Entry("when coming back up",
+			observabilityv1alpha1.Down, observabilityv1alpha1.Up,
+			ConsistOf("Normal CheckUp Check status changed from down to up")),
+		Entry("when paused",
+			observabilityv1alpha1.Up, observabilityv1alpha1.Paused,
+			ConsistOf("Normal CheckStatusChanged Check status changed from up to paused")),
+	)
+})
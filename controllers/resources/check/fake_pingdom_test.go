@@ -0,0 +1,409 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/russellcardullo/go-pingdom/pingdom"
+
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+)
+
+// Credentials accepted by the fake
+const (
+	fakeUser     = "user"
+	fakePassword = "password"
+	fakeAppKey   = "key"
+	fakeAPIToken = "token"
+)
+
+/*
+fakePingdom is an in-memory fake of the subset of the Pingdom API used by the
+check reconciler.
+
+It serves a single API version under `/api/<version>/`, requiring the
+authentication scheme of that version: basic auth with an `App-Key` header
+for 2.1 and a bearer token for 3.1. Payloads have the shape of that version:
+3.1 reports missing checks as 404, counts checks in the list, which is paged
+with `limit` and `offset`, and returns additional fields in check details.
+*/
+type fakePingdom struct {
+	server     *httptest.Server
+	apiVersion string
+
+	mu          sync.Mutex
+	checks      map[int]*fakeCheck
+	nextID      int
+	requests    []string
+	rateLimited bool
+	pageSize    int
+}
+
+type fakeCheck struct {
+	ID     int
+	Params map[string]string
+	Status string
+
+	// Summary returned by summary endpoints for any period
+	TotalUp, TotalDown, AvgResponse int
+	Outages                         []pdclient.OutageState
+}
+
+func newFakePingdom() *fakePingdom {
+	return newFakePingdomVersion(pdclient.APIVersion21)
+}
+
+func newFakePingdomVersion(apiVersion string) *fakePingdom {
+	fp := &fakePingdom{
+		apiVersion: apiVersion,
+		checks:     map[int]*fakeCheck{},
+		nextID:     1000,
+		pageSize:   25000,
+	}
+	fp.server = httptest.NewServer(http.HandlerFunc(fp.handle))
+	return fp
+}
+
+func (fp *fakePingdom) Close() {
+	fp.server.Close()
+}
+
+// Client returns a client for the fake with valid credentials.
+func (fp *fakePingdom) Client() *pingdom.Client {
+	return fp.ClientWith(&pdclient.Credentials{
+		APIVersion: fp.apiVersion,
+		User:       fakeUser,
+		Password:   fakePassword,
+		AppKey:     fakeAppKey,
+		APIToken:   fakeAPIToken,
+	})
+}
+
+// ClientWith returns a client for the fake with given credentials.
+func (fp *fakePingdom) ClientWith(creds *pdclient.Credentials) *pingdom.Client {
+	client, err := pdclient.New(creds, fp.server.URL+"/api/", pdclient.NewLimiter(0, 1))
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// Add adds a check with given parameters (as they'd be sent in a POST
+// request) to the fake and returns its ID.
+func (fp *fakePingdom) Add(params map[string]string) int {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+	fp.nextID++
+	fp.checks[fp.nextID] = &fakeCheck{ID: fp.nextID, Params: params, Status: "up"}
+	return fp.nextID
+}
+
+// RateLimit makes the fake reject all further requests with 429 Too Many
+// Requests.
+func (fp *fakePingdom) RateLimit() {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+	fp.rateLimited = true
+}
+
+// PageSize sets the maximum number of checks returned in one page of the 3.1
+// list of checks.
+func (fp *fakePingdom) PageSize(size int) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+	fp.pageSize = size
+}
+
+// Get returns a check with given ID or nil if it doesn't exist.
+func (fp *fakePingdom) Get(id int) *fakeCheck {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+	return fp.checks[id]
+}
+
+// Requests returns methods and paths of requests received so far.
+func (fp *fakePingdom) Requests() []string {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+	return append([]string(nil), fp.requests...)
+}
+
+func (fp *fakePingdom) handle(w http.ResponseWriter, r *http.Request) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	prefix := "/api/" + fp.apiVersion + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		writeError(w, http.StatusNotFound, "Not Found", "Unknown API version")
+		return
+	}
+	if !fp.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "Unauthorized", "Invalid credentials")
+		return
+	}
+
+	if fp.rateLimited {
+		w.Header().Set("Req-Limit-Short", "Remaining: 0 Time until reset: 42")
+		writeError(w, http.StatusTooManyRequests, "Too Many Requests", "Rate limit exceeded")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, prefix)
+	fp.requests = append(fp.requests, r.Method+" /"+path)
+	params := map[string]string{}
+	for key := range r.URL.Query() {
+		params[key] = r.URL.Query().Get(key)
+	}
+
+	if path == "checks" {
+		switch r.Method {
+		case http.MethodGet:
+			fp.list(w, params)
+		case http.MethodPost:
+			fp.nextID++
+			fp.checks[fp.nextID] = &fakeCheck{ID: fp.nextID, Params: params, Status: "up"}
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"check": map[string]interface{}{"id": fp.nextID, "name": params["name"]},
+			})
+		}
+		return
+	}
+
+	resource := "checks"
+	if i := strings.Index(path, "/"); i >= 0 {
+		resource = path[:i]
+	}
+	id, err := strconv.Atoi(strings.TrimPrefix(path, resource+"/"))
+	check, ok := fp.checks[id]
+	if err != nil || !ok {
+		if fp.apiVersion == pdclient.APIVersion31 {
+			writeError(w, http.StatusNotFound, "Not Found", "Check not found")
+		} else {
+			writeError(
+				w, invalidIdentifierError.StatusCode,
+				invalidIdentifierError.StatusDesc, invalidIdentifierError.Message,
+			)
+		}
+		return
+	}
+
+	switch resource {
+	case "summary.average":
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"summary": map[string]interface{}{
+				"responsetime": map[string]int{"avgresponse": check.AvgResponse},
+				"status": map[string]int{
+					"totalup": check.TotalUp, "totaldown": check.TotalDown,
+				},
+			},
+		})
+		return
+	case "summary.outage":
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"summary": map[string]interface{}{"states": check.Outages},
+		})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"check": check.details(fp.apiVersion, params["include_teams"] == "true"),
+		})
+	case http.MethodPut:
+		for key, value := range params {
+			if key == "addtags" {
+				if !check.hasAnyTag(strings.Split(value, ",")) {
+					value = strings.TrimPrefix(check.Params["tags"]+","+value, ",")
+				}
+				key = "tags"
+			}
+			check.Params[key] = value
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"message": "Modification of check was successful!"})
+	case http.MethodDelete:
+		delete(fp.checks, id)
+		writeJSON(w, http.StatusOK, map[string]string{"message": "Deletion of check was successful!"})
+	}
+}
+
+func (fp *fakePingdom) authorized(r *http.Request) bool {
+	user, password, hasBasicAuth := r.BasicAuth()
+	switch fp.apiVersion {
+	case pdclient.APIVersion21:
+		return (hasBasicAuth && user == fakeUser && password == fakePassword &&
+			r.Header.Get("App-Key") == fakeAppKey)
+	case pdclient.APIVersion31:
+		return (r.Header.Get("Authorization") == "Bearer "+fakeAPIToken &&
+			r.Header.Get("App-Key") == "")
+	default:
+		return false
+	}
+}
+
+func (fp *fakePingdom) list(w http.ResponseWriter, params map[string]string) {
+	ids := make([]int, 0, len(fp.checks))
+	for id := range fp.checks {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	checks := make([]map[string]interface{}, 0)
+	for _, id := range ids {
+		check := fp.checks[id]
+		if tags, ok := params["tags"]; ok && !check.hasAnyTag(strings.Split(tags, ",")) {
+			continue
+		}
+		checks = append(checks, check.summary(fp.apiVersion))
+	}
+	if fp.apiVersion != pdclient.APIVersion31 {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"checks": checks})
+		return
+	}
+
+	filtered := len(checks)
+	offset, _ := strconv.Atoi(params["offset"])
+	if offset > len(checks) {
+		offset = len(checks)
+	}
+	limit, _ := strconv.Atoi(params["limit"])
+	if limit == 0 || limit > fp.pageSize {
+		limit = fp.pageSize
+	}
+	checks = checks[offset:]
+	if limit < len(checks) {
+		checks = checks[:limit]
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"checks": checks,
+		"counts": map[string]int{
+			"total": len(fp.checks), "limited": len(checks), "filtered": filtered,
+		},
+	})
+}
+
+func (c *fakeCheck) hasAnyTag(tags []string) bool {
+	for _, have := range strings.Split(c.Params["tags"], ",") {
+		for _, want := range tags {
+			if have == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// summary returns the check as returned by the list endpoint, which has the
+// type as a string and no type specific details.
+func (c *fakeCheck) summary(apiVersion string) map[string]interface{} {
+	summary := c.details(apiVersion, false)
+	summary["type"] = c.Params["type"]
+	return summary
+}
+
+// details returns the check as returned when reading it in given API version;
+// teams alerted by the check are only included if requested.
+func (c *fakeCheck) details(apiVersion string, includeTeams bool) map[string]interface{} {
+	resolution, _ := strconv.Atoi(c.Params["resolution"])
+	if resolution == 0 {
+		resolution = 5
+	}
+	typeDetails := map[string]interface{}{}
+	if c.Params["type"] == "http" {
+		port, _ := strconv.Atoi(c.Params["port"])
+		typeDetails = map[string]interface{}{
+			"url":        c.Params["url"],
+			"encryption": c.Params["encryption"] == "true",
+			"port":       port,
+		}
+		if auth := strings.SplitN(c.Params["auth"], ":", 2); len(auth) == 2 {
+			typeDetails["username"] = auth[0]
+			typeDetails["password"] = auth[1]
+		}
+	}
+	status := c.Status
+	if c.Params["paused"] == "true" {
+		status = "paused"
+	}
+	tags := []map[string]interface{}{}
+	for _, tag := range strings.Split(c.Params["tags"], ",") {
+		if tag != "" {
+			tags = append(tags, map[string]interface{}{"name": tag, "type": "u", "count": 1})
+		}
+	}
+	details := map[string]interface{}{
+		"tags":       tags,
+		"id":         c.ID,
+		"name":       c.Params["name"],
+		"hostname":   c.Params["host"],
+		"resolution": resolution,
+		"status":     status,
+		"created":    1500000000,
+		"type":       map[string]interface{}{c.Params["type"]: typeDetails},
+		"userids":    splitIDs(c.Params["userids"]),
+	}
+	if includeTeams {
+		teams := []map[string]interface{}{}
+		for _, id := range splitIDs(c.Params["teamids"]) {
+			teams = append(teams, map[string]interface{}{"id": id, "name": "Team " + strconv.Itoa(id)})
+		}
+		details["teams"] = teams
+	}
+	if apiVersion == pdclient.APIVersion31 {
+		details["ipv6"] = false
+		details["verify_certificate"] = true
+		details["ssl_down_days_before"] = 0
+		details["responsetime_threshold"] = 30000
+		details["custom_message"] = ""
+		details["integrationids"] = []int{}
+		details["probe_filters"] = []string{}
+		details["lastdownstart"] = 0
+		details["lastdownend"] = 0
+	}
+	return details
+}
+
+func splitIDs(ids string) []int {
+	result := []int{}
+	for _, id := range strings.Split(ids, ",") {
+		if n, err := strconv.Atoi(id); err == nil {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+func writeError(w http.ResponseWriter, status int, desc, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"error": map[string]interface{}{
+			"statuscode":   status,
+			"statusdesc":   desc,
+			"errormessage": message,
+		},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
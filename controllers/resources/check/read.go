@@ -16,35 +16,81 @@ limitations under the License.
 package check
 
 import (
+	"sort"
+	"strings"
+
 	"github.com/giantswarm/microerror"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
 )
 
+/*
+read refreshes the Status from the state of the check in Pingdom.
+
+If the check's summary in the account's Snapshot is consistent with the
+Status, only fields included in the summary are refreshed from it; otherwise
+the check is read from the Pingdom API with all its details.
+*/
 func (cr *checkReconciler) read() error {
+	if pdCheck, ok := cr.snapshotCheck(); ok {
+		cr.log.V(1).Info("populating Status from snapshot", "id", cr.check.Status.ID)
+		cr.populateSummary(pdCheck)
+		return nil
+	}
+	return cr.readDetails()
+}
+
+/*
+snapshotCheck returns the summary of the check from the account's Snapshot,
+if it's there and consistent with the Status: the latest spec was applied and
+none of the summary parameters differ, as otherwise the check's details may
+have changed too.
+*/
+func (cr *checkReconciler) snapshotCheck() (*pingdom.CheckResponse, bool) {
+	status := &cr.check.Status
+	if status.ObservedGeneration != cr.check.GetGeneration() {
+		return nil, false
+	}
+	pdCheck, ok := cr.snapshot.Get(int(status.ID))
+	if !ok {
+		return nil, false
+	}
+	consistent := (status.Name != nil && *status.Name == pdCheck.Name &&
+		status.Host == pdCheck.Hostname &&
+		string(status.Type) == pdCheck.Type.Name &&
+		status.ResolutionMinutes != nil &&
+		int(*status.ResolutionMinutes) == pdCheck.Resolution)
+	return pdCheck, consistent
+}
+
+// readDetails reads the check with all its details from the Pingdom API and
+// populates the Status.
+func (cr *checkReconciler) readDetails() error {
 	status := &cr.check.Status
 	checkID := status.ID
 	log := cr.log.WithValues("action", "read", "id", checkID)
 
 	log.V(1).Info("fetching check resource from Pingdom")
-	pdCheck, err := cr.pdClient.Checks.Read(int(checkID))
+	pdCheck, err := pdclient.ReadCheck(cr.pdClient, cr.apiVersion, int(checkID))
 	if err != nil {
 		log.Error(err, "unable to fetch check resource from Pingdom")
+		cr.recorder.Eventf(
+			cr.check, corev1.EventTypeWarning, ReasonRefreshFailed,
+			"Failed to read Pingdom check %d: %v", checkID, err,
+		)
 		return microerror.Maskf(err, "unable to fetch check resource from Pingdom")
 	}
 
 	log.V(1).Info("populating Status")
-	status.Name = &pdCheck.Name
-	status.Type = observabilityv1alpha1.CheckType(pdCheck.Type.Name)
-	status.Host = pdCheck.Hostname
-	status.ResolutionMinutes = ptrI32(int32(pdCheck.Resolution))
+	cr.populateSummary(pdCheck)
 	status.UserIds = ptrIntSlice(pdCheck.UserIds)
-	status.Status = observabilityv1alpha1.CheckResult(pdCheck.Status)
-	status.LastErrorTime = parsePdTime(pdCheck.LastErrorTime)
-	status.LastTestTime = parsePdTime(pdCheck.LastTestTime)
-	status.LastResponseTimeMilis = &pdCheck.LastResponseTime
-	status.CreatedTime = *parsePdTime(pdCheck.Created)
+	status.TeamIds = ptrIntSlice(pdCheck.TeamIds)
+	status.Tags = cr.userTags(pdCheck.Tags)
+	cr.readOwnership(pdCheck.Tags)
 	if pdCheck.Type.Name == string(observabilityv1alpha1.HTTP) {
 		if pdCheck.Type.HTTP == nil {
 			err = microerror.New("check type is http but details not available")
@@ -54,6 +100,13 @@ func (cr *checkReconciler) read() error {
 		status.Port = ptrI32(int32(pdCheck.Type.HTTP.Port))
 		status.URL = &pdCheck.Type.HTTP.Url
 		status.Encryption = &pdCheck.Type.HTTP.Encryption
+		status.ShouldContain = ptrStrOrNil(pdCheck.Type.HTTP.ShouldContain)
+		status.ShouldNotContain = ptrStrOrNil(pdCheck.Type.HTTP.ShouldNotContain)
+		status.PostData = ptrStrOrNil(pdCheck.Type.HTTP.PostData)
+		status.RequestHeaderChecksums = cr.headerChecksums(pdCheck.Type.HTTP.RequestHeaders)
+		status.AuthChecksum = cr.authChecksum(
+			pdCheck.Type.HTTP.Username, pdCheck.Type.HTTP.Password,
+		)
 	} else if pdCheck.Type.Name == string(observabilityv1alpha1.TCP) {
 		if pdCheck.Type.TCP == nil {
 			err = microerror.New("check type is tcp but details not available")
@@ -63,10 +116,61 @@ func (cr *checkReconciler) read() error {
 		status.Port = ptrI32(int32(pdCheck.Type.TCP.Port))
 	}
 
+	cr.snapshot.MarkRead(int(checkID))
 	log.V(1).Info("populated Status object from Pingdom state")
 	return nil
 }
 
+// userTags returns names of given tags of the check except ownership tags,
+// sorted.
+func (cr *checkReconciler) userTags(tags []pingdom.CheckResponseTag) []string {
+	names := []string{}
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag.Name, observabilityv1alpha1.OwnershipTagPrefix) {
+			names = append(names, tag.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+/*
+readOwnership records whether given tags of the check include the ownership
+tag of the Check, or the ownership tag of a different Check.
+*/
+func (cr *checkReconciler) readOwnership(tags []pingdom.CheckResponseTag) {
+	ownershipTag := cr.check.OwnershipTag()
+	cr.untagged = true
+	cr.foreignTag = ""
+	for _, tag := range tags {
+		switch {
+		case tag.Name == ownershipTag:
+			cr.untagged = false
+		case strings.HasPrefix(tag.Name, observabilityv1alpha1.OwnershipTagPrefix):
+			cr.foreignTag = tag.Name
+		}
+	}
+}
+
+// populateSummary populates the Status with parameters included in both the
+// Pingdom check list and details responses.
+func (cr *checkReconciler) populateSummary(pdCheck *pingdom.CheckResponse) {
+	status := &cr.check.Status
+	name := pdCheck.Name
+	status.Name = &name
+	status.Type = observabilityv1alpha1.CheckType(pdCheck.Type.Name)
+	status.Host = pdCheck.Hostname
+	status.ResolutionMinutes = ptrI32(int32(pdCheck.Resolution))
+	previousStatus := status.Status
+	status.Status = observabilityv1alpha1.CheckResult(pdCheck.Status)
+	cr.recordStatusTransition(previousStatus, status.Status)
+	status.LastErrorTime = parsePdTime(pdCheck.LastErrorTime)
+	status.LastTestTime = parsePdTime(pdCheck.LastTestTime)
+	responseTime := pdCheck.LastResponseTime
+	status.LastResponseTimeMilis = &responseTime
+	status.CreatedTime = *parsePdTime(pdCheck.Created)
+}
+
 /*
 prtI32 returns a pointer to a given int32 value
 */
@@ -74,6 +178,18 @@ func ptrI32(i int32) *int32 {
 	return &i
 }
 
+/*
+ptrStrOrNil returns a pointer to a given string value or nil if the string is
+empty. Pingdom API omits unset optional string parameters so an empty value
+means it's not set.
+*/
+func ptrStrOrNil(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
 /*
 ptrIntSlice returns a pointer to an int slice
 If the given int slice is nil, it initialises a new empty slice and returns a
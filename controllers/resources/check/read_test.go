@@ -0,0 +1,156 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+	"gitlab.com/mig4/pingdom-operator/controllers/resources"
+)
+
+var _ = Describe("Read", func() {
+	var (
+		fake     *fakePingdom
+		snapshot *pdclient.Snapshot
+		check    *observabilityv1alpha1.Check
+		id       int
+	)
+
+	reconciler := func() resources.ResourceReconciler {
+		return New(&Config{
+			Logger:   zap.Logger(true),
+			Recorder: record.NewFakeRecorder(10),
+			PdClient: fake.Client(),
+			Snapshot: snapshot,
+			Check:    check,
+		})
+	}
+	poll := func() {
+		checks, err := fake.Client().Checks.List()
+		Expect(err).NotTo(HaveOccurred())
+		snapshot.Update(checks)
+	}
+	detailReads := func() int {
+		count := 0
+		for _, request := range fake.Requests() {
+			if request == "GET /checks/"+strconv.Itoa(id) {
+				count++
+			}
+		}
+		return count
+	}
+
+	BeforeEach(func() {
+		fake = newFakePingdom()
+		snapshot = pdclient.NewSnapshot(time.Minute, 0)
+		id = fake.Add(map[string]string{
+			"name": "read", "host": "read.example.com", "type": "http",
+			"url": "/health", "port": "80",
+		})
+		check = &observabilityv1alpha1.Check{
+			ObjectMeta: metav1.ObjectMeta{Name: "read", Namespace: "default", Generation: 1},
+			Spec: observabilityv1alpha1.CheckSpec{
+				CheckParameters: observabilityv1alpha1.CheckParameters{
+					Host: "read.example.com",
+					Type: observabilityv1alpha1.HTTP,
+				},
+				CredentialsSecret: corev1.LocalObjectReference{Name: "creds"},
+			},
+			Status: observabilityv1alpha1.CheckStatus{ID: int32(id), ObservedGeneration: 1},
+		}
+		check.Default()
+	})
+
+	AfterEach(func() {
+		fake.Close()
+	})
+
+	It("reads details when the snapshot has no summary of the check", func() {
+		Expect(reconciler().RefreshState(context.Background())).To(Succeed())
+		Expect(detailReads()).To(Equal(1))
+		Expect(*check.Status.URL).To(Equal("/health"))
+	})
+
+	It("refreshes from the snapshot once details are known", func() {
+		Expect(reconciler().RefreshState(context.Background())).To(Succeed())
+		poll()
+		fake.Get(id).Status = "down"
+
+		Expect(reconciler().RefreshState(context.Background())).To(Succeed())
+		Expect(detailReads()).To(Equal(1))
+		Expect(check.Status.Status).To(Equal(observabilityv1alpha1.Up))
+
+		poll()
+		Expect(reconciler().RefreshState(context.Background())).To(Succeed())
+		Expect(detailReads()).To(Equal(1))
+		Expect(check.Status.Status).To(Equal(observabilityv1alpha1.Down))
+		Expect(*check.Status.URL).To(Equal("/health"))
+	})
+
+	It("reads details when the summary differs from status", func() {
+		Expect(reconciler().RefreshState(context.Background())).To(Succeed())
+		fake.Get(id).Params["host"] = "moved.example.com"
+		poll()
+
+		Expect(reconciler().RefreshState(context.Background())).To(Succeed())
+		Expect(detailReads()).To(Equal(2))
+		Expect(check.Status.Host).To(Equal("moved.example.com"))
+	})
+
+	It("reads details when the spec changed", func() {
+		Expect(reconciler().RefreshState(context.Background())).To(Succeed())
+		poll()
+		check.Generation = 2
+
+		Expect(reconciler().RefreshState(context.Background())).To(Succeed())
+		Expect(detailReads()).To(Equal(2))
+	})
+
+	It("reads details after the check is updated", func() {
+		Expect(reconciler().RefreshState(context.Background())).To(Succeed())
+		url := "/ready"
+		check.Spec.URL = &url
+		Expect(reconciler().EnsureState(context.Background())).To(Succeed())
+		poll()
+
+		Expect(reconciler().RefreshState(context.Background())).To(Succeed())
+		Expect(detailReads()).To(Equal(2))
+		Expect(*check.Status.URL).To(Equal("/ready"))
+	})
+
+	It("periodically reads details to detect changes made in Pingdom", func() {
+		snapshot = pdclient.NewSnapshot(time.Minute, time.Millisecond)
+		Expect(reconciler().RefreshState(context.Background())).To(Succeed())
+		fake.Get(id).Params["url"] = "/changed"
+		poll()
+		time.Sleep(2 * time.Millisecond)
+
+		Expect(reconciler().RefreshState(context.Background())).To(Succeed())
+		Expect(detailReads()).To(Equal(2))
+		Expect(*check.Status.URL).To(Equal("/changed"))
+	})
+})
@@ -17,6 +17,10 @@ package check
 
 import (
 	"context"
+
+	corev1 "k8s.io/api/core/v1"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
 )
 
 // Name describes the object this reconciler maintains
@@ -30,26 +34,86 @@ func (cr *checkReconciler) RefreshState(ctx context.Context) error {
 		log.Info("Pingdom resource doesn't exist yet, nothing to refresh")
 		return nil
 	}
-	return cr.read()
+	if err := cr.read(); err != nil {
+		status.SetCondition(
+			observabilityv1alpha1.Synced, corev1.ConditionFalse,
+			ReasonRefreshFailed, err.Error(),
+		)
+		return err
+	}
+	if !cr.check.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+	// The summary is informational so failing to refresh it doesn't fail the
+	// reconcile, unless the request limit was reached which needs a back off
+	if err := cr.refreshSummary(); err != nil {
+		if IsRateLimitError(err) {
+			return err
+		}
+		log.Error(err, "unable to refresh summary of the check")
+		cr.recorder.Eventf(
+			cr.check, corev1.EventTypeWarning, ReasonSummaryFailed,
+			"Failed to read summary of Pingdom check %d: %v", checkID, err,
+		)
+	}
+	return nil
 }
 
 func (cr *checkReconciler) EnsureState(ctx context.Context) (err error) {
 	log := cr.log.WithValues("action", "ensureState")
 	log.Info("entered reconciling external resource state")
 
+	deleting := !cr.check.GetDeletionTimestamp().IsZero()
+	if !deleting && cr.check.Status.ID == 0 {
+		if err = cr.adopt(); err != nil {
+			cr.recorder.Eventf(
+				cr.check, corev1.EventTypeWarning, ReasonAdoptFailed,
+				"Failed to adopt existing Pingdom check: %v", err,
+			)
+			cr.check.Status.SetCondition(
+				observabilityv1alpha1.Synced, corev1.ConditionFalse,
+				ReasonAdoptFailed, err.Error(),
+			)
+			return err
+		}
+	}
+
+	var successReason, failureReason string
 	switch {
-	case !cr.check.GetDeletionTimestamp().IsZero():
+	case deleting:
+		successReason, failureReason = ReasonDeleted, ReasonDeleteFailed
+		switch cr.deletionPolicy() {
+		case observabilityv1alpha1.DeletionPolicyRetain:
+			successReason = ReasonRetained
+		case observabilityv1alpha1.DeletionPolicyPause:
+			successReason = ReasonPaused
+		}
 		err = cr.delete()
 	case cr.check.Status.ID == 0:
+		successReason, failureReason = ReasonCreated, ReasonCreateFailed
 		err = cr.create()
-	case cr.check.NeedsUpdate():
+	case cr.check.NeedsUpdate() || cr.authNeedsUpdate() || cr.headersNeedUpdate() || cr.untagged || cr.foreignTag != "":
+		successReason, failureReason = ReasonUpdated, ReasonUpdateFailed
 		err = cr.update()
 	default:
+		successReason = ReasonUpToDate
 		log.V(1).Info(
 			"check is up-to-date with regards to its spec", "id", cr.check.Status.ID,
 		)
 	}
 
+	if err != nil {
+		cr.check.Status.SetCondition(
+			observabilityv1alpha1.Synced, corev1.ConditionFalse,
+			failureReason, err.Error(),
+		)
+	} else {
+		cr.check.Status.SetCondition(
+			observabilityv1alpha1.Synced, corev1.ConditionTrue,
+			successReason, "Pingdom check matches the spec",
+		)
+	}
+
 	log.Info("finished reconciling external resource state")
 	return err
 }
@@ -0,0 +1,95 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"strings"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+)
+
+/*
+checkRequest adapts a CheckSpec along with the resolved basic authentication
+credentials and the ownership tag to the pingdom.Check interface.
+
+userTags are the tags of the check on Pingdom other than ownership tags, set
+only when those have to be replaced, to drop the ownership tag of a different
+Check.
+*/
+type checkRequest struct {
+	spec     *observabilityv1alpha1.CheckSpec
+	auth     *Credentials
+	tag      string
+	userTags []string
+}
+
+/*
+PutParams replaces tags of the check when the spec sets them, otherwise only
+adds the ownership tag, so it's kept on adopted checks, unless the tags of the
+check have to be replaced with its user tags.
+*/
+func (r *checkRequest) PutParams() map[string]string {
+	params := r.withAuth(r.spec.PutParams())
+	if len(params) == 0 {
+		return params
+	}
+	switch {
+	case r.spec.Tags != nil:
+		params["tags"] = r.tags()
+	case r.userTags != nil:
+		params["tags"] = strings.Join(append([]string{r.tag}, r.userTags...), ",")
+	default:
+		params["addtags"] = r.tag
+	}
+	return params
+}
+
+func (r *checkRequest) PostParams() map[string]string {
+	params := r.withAuth(r.spec.PostParams())
+	if len(params) > 0 {
+		params["tags"] = r.tags()
+	}
+	return params
+}
+
+func (r *checkRequest) Valid() error {
+	return r.spec.Valid()
+}
+
+// tags returns the ownership tag along with tags from the spec, comma
+// separated.
+func (r *checkRequest) tags() string {
+	return strings.Join(append([]string{r.tag}, r.spec.Tags...), ",")
+}
+
+func (r *checkRequest) withAuth(params map[string]string) map[string]string {
+	if r.auth != nil && len(params) > 0 {
+		params["auth"] = r.auth.Username + ":" + r.auth.Password
+	}
+	return params
+}
+
+func (cr *checkReconciler) request() *checkRequest {
+	r := &checkRequest{
+		spec: &cr.check.Spec,
+		auth: cr.auth,
+		tag:  cr.check.OwnershipTag(),
+	}
+	if cr.foreignTag != "" {
+		r.userTags = append([]string{}, cr.check.Status.Tags...)
+	}
+	return r
+}
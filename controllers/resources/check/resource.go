@@ -16,9 +16,14 @@ limitations under the License.
 package check
 
 import (
+	"time"
+
 	"github.com/go-logr/logr"
 	"github.com/russellcardullo/go-pingdom/pingdom"
+	"k8s.io/client-go/tools/record"
+
 	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
 	"gitlab.com/mig4/pingdom-operator/controllers/resources"
 )
 
@@ -28,14 +33,50 @@ for Check objects.
 */
 type Config struct {
 	Logger   logr.Logger
-	PdClient *pingdom.Client
+	Recorder record.EventRecorder
 	Check    *observabilityv1alpha1.Check
+
+	// PdClient is the Pingdom API client for the Check's credentials; it's
+	// shared with other reconciles using the same credentials (see
+	// pdclient.Cache) so must not be modified
+	PdClient *pingdom.Client
+
+	// APIVersion is the version of Pingdom API PdClient uses
+	APIVersion string
+
+	// Snapshot holds summaries of all checks of the account, used to refresh
+	// the Status without reading the check individually; optional
+	Snapshot *pdclient.Snapshot
+
+	// HTTPAuth are credentials resolved from CheckSpec.Auth (if set)
+	HTTPAuth *Credentials
+
+	// AuthKey is the secret key of checksums of HTTPAuth stored in the
+	// Status
+	AuthKey []byte
+
+	// SummaryInterval is how often the summary in the Status is refreshed;
+	// it takes a few requests per check so it should be much less often than
+	// the status, 0 disables it
+	SummaryInterval time.Duration
 }
 
 type checkReconciler struct {
-	log      logr.Logger
-	pdClient *pingdom.Client
-	check    *observabilityv1alpha1.Check
+	log        logr.Logger
+	recorder   record.EventRecorder
+	pdClient   *pingdom.Client
+	apiVersion string
+	snapshot   *pdclient.Snapshot
+	check      *observabilityv1alpha1.Check
+	auth       *Credentials
+	authKey    []byte
+
+	summaryInterval time.Duration
+
+	// untagged is set when the check read from Pingdom lacks the ownership
+	// tag and foreignTag to the ownership tag of a different Check it has
+	untagged   bool
+	foreignTag string
 
 	didWork bool
 }
@@ -48,8 +89,15 @@ func New(config *Config) resources.ResourceReconciler {
 		log: config.Logger.WithName("resource-reconciler").WithValues(
 			"name", config.Check.GetName(),
 		),
-		pdClient: config.PdClient,
-		check:    config.Check,
-		didWork:  false,
+		recorder:   config.Recorder,
+		pdClient:   config.PdClient,
+		apiVersion: config.APIVersion,
+		snapshot:   config.Snapshot,
+		check:      config.Check,
+		auth:       config.HTTPAuth,
+		authKey:    config.AuthKey,
+
+		summaryInterval: config.SummaryInterval,
+		didWork:         false,
 	}
 }
@@ -0,0 +1,112 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+)
+
+const day = 24 * time.Hour
+
+/*
+refreshSummary refreshes the uptime, average response time and last outage of
+the check in the Status, if it wasn't refreshed in the last summary interval.
+The summary is cleared if the interval is 0, which disables it.
+
+It takes two requests: uptime over all periods and the last outage come from
+the outage summary of the last 30 days, the average response time from the
+average summary of the last day.
+*/
+func (cr *checkReconciler) refreshSummary() error {
+	status := &cr.check.Status
+	if cr.summaryInterval <= 0 {
+		status.Summary = nil
+		return nil
+	}
+	now := time.Now()
+	if status.Summary != nil && now.Sub(status.Summary.UpdatedTime.Time) < cr.summaryInterval {
+		return nil
+	}
+	checkID := int(status.ID)
+	cr.log.V(1).Info("refreshing check summary", "id", checkID)
+
+	states, err := pdclient.SummaryOutage(cr.pdClient, checkID, now.Add(-30*day), now)
+	if err != nil {
+		return microerror.Maskf(err, "unable to read outage summary")
+	}
+	summary := &observabilityv1alpha1.CheckSummary{UpdatedTime: metav1.NewTime(now)}
+	for _, period := range []struct {
+		length time.Duration
+		uptime **string
+	}{
+		{day, &summary.Uptime24h},
+		{7 * day, &summary.Uptime7d},
+		{30 * day, &summary.Uptime30d},
+	} {
+		if uptime, ok := pdclient.OutageUptime(states, now.Add(-period.length), now); ok {
+			formatted := strconv.FormatFloat(uptime, 'f', 2, 64)
+			*period.uptime = &formatted
+		}
+	}
+	summary.LastOutage = lastOutage(states)
+
+	average, err := pdclient.SummaryAverage(cr.pdClient, checkID, now.Add(-day), now)
+	if err != nil {
+		return microerror.Maskf(err, "unable to read average summary")
+	}
+	if average.AvgResponse > 0 {
+		avgResponse := int64(average.AvgResponse)
+		summary.AvgResponseTimeMilis = &avgResponse
+	}
+
+	status.Summary = summary
+	return nil
+}
+
+/*
+lastOutage returns the most recent outage from given states (oldest first),
+merging consecutive down states; the outage has no end if the check is still
+down.
+*/
+func lastOutage(states []pdclient.OutageState) *observabilityv1alpha1.Outage {
+	end := -1
+	for i := len(states) - 1; i >= 0; i-- {
+		if states[i].Status == pdclient.OutageStatusDown {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return nil
+	}
+	start := end
+	for start > 0 && states[start-1].Status == pdclient.OutageStatusDown {
+		start--
+	}
+
+	outage := &observabilityv1alpha1.Outage{Start: *parsePdTime(states[start].TimeFrom)}
+	if end < len(states)-1 {
+		outage.End = parsePdTime(states[end].TimeTo)
+	}
+	return outage
+}
@@ -0,0 +1,168 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+	"gitlab.com/mig4/pingdom-operator/controllers/resources"
+)
+
+var _ = Describe("Summary", func() {
+	var (
+		fake  *fakePingdom
+		check *observabilityv1alpha1.Check
+		id    int
+	)
+
+	reconciler := func() resources.ResourceReconciler {
+		return New(&Config{
+			Logger:          zap.Logger(true),
+			Recorder:        record.NewFakeRecorder(10),
+			PdClient:        fake.Client(),
+			Check:           check,
+			SummaryInterval: 15 * time.Minute,
+		})
+	}
+	// ago returns the Unix time given duration ago
+	ago := func(d time.Duration) int64 {
+		return time.Now().Add(-d).Unix()
+	}
+	summaryReads := func() int {
+		count := 0
+		for _, request := range fake.Requests() {
+			if strings.HasPrefix(request, "GET /summary") {
+				count++
+			}
+		}
+		return count
+	}
+
+	BeforeEach(func() {
+		fake = newFakePingdom()
+		id = fake.Add(map[string]string{
+			"name": "summary", "host": "summary.example.com", "type": "ping",
+		})
+		fake.Get(id).AvgResponse = 120
+		check = &observabilityv1alpha1.Check{
+			ObjectMeta: metav1.ObjectMeta{Name: "summary", Namespace: "default"},
+			Spec: observabilityv1alpha1.CheckSpec{
+				CheckParameters: observabilityv1alpha1.CheckParameters{
+					Host: "summary.example.com",
+					Type: observabilityv1alpha1.Ping,
+				},
+				CredentialsSecret: corev1.LocalObjectReference{Name: "creds"},
+			},
+			Status: observabilityv1alpha1.CheckStatus{ID: int32(id)},
+		}
+		check.Default()
+	})
+
+	AfterEach(func() {
+		fake.Close()
+	})
+
+	It("populates uptime of each period and average response time", func() {
+		fake.Get(id).Outages = []pdclient.OutageState{
+			{Status: "up", TimeFrom: ago(30 * day), TimeTo: ago(10 * day)},
+			{Status: "down", TimeFrom: ago(10 * day), TimeTo: ago(7 * day)},
+			{Status: "up", TimeFrom: ago(7 * day), TimeTo: ago(0)},
+		}
+		Expect(reconciler().RefreshState(context.Background())).To(Succeed())
+
+		summary := check.Status.Summary
+		Expect(summary).NotTo(BeNil())
+		Expect(*summary.Uptime24h).To(Equal("100.00"))
+		Expect(*summary.Uptime7d).To(Equal("100.00"))
+		Expect(*summary.Uptime30d).To(Equal("90.00"))
+		Expect(*summary.AvgResponseTimeMilis).To(Equal(int64(120)))
+		Expect(summary.LastOutage.Start.Unix()).To(Equal(ago(10 * day)))
+	})
+
+	It("populates the most recent outage", func() {
+		fake.Get(id).Outages = []pdclient.OutageState{
+			{Status: "down", TimeFrom: ago(3000), TimeTo: ago(2900)},
+			{Status: "up", TimeFrom: ago(2900), TimeTo: ago(2000)},
+			{Status: "down", TimeFrom: ago(2000), TimeTo: ago(1900)},
+			{Status: "down", TimeFrom: ago(1900), TimeTo: ago(1800)},
+			{Status: "up", TimeFrom: ago(1800), TimeTo: ago(0)},
+		}
+		Expect(reconciler().RefreshState(context.Background())).To(Succeed())
+
+		outage := check.Status.Summary.LastOutage
+		Expect(outage.Start.Unix()).To(Equal(ago(2000)))
+		Expect(outage.End.Unix()).To(Equal(ago(1800)))
+	})
+
+	It("leaves the end of an ongoing outage unset", func() {
+		fake.Get(id).Outages = []pdclient.OutageState{
+			{Status: "up", TimeFrom: ago(3000), TimeTo: ago(2000)},
+			{Status: "down", TimeFrom: ago(2000), TimeTo: ago(0)},
+		}
+		Expect(reconciler().RefreshState(context.Background())).To(Succeed())
+
+		outage := check.Status.Summary.LastOutage
+		Expect(outage.Start.Unix()).To(Equal(ago(2000)))
+		Expect(outage.End).To(BeNil())
+	})
+
+	It("doesn't refresh a recent summary", func() {
+		Expect(reconciler().RefreshState(context.Background())).To(Succeed())
+		reads := summaryReads()
+		Expect(reads).To(Equal(2))
+
+		Expect(reconciler().RefreshState(context.Background())).To(Succeed())
+		Expect(summaryReads()).To(Equal(reads))
+
+		check.Status.Summary.UpdatedTime = metav1.NewTime(
+			time.Now().Add(-15 * time.Minute),
+		)
+		Expect(reconciler().RefreshState(context.Background())).To(Succeed())
+		Expect(summaryReads()).To(Equal(2 * reads))
+	})
+
+	It("doesn't read or keep the summary when it's disabled", func() {
+		check.Status.Summary = &observabilityv1alpha1.CheckSummary{}
+		disabled := New(&Config{
+			Logger:   zap.Logger(true),
+			Recorder: record.NewFakeRecorder(10),
+			PdClient: fake.Client(),
+			Check:    check,
+		})
+		Expect(disabled.RefreshState(context.Background())).To(Succeed())
+		Expect(summaryReads()).To(BeZero())
+		Expect(check.Status.Summary).To(BeNil())
+	})
+
+	It("leaves uptime unset when the check wasn't tested", func() {
+		fake.Get(id).Outages = []pdclient.OutageState{
+			{Status: "unknown", TimeFrom: ago(30 * day), TimeTo: ago(0)},
+		}
+		Expect(reconciler().RefreshState(context.Background())).To(Succeed())
+		Expect(check.Status.Summary.Uptime30d).To(BeNil())
+	})
+})
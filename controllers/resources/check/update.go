@@ -15,14 +15,28 @@ limitations under the License.
 
 package check
 
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
 func (cr *checkReconciler) update() error {
 	log := cr.log.WithValues("action", "update", "id", cr.check.Status.ID)
 	log.Info("updating check resource on Pingdom")
-	resp, err := cr.pdClient.Checks.Update(int(cr.check.Status.ID), &cr.check.Spec)
+	cr.snapshot.MarkChanged(int(cr.check.Status.ID))
+	resp, err := cr.pdClient.Checks.Update(int(cr.check.Status.ID), cr.request())
 	log.V(1).Info("Pingdom Checks.Update() response", "response", resp, "error", err)
-	if err == nil {
-		log.Info("updated check resource on Pingdom", "message", resp.Message)
-		cr.didWork = true
+	if err != nil {
+		cr.recorder.Eventf(
+			cr.check, corev1.EventTypeWarning, ReasonUpdateFailed,
+			"Failed to update Pingdom check %d: %v", cr.check.Status.ID, err,
+		)
+		return err
 	}
-	return err
+	log.Info("updated check resource on Pingdom", "message", resp.Message)
+	cr.recorder.Eventf(
+		cr.check, corev1.EventTypeNormal, ReasonUpdated,
+		"Updated Pingdom check %d", cr.check.Status.ID,
+	)
+	cr.didWork = true
+	return nil
 }
@@ -0,0 +1,41 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenance
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+)
+
+// isNotFoundError returns true if given error returned by the Pingdom API
+// indicates the maintenance window doesn't exist. API 2.1 responds with a 403
+// invalid identifier error in that case, while 3.1 responds with 404.
+func isNotFoundError(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch t := microerror.Cause(err).(type) {
+	case *pingdom.PingdomError:
+		return (t.StatusCode == http.StatusNotFound ||
+			(t.StatusCode == http.StatusForbidden &&
+				strings.Contains(strings.ToLower(t.Message), "identifier")))
+	default:
+		return false
+	}
+}
@@ -0,0 +1,101 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenance
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gitlab.com/mig4/pingdom-operator/controllers/internal/pdfake"
+)
+
+/*
+fakePingdom is an in-memory fake of the maintenance endpoints of the Pingdom
+API 3.1.
+*/
+type fakePingdom struct {
+	*pdfake.Server
+
+	windows map[int]map[string]string
+}
+
+func newFakePingdom() *fakePingdom {
+	fp := &fakePingdom{windows: map[int]map[string]string{}}
+	fp.Server = pdfake.NewServer(100, fp.handle)
+	return fp
+}
+
+// Get returns parameters of a window with given ID or nil if it doesn't
+// exist.
+func (fp *fakePingdom) Get(id int) map[string]string {
+	fp.Lock()
+	defer fp.Unlock()
+	return fp.windows[id]
+}
+
+func (fp *fakePingdom) handle(w http.ResponseWriter, r *http.Request, path string) {
+	params := pdfake.Params(r)
+	if path == "/maintenance" && r.Method == http.MethodPost {
+		id := fp.NextID()
+		fp.windows[id] = params
+		pdfake.WriteJSON(w, http.StatusOK, map[string]interface{}{
+			"maintenance": map[string]interface{}{"id": id},
+		})
+		return
+	}
+
+	id, err := strconv.Atoi(strings.TrimPrefix(path, "/maintenance/"))
+	window, ok := fp.windows[id]
+	if err != nil || !ok {
+		pdfake.WriteNotFound(w)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		atoi := func(key string) int {
+			value, _ := strconv.Atoi(window[key])
+			return value
+		}
+		uptime := []int{}
+		for _, id := range strings.Split(window["uptimeids"], ",") {
+			if id, err := strconv.Atoi(id); err == nil {
+				uptime = append(uptime, id)
+			}
+		}
+		pdfake.WriteJSON(w, http.StatusOK, map[string]interface{}{
+			"maintenance": map[string]interface{}{
+				"id":             id,
+				"description":    window["description"],
+				"from":           atoi("from"),
+				"to":             atoi("to"),
+				"recurrencetype": window["recurrencetype"],
+				"repeatevery":    atoi("repeatevery"),
+				"effectiveto":    atoi("effectiveto"),
+				"checks":         map[string]interface{}{"uptime": uptime, "tms": []int{}},
+			},
+		})
+	case http.MethodPut:
+		for key, value := range params {
+			window[key] = value
+		}
+		pdfake.WriteJSON(w, http.StatusOK, map[string]string{"message": "Maintenance window successfully modified!"})
+	case http.MethodDelete:
+		delete(fp.windows, id)
+		pdfake.WriteJSON(w, http.StatusOK, map[string]string{"message": "Maintenance window successfully deleted!"})
+	}
+}
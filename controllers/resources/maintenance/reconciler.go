@@ -0,0 +1,272 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenance
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	corev1 "k8s.io/api/core/v1"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+)
+
+// Name describes the object this reconciler maintains
+var Name = "maintenance-window-resource"
+
+// Reasons used in events recorded and conditions set by this reconciler
+const (
+	ReasonCreated       = "Created"
+	ReasonCreateFailed  = "CreateFailed"
+	ReasonUpdated       = "Updated"
+	ReasonUpdateFailed  = "UpdateFailed"
+	ReasonDeleted       = "Deleted"
+	ReasonDeleteFailed  = "DeleteFailed"
+	ReasonUpToDate      = "UpToDate"
+	ReasonNoChecks      = "NoChecks"
+	ReasonRefreshFailed = "RefreshFailed"
+)
+
+// recurrenceNone is the recurrence type of windows which happen only once
+const recurrenceNone = "none"
+
+func (mr *maintenanceReconciler) RefreshState(ctx context.Context) error {
+	status := &mr.window.Status
+	log := mr.log.WithValues("action", "refreshState", "id", status.ID)
+	if status.ID == 0 {
+		log.Info("Pingdom resource doesn't exist yet, nothing to refresh")
+		return nil
+	}
+
+	remote, err := mr.pdClient.Maintenances.Read(int(status.ID))
+	if isNotFoundError(err) {
+		log.Info("maintenance window no longer exists in Pingdom")
+		status.ID = 0
+		return nil
+	}
+	if err != nil {
+		status.SetCondition(
+			observabilityv1alpha1.Synced, corev1.ConditionFalse,
+			ReasonRefreshFailed, err.Error(),
+		)
+		return microerror.Maskf(err, "unable to fetch maintenance window from Pingdom")
+	}
+	mr.remote = remote
+	return nil
+}
+
+/*
+EnsureState creates, updates or deletes the Pingdom maintenance window so it
+matches the spec and applies to the selected checks.
+
+Pingdom doesn't allow removing all checks from a window, so a window which
+selects no checks (yet) is deleted until it selects some again.
+*/
+func (mr *maintenanceReconciler) EnsureState(ctx context.Context) (err error) {
+	log := mr.log.WithValues("action", "ensureState")
+	log.Info("entered reconciling external resource state")
+
+	status := &mr.window.Status
+	var successReason, failureReason string
+	switch {
+	case !mr.window.GetDeletionTimestamp().IsZero():
+		successReason, failureReason = ReasonDeleted, ReasonDeleteFailed
+		err = mr.delete()
+	case len(mr.checkIDs) == 0:
+		successReason, failureReason = ReasonNoChecks, ReasonDeleteFailed
+		err = mr.delete()
+	case status.ID == 0:
+		successReason, failureReason = ReasonCreated, ReasonCreateFailed
+		err = mr.create()
+	case mr.needsUpdate():
+		successReason, failureReason = ReasonUpdated, ReasonUpdateFailed
+		err = mr.update()
+	default:
+		successReason = ReasonUpToDate
+		log.V(1).Info("maintenance window is up-to-date with its spec", "id", status.ID)
+	}
+
+	if err != nil {
+		status.SetCondition(
+			observabilityv1alpha1.Synced, corev1.ConditionFalse,
+			failureReason, err.Error(),
+		)
+	} else {
+		message := "Pingdom maintenance window matches the spec"
+		if successReason == ReasonNoChecks {
+			message = "No Checks with a Pingdom check selected"
+		}
+		status.SetCondition(
+			observabilityv1alpha1.Synced, corev1.ConditionTrue,
+			successReason, message,
+		)
+		status.CheckIDs = mr.checkIDs
+	}
+
+	log.Info("finished reconciling external resource state")
+	return err
+}
+
+func (mr *maintenanceReconciler) FinalizerName() *string {
+	return &Name
+}
+
+func (mr *maintenanceReconciler) DidWork() bool {
+	return mr.didWork
+}
+
+func (mr *maintenanceReconciler) create() error {
+	log := mr.log.WithValues("action", "create")
+	created, err := mr.pdClient.Maintenances.Create(mr.request())
+	if err != nil {
+		log.Error(err, "unable to create maintenance window in Pingdom")
+		mr.recorder.Eventf(
+			mr.window, corev1.EventTypeWarning, ReasonCreateFailed,
+			"Failed to create Pingdom maintenance window: %v", err,
+		)
+		return microerror.Maskf(err, "unable to create maintenance window")
+	}
+	mr.window.Status.ID = int32(created.ID)
+	mr.didWork = true
+	log.Info("created maintenance window", "id", created.ID)
+	mr.recorder.Eventf(
+		mr.window, corev1.EventTypeNormal, ReasonCreated,
+		"Created Pingdom maintenance window %d", created.ID,
+	)
+	return nil
+}
+
+func (mr *maintenanceReconciler) update() error {
+	id := mr.window.Status.ID
+	log := mr.log.WithValues("action", "update", "id", id)
+	if _, err := mr.pdClient.Maintenances.Update(int(id), mr.request()); err != nil {
+		log.Error(err, "unable to update maintenance window in Pingdom")
+		mr.recorder.Eventf(
+			mr.window, corev1.EventTypeWarning, ReasonUpdateFailed,
+			"Failed to update Pingdom maintenance window %d: %v", id, err,
+		)
+		return microerror.Maskf(err, "unable to update maintenance window")
+	}
+	mr.didWork = true
+	log.Info("updated maintenance window")
+	mr.recorder.Eventf(
+		mr.window, corev1.EventTypeNormal, ReasonUpdated,
+		"Updated Pingdom maintenance window %d", id,
+	)
+	return nil
+}
+
+func (mr *maintenanceReconciler) delete() error {
+	id := mr.window.Status.ID
+	if id == 0 {
+		return nil
+	}
+	log := mr.log.WithValues("action", "delete", "id", id)
+	if _, err := mr.pdClient.Maintenances.Delete(int(id)); err != nil && !isNotFoundError(err) {
+		log.Error(err, "unable to delete maintenance window in Pingdom")
+		mr.recorder.Eventf(
+			mr.window, corev1.EventTypeWarning, ReasonDeleteFailed,
+			"Failed to delete Pingdom maintenance window %d: %v", id, err,
+		)
+		return microerror.Maskf(err, "unable to delete maintenance window")
+	}
+	mr.window.Status.ID = 0
+	mr.didWork = true
+	log.Info("deleted maintenance window")
+	mr.recorder.Eventf(
+		mr.window, corev1.EventTypeNormal, ReasonDeleted,
+		"Deleted Pingdom maintenance window %d", id,
+	)
+	return nil
+}
+
+// request returns the Pingdom maintenance window described by the spec.
+func (mr *maintenanceReconciler) request() *pingdom.MaintenanceWindow {
+	spec := &mr.window.Spec
+	request := &pingdom.MaintenanceWindow{
+		Description:    mr.description(),
+		From:           spec.Start.Unix(),
+		To:             spec.End.Unix(),
+		RecurrenceType: recurrenceNone,
+		UptimeIDs:      joinIDs(mr.checkIDs),
+	}
+	if spec.Recurrence != nil {
+		request.RecurrenceType = string(spec.Recurrence.Type)
+		request.RepeatEvery = 1
+		if spec.Recurrence.RepeatEvery != nil {
+			request.RepeatEvery = int(*spec.Recurrence.RepeatEvery)
+		}
+		request.EffectiveTo = int(spec.Recurrence.Until.Unix())
+	}
+	return request
+}
+
+// description returns the description of the window, defaulting to its
+// namespaced name
+func (mr *maintenanceReconciler) description() string {
+	if desc := mr.window.Spec.Description; desc != nil && *desc != "" {
+		return *desc
+	}
+	return mr.window.GetNamespace() + "/" + mr.window.GetName()
+}
+
+// needsUpdate returns true if the window read from Pingdom differs from the
+// one described by the spec.
+func (mr *maintenanceReconciler) needsUpdate() bool {
+	if mr.remote == nil {
+		return true
+	}
+	request := mr.request()
+	remote := *mr.remote
+	remoteRecurrence := remote.RecurrenceType
+	if remoteRecurrence == "" {
+		remoteRecurrence = recurrenceNone
+	}
+	if remoteRecurrence == recurrenceNone {
+		// repetition parameters are meaningless for one-off windows
+		remote.RepeatEvery, remote.EffectiveTo = 0, 0
+	}
+	return (remote.Description != request.Description ||
+		remote.From != request.From ||
+		remote.To != request.To ||
+		remoteRecurrence != request.RecurrenceType ||
+		remote.RepeatEvery != request.RepeatEvery ||
+		remote.EffectiveTo != int64(request.EffectiveTo) ||
+		joinIDs(intsToInt32s(remote.Checks.Uptime)) != request.UptimeIDs)
+}
+
+// joinIDs returns sorted identifiers as a comma separated list
+func joinIDs(ids []int32) string {
+	sorted := append([]int32(nil), ids...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	strs := make([]string, len(sorted))
+	for i, id := range sorted {
+		strs[i] = strconv.FormatInt(int64(id), 10)
+	}
+	return strings.Join(strs, ",")
+}
+
+func intsToInt32s(ints []int) []int32 {
+	result := make([]int32, len(ints))
+	for i, v := range ints {
+		result[i] = int32(v)
+	}
+	return result
+}
@@ -0,0 +1,143 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenance
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+)
+
+var _ = Describe("MaintenanceReconciler", func() {
+	var (
+		ctx      = context.Background()
+		fake     *fakePingdom
+		window   *observabilityv1alpha1.MaintenanceWindow
+		checkIDs []int32
+		start    = time.Date(2020, 1, 1, 2, 0, 0, 0, time.UTC)
+	)
+
+	reconcile := func() {
+		reconciler := New(&Config{
+			Logger:   zap.Logger(true),
+			Recorder: record.NewFakeRecorder(10),
+			PdClient: fake.Client(),
+			Window:   window,
+			CheckIDs: checkIDs,
+		})
+		Expect(reconciler.RefreshState(ctx)).To(Succeed())
+		Expect(reconciler.EnsureState(ctx)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		fake = newFakePingdom()
+		checkIDs = []int32{2, 1}
+		window = &observabilityv1alpha1.MaintenanceWindow{
+			ObjectMeta: metav1.ObjectMeta{Name: "upgrade", Namespace: "default"},
+			Spec: observabilityv1alpha1.MaintenanceWindowSpec{
+				Start:             metav1.NewTime(start),
+				End:               metav1.NewTime(start.Add(time.Hour)),
+				CredentialsSecret: corev1.LocalObjectReference{Name: "creds"},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		fake.Close()
+	})
+
+	It("creates a window for the selected checks", func() {
+		reconcile()
+
+		Expect(window.Status.ID).NotTo(BeZero())
+		Expect(window.Status.CheckIDs).To(Equal(checkIDs))
+		Expect(fake.Get(int(window.Status.ID))).To(Equal(map[string]string{
+			"description":    "default/upgrade",
+			"from":           "1577844000",
+			"to":             "1577847600",
+			"recurrencetype": "none",
+			"uptimeids":      "1,2",
+		}))
+	})
+
+	It("creates a recurring window", func() {
+		window.Spec.Recurrence = &observabilityv1alpha1.Recurrence{
+			Type:  observabilityv1alpha1.RecurrenceWeekly,
+			Until: metav1.NewTime(start.Add(30 * 24 * time.Hour)),
+		}
+		reconcile()
+
+		remote := fake.Get(int(window.Status.ID))
+		Expect(remote["recurrencetype"]).To(Equal("week"))
+		Expect(remote["repeatevery"]).To(Equal("1"))
+		Expect(remote["effectiveto"]).To(Equal("1580436000"))
+	})
+
+	It("doesn't update an up-to-date window", func() {
+		reconcile()
+		reconcile()
+		Expect(fake.Requests()).NotTo(ContainElement(HavePrefix("PUT")))
+	})
+
+	It("updates the window when checks change", func() {
+		reconcile()
+		checkIDs = []int32{1, 2, 3}
+		reconcile()
+
+		Expect(fake.Requests()).To(ContainElement(HavePrefix("PUT")))
+		Expect(fake.Get(int(window.Status.ID))["uptimeids"]).To(Equal("1,2,3"))
+		Expect(window.Status.CheckIDs).To(Equal(checkIDs))
+	})
+
+	It("deletes the window when no checks are selected", func() {
+		reconcile()
+		id := int(window.Status.ID)
+		checkIDs = nil
+		reconcile()
+
+		Expect(fake.Get(id)).To(BeNil())
+		Expect(window.Status.ID).To(BeZero())
+		Expect(window.Status.GetCondition(observabilityv1alpha1.Synced).Reason).To(Equal(ReasonNoChecks))
+	})
+
+	It("re-creates a window deleted in Pingdom", func() {
+		reconcile()
+		id := int(window.Status.ID)
+		fake.windows = map[int]map[string]string{}
+		reconcile()
+
+		Expect(window.Status.ID).NotTo(BeZero())
+		Expect(int(window.Status.ID)).NotTo(Equal(id))
+	})
+
+	It("deletes the window when deleting", func() {
+		reconcile()
+		id := int(window.Status.ID)
+		now := metav1.Now()
+		window.DeletionTimestamp = &now
+		reconcile()
+
+		Expect(fake.Get(id)).To(BeNil())
+	})
+})
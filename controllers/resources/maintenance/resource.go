@@ -0,0 +1,76 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package maintenance implements a ResourceReconciler for Pingdom maintenance
+windows, maintained for MaintenanceWindow objects.
+*/
+package maintenance
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"k8s.io/client-go/tools/record"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/resources"
+)
+
+/*
+Config is a structure holding data needed to create a new ResourceReconciller
+for MaintenanceWindow objects.
+*/
+type Config struct {
+	Logger   logr.Logger
+	Recorder record.EventRecorder
+	Window   *observabilityv1alpha1.MaintenanceWindow
+
+	// PdClient is the Pingdom API client for the window's credentials; it's
+	// shared with other reconciles so must not be modified
+	PdClient *pingdom.Client
+
+	// CheckIDs are identifiers of Pingdom checks of the Checks selected by
+	// the window
+	CheckIDs []int32
+}
+
+type maintenanceReconciler struct {
+	log      logr.Logger
+	recorder record.EventRecorder
+	pdClient *pingdom.Client
+	window   *observabilityv1alpha1.MaintenanceWindow
+	checkIDs []int32
+
+	// remote is the maintenance window as read from Pingdom by RefreshState
+	remote  *pingdom.MaintenanceResponse
+	didWork bool
+}
+
+/*
+New returns a new ResourceReconciller for a Pingdom maintenance window
+external resource.
+*/
+func New(config *Config) resources.ResourceReconciler {
+	return &maintenanceReconciler{
+		log: config.Logger.WithName("resource-reconciler").WithValues(
+			"name", config.Window.GetName(),
+		),
+		recorder: config.Recorder,
+		pdClient: config.PdClient,
+		window:   config.Window,
+		checkIDs: config.CheckIDs,
+		didWork:  false,
+	}
+}
@@ -0,0 +1,90 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transaction
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gitlab.com/mig4/pingdom-operator/controllers/internal/pdfake"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+)
+
+/*
+fakePingdom is an in-memory fake of the transaction check (TMS) endpoints of
+the Pingdom API 3.1.
+*/
+type fakePingdom struct {
+	*pdfake.Server
+
+	checks map[int]*pdclient.TMSCheck
+}
+
+func newFakePingdom() *fakePingdom {
+	fp := &fakePingdom{checks: map[int]*pdclient.TMSCheck{}}
+	fp.Server = pdfake.NewServer(100, fp.handle)
+	return fp
+}
+
+// Check returns a transaction check with given ID or nil if it doesn't
+// exist.
+func (fp *fakePingdom) Check(id int32) *pdclient.TMSCheck {
+	fp.Lock()
+	defer fp.Unlock()
+	return fp.checks[int(id)]
+}
+
+func (fp *fakePingdom) handle(w http.ResponseWriter, r *http.Request, path string) {
+	if !strings.HasPrefix(path, "/tms/check") {
+		pdfake.WriteNotFound(w)
+		return
+	}
+	path = strings.TrimPrefix(path, "/tms/check")
+
+	if path == "" && r.Method == http.MethodPost {
+		check := &pdclient.TMSCheck{}
+		_ = json.NewDecoder(r.Body).Decode(check)
+		check.ID = fp.NextID()
+		check.Status = "unknown"
+		check.CreatedAt = 1500000000
+		fp.checks[check.ID] = check
+		pdfake.WriteJSON(w, http.StatusOK, check)
+		return
+	}
+
+	id, err := strconv.Atoi(strings.TrimPrefix(path, "/"))
+	check, ok := fp.checks[id]
+	if err != nil || !ok {
+		pdfake.WriteNotFound(w)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		pdfake.WriteJSON(w, http.StatusOK, check)
+	case http.MethodPut:
+		updated := &pdclient.TMSCheck{}
+		_ = json.NewDecoder(r.Body).Decode(updated)
+		updated.ID, updated.Status, updated.CreatedAt = id, check.Status, check.CreatedAt
+		updated.ModifiedAt = 1600000000
+		fp.checks[id] = updated
+		pdfake.WriteJSON(w, http.StatusOK, updated)
+	case http.MethodDelete:
+		delete(fp.checks, id)
+		pdfake.WriteJSON(w, http.StatusOK, map[string]string{"message": "Deletion of check was successful!"})
+	}
+}
@@ -0,0 +1,356 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transaction
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+	checkreconciler "gitlab.com/mig4/pingdom-operator/controllers/resources/check"
+)
+
+// Name describes the object this reconciler maintains
+var Name = "transaction-check-resource"
+
+// Reasons used in events recorded and conditions set by this reconciler
+const (
+	ReasonCreated       = "Created"
+	ReasonCreateFailed  = "CreateFailed"
+	ReasonUpdated       = "Updated"
+	ReasonUpdateFailed  = "UpdateFailed"
+	ReasonDeleted       = "Deleted"
+	ReasonDeleteFailed  = "DeleteFailed"
+	ReasonRetained      = "Retained"
+	ReasonPaused        = "Paused"
+	ReasonUpToDate      = "UpToDate"
+	ReasonRefreshFailed = "RefreshFailed"
+)
+
+// Defaults of parameters of transaction checks
+const (
+	defaultIntervalMinutes = 10
+	defaultRegion          = "us-east"
+)
+
+func (tr *transactionReconciler) RefreshState(ctx context.Context) error {
+	status := &tr.check.Status
+	log := tr.log.WithValues("action", "refreshState", "id", status.ID)
+	if status.ID == 0 {
+		log.Info("Pingdom resource doesn't exist yet, nothing to refresh")
+		return nil
+	}
+	remote, err := pdclient.ReadTMSCheck(tr.pdClient, int(status.ID))
+	if checkreconciler.IsInvalidIdentifierError(err) {
+		log.Info("transaction check no longer exists in Pingdom")
+		status.ID = 0
+		return nil
+	}
+	if err != nil {
+		status.SetCondition(
+			observabilityv1alpha1.Synced, corev1.ConditionFalse,
+			ReasonRefreshFailed, err.Error(),
+		)
+		return microerror.Maskf(err, "unable to fetch transaction check from Pingdom")
+	}
+	tr.remote = remote
+	tr.mirror(remote)
+	status.Status = remote.Status
+	status.CreatedTime = unixTime(remote.CreatedAt)
+	status.ModifiedTime = unixTime(remote.ModifiedAt)
+	status.LastDowntimeStart = unixTime(remote.LastDowntimeStart)
+	status.LastDowntimeEnd = unixTime(remote.LastDowntimeEnd)
+	return nil
+}
+
+/*
+EnsureState creates or updates the Pingdom transaction check so it matches
+the spec, or handles it according to the deletion policy when the object is
+being deleted: deletes it, pauses it or leaves it as is.
+*/
+func (tr *transactionReconciler) EnsureState(ctx context.Context) (err error) {
+	log := tr.log.WithValues("action", "ensureState")
+	log.Info("entered reconciling external resource state")
+	status := &tr.check.Status
+	request := tr.request()
+
+	var successReason, failureReason string
+	switch {
+	case !tr.check.GetDeletionTimestamp().IsZero():
+		failureReason = ReasonDeleteFailed
+		successReason, err = tr.delete(request)
+	case status.ID == 0:
+		successReason, failureReason = ReasonCreated, ReasonCreateFailed
+		err = tr.create(request)
+	case tr.needsUpdate(request):
+		successReason, failureReason = ReasonUpdated, ReasonUpdateFailed
+		err = tr.update(request, ReasonUpdated)
+	default:
+		successReason = ReasonUpToDate
+		log.V(1).Info("transaction check is up-to-date with its spec", "id", status.ID)
+	}
+
+	if err != nil {
+		status.SetCondition(
+			observabilityv1alpha1.Synced, corev1.ConditionFalse,
+			failureReason, err.Error(),
+		)
+	} else {
+		status.SetCondition(
+			observabilityv1alpha1.Synced, corev1.ConditionTrue,
+			successReason, "Pingdom transaction check matches the spec",
+		)
+	}
+	log.Info("finished reconciling external resource state")
+	return err
+}
+
+func (tr *transactionReconciler) FinalizerName() *string {
+	return &Name
+}
+
+func (tr *transactionReconciler) DidWork() bool {
+	return tr.didWork
+}
+
+func (tr *transactionReconciler) create(request *pdclient.TMSCheck) error {
+	log := tr.log.WithValues("action", "create")
+	id, err := pdclient.CreateTMSCheck(tr.pdClient, request)
+	if err != nil {
+		log.Error(err, "unable to create transaction check in Pingdom")
+		tr.recorder.Eventf(
+			tr.check, corev1.EventTypeWarning, ReasonCreateFailed,
+			"Failed to create Pingdom transaction check: %v", err,
+		)
+		return microerror.Maskf(err, "unable to create transaction check")
+	}
+	tr.check.Status.ID = int32(id)
+	tr.mirror(request)
+	tr.didWork = true
+	log.Info("created transaction check", "id", id)
+	tr.recorder.Eventf(
+		tr.check, corev1.EventTypeNormal, ReasonCreated,
+		"Created Pingdom transaction check %d", id,
+	)
+	return nil
+}
+
+// update replaces the Pingdom transaction check with the request, recording
+// an event with given reason.
+func (tr *transactionReconciler) update(request *pdclient.TMSCheck, reason string) error {
+	id := tr.check.Status.ID
+	log := tr.log.WithValues("action", "update", "id", id)
+	if err := pdclient.UpdateTMSCheck(tr.pdClient, int(id), request); err != nil {
+		log.Error(err, "unable to update transaction check in Pingdom")
+		tr.recorder.Eventf(
+			tr.check, corev1.EventTypeWarning, ReasonUpdateFailed,
+			"Failed to update Pingdom transaction check %d: %v", id, err,
+		)
+		return microerror.Maskf(err, "unable to update transaction check")
+	}
+	tr.mirror(request)
+	tr.didWork = true
+	log.Info("updated transaction check", "reason", reason)
+	tr.recorder.Eventf(
+		tr.check, corev1.EventTypeNormal, reason,
+		"%s Pingdom transaction check %d", reason, id,
+	)
+	return nil
+}
+
+/*
+delete handles the Pingdom transaction check when the object is deleted,
+according to its deletion policy, returning the reason of the outcome.
+*/
+func (tr *transactionReconciler) delete(request *pdclient.TMSCheck) (string, error) {
+	id := tr.check.Status.ID
+	if id == 0 {
+		return ReasonDeleted, nil
+	}
+	log := tr.log.WithValues("action", "delete", "id", id)
+
+	switch tr.deletionPolicy() {
+	case observabilityv1alpha1.DeletionPolicyRetain:
+		log.Info("retaining transaction check on Pingdom as per deletion policy")
+		tr.recorder.Eventf(
+			tr.check, corev1.EventTypeNormal, ReasonRetained,
+			"Retained Pingdom transaction check %d", id,
+		)
+		return ReasonRetained, nil
+	case observabilityv1alpha1.DeletionPolicyPause:
+		request.Active = false
+		err := tr.update(request, ReasonPaused)
+		if checkreconciler.IsInvalidIdentifierError(err) {
+			err = nil
+		}
+		return ReasonPaused, err
+	}
+
+	if err := pdclient.DeleteTMSCheck(tr.pdClient, int(id)); err != nil &&
+		!checkreconciler.IsInvalidIdentifierError(err) {
+		log.Error(err, "unable to delete transaction check in Pingdom")
+		tr.recorder.Eventf(
+			tr.check, corev1.EventTypeWarning, ReasonDeleteFailed,
+			"Failed to delete Pingdom transaction check %d: %v", id, err,
+		)
+		return "", microerror.Maskf(err, "unable to delete transaction check")
+	}
+	tr.check.Status.ID = 0
+	tr.didWork = true
+	log.Info("deleted transaction check")
+	tr.recorder.Eventf(
+		tr.check, corev1.EventTypeNormal, ReasonDeleted,
+		"Deleted Pingdom transaction check %d", id,
+	)
+	return ReasonDeleted, nil
+}
+
+func (tr *transactionReconciler) deletionPolicy() observabilityv1alpha1.DeletionPolicy {
+	if tr.check.Spec.DeletionPolicy == nil {
+		return observabilityv1alpha1.DeletionPolicyDelete
+	}
+	return *tr.check.Spec.DeletionPolicy
+}
+
+// request returns the Pingdom transaction check described by the spec.
+func (tr *transactionReconciler) request() *pdclient.TMSCheck {
+	spec := &tr.check.Spec
+	request := &pdclient.TMSCheck{
+		Name:       tr.check.GetName(),
+		Active:     spec.Paused == nil || !*spec.Paused,
+		Interval:   defaultIntervalMinutes,
+		Region:     defaultRegion,
+		ContactIDs: requestIDs(tr.contactIDs),
+		TeamIDs:    requestIDs(tr.teamIDs),
+		Steps:      make([]pdclient.TMSStep, 0, len(spec.Steps)),
+	}
+	if spec.Name != nil && *spec.Name != "" {
+		request.Name = *spec.Name
+	}
+	if spec.IntervalMinutes != nil {
+		request.Interval = int(*spec.IntervalMinutes)
+	}
+	if spec.Region != nil {
+		request.Region = *spec.Region
+	}
+	for i := range spec.Steps {
+		request.Steps = append(request.Steps, tmsStep(&spec.Steps[i]))
+	}
+	return request
+}
+
+/*
+tmsStep returns the TMS function and arguments of a step: go_to, click and
+fill map to functions of the same name, assert to the function of its
+assertion.
+*/
+func tmsStep(step *observabilityv1alpha1.TransactionStep) pdclient.TMSStep {
+	switch step.Action {
+	case observabilityv1alpha1.TransactionGoTo:
+		return pdclient.TMSStep{Fn: string(step.Action), Args: map[string]string{"url": step.URL}}
+	case observabilityv1alpha1.TransactionFill:
+		return pdclient.TMSStep{Fn: string(step.Action), Args: map[string]string{
+			"input": step.Element, "value": step.Value,
+		}}
+	case observabilityv1alpha1.TransactionAssert:
+		assertion := step.AssertionOrDefault()
+		args := map[string]string{"element": step.Element}
+		if assertion == observabilityv1alpha1.AssertContainsText ||
+			assertion == observabilityv1alpha1.AssertNotContainsText {
+			args["value"] = step.Value
+		}
+		return pdclient.TMSStep{Fn: string(assertion), Args: args}
+	default:
+		return pdclient.TMSStep{Fn: string(step.Action), Args: map[string]string{"element": step.Element}}
+	}
+}
+
+// needsUpdate returns true if the transaction check read from Pingdom
+// differs from the request.
+func (tr *transactionReconciler) needsUpdate(request *pdclient.TMSCheck) bool {
+	if tr.remote == nil {
+		return true
+	}
+	remote := pdclient.TMSCheck{
+		Name:       tr.remote.Name,
+		Active:     tr.remote.Active,
+		Interval:   tr.remote.Interval,
+		Region:     tr.remote.Region,
+		ContactIDs: sortedInts(tr.remote.ContactIDs),
+		TeamIDs:    sortedInts(tr.remote.TeamIDs),
+		Steps:      tr.remote.Steps,
+	}
+	if remote.Steps == nil {
+		remote.Steps = []pdclient.TMSStep{}
+	}
+	return !reflect.DeepEqual(&remote, request)
+}
+
+// mirror sets the parameters of the check in the status to the ones of a
+// transaction check read from or sent to Pingdom.
+func (tr *transactionReconciler) mirror(check *pdclient.TMSCheck) {
+	status := &tr.check.Status
+	status.Name = check.Name
+	status.Active = check.Active
+	status.IntervalMinutes = int32(check.Interval)
+	status.Region = check.Region
+	status.ContactIDs = int32s(check.ContactIDs)
+	status.TeamIDs = int32s(check.TeamIDs)
+}
+
+// requestIDs returns sorted identifiers as ints, as sent to Pingdom
+func requestIDs(ids []int32) []int {
+	sorted := make([]int, 0, len(ids))
+	for _, id := range ids {
+		sorted = append(sorted, int(id))
+	}
+	sort.Ints(sorted)
+	return sorted
+}
+
+// sortedInts returns a sorted, never nil copy of identifiers
+func sortedInts(ids []int) []int {
+	sorted := append([]int{}, ids...)
+	sort.Ints(sorted)
+	return sorted
+}
+
+func int32s(ids []int) []int32 {
+	if len(ids) == 0 {
+		return nil
+	}
+	result := make([]int32, len(ids))
+	for i, id := range ids {
+		result[i] = int32(id)
+	}
+	return result
+}
+
+// unixTime returns the time of a Unix timestamp or nil if it's not set
+func unixTime(timestamp int64) *metav1.Time {
+	if timestamp == 0 {
+		return nil
+	}
+	t := metav1.NewTime(time.Unix(timestamp, 0))
+	return &t
+}
@@ -0,0 +1,167 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transaction
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+)
+
+var _ = Describe("TransactionReconciler", func() {
+	var (
+		ctx   = context.Background()
+		fake  *fakePingdom
+		check *observabilityv1alpha1.TransactionCheck
+	)
+
+	reconcile := func() bool {
+		reconciler := New(&Config{
+			Logger:     zap.Logger(true),
+			Recorder:   record.NewFakeRecorder(10),
+			PdClient:   fake.Client(),
+			Check:      check,
+			ContactIDs: []int32{7, 3},
+		})
+		Expect(reconciler.RefreshState(ctx)).To(Succeed())
+		Expect(reconciler.EnsureState(ctx)).To(Succeed())
+		return reconciler.DidWork()
+	}
+	deleting := func(policy observabilityv1alpha1.DeletionPolicy) {
+		Expect(reconcile()).To(BeTrue())
+		now := metav1.Now()
+		check.DeletionTimestamp = &now
+		check.Spec.DeletionPolicy = &policy
+	}
+
+	BeforeEach(func() {
+		fake = newFakePingdom()
+		notExists := observabilityv1alpha1.AssertNotExists
+		check = &observabilityv1alpha1.TransactionCheck{
+			ObjectMeta: metav1.ObjectMeta{Name: "login", Namespace: "default"},
+			Spec: observabilityv1alpha1.TransactionCheckSpec{
+				Steps: []observabilityv1alpha1.TransactionStep{
+					{Action: observabilityv1alpha1.TransactionGoTo, URL: "https://example.com/login"},
+					{Action: observabilityv1alpha1.TransactionFill, Element: "#user", Value: "monitor"},
+					{Action: observabilityv1alpha1.TransactionClick, Element: "#submit"},
+					{Action: observabilityv1alpha1.TransactionAssert, Element: "h1", Value: "Welcome"},
+					{Action: observabilityv1alpha1.TransactionAssert, Element: ".error", Assertion: &notExists},
+				},
+				CredentialsSecret: corev1.LocalObjectReference{Name: "creds"},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		fake.Close()
+	})
+
+	It("creates a transaction check running the steps", func() {
+		Expect(reconcile()).To(BeTrue())
+
+		Expect(check.Status.ID).NotTo(BeZero())
+		remote := fake.Check(check.Status.ID)
+		Expect(remote.Name).To(Equal("login"))
+		Expect(remote.Active).To(BeTrue())
+		Expect(remote.Interval).To(Equal(10))
+		Expect(remote.Region).To(Equal("us-east"))
+		Expect(remote.ContactIDs).To(Equal([]int{3, 7}))
+		Expect(remote.Steps).To(Equal([]pdclient.TMSStep{
+			{Fn: "go_to", Args: map[string]string{"url": "https://example.com/login"}},
+			{Fn: "fill", Args: map[string]string{"input": "#user", "value": "monitor"}},
+			{Fn: "click", Args: map[string]string{"element": "#submit"}},
+			{Fn: "contains_text", Args: map[string]string{"element": "h1", "value": "Welcome"}},
+			{Fn: "not_exists", Args: map[string]string{"element": ".error"}},
+		}))
+		Expect(check.Status.GetCondition(observabilityv1alpha1.Synced).Reason).To(Equal(ReasonCreated))
+	})
+
+	It("mirrors the status and does nothing when up-to-date", func() {
+		Expect(reconcile()).To(BeTrue())
+		fake.Check(check.Status.ID).Status = "failing"
+		fake.Check(check.Status.ID).LastDowntimeStart = 1600000000
+
+		Expect(reconcile()).To(BeFalse())
+		Expect(check.Status.Status).To(Equal("failing"))
+		Expect(check.Status.ContactIDs).To(Equal([]int32{3, 7}))
+		Expect(check.Status.LastDowntimeStart.Unix()).To(BeEquivalentTo(1600000000))
+		Expect(check.Status.CreatedTime.Unix()).To(BeEquivalentTo(1500000000))
+		Expect(check.Status.GetCondition(observabilityv1alpha1.Synced).Reason).To(Equal(ReasonUpToDate))
+	})
+
+	It("updates the check when the spec changes", func() {
+		Expect(reconcile()).To(BeTrue())
+		region := "eu"
+		check.Spec.Region = &region
+		check.Spec.Steps = check.Spec.Steps[:1]
+
+		Expect(reconcile()).To(BeTrue())
+		remote := fake.Check(check.Status.ID)
+		Expect(remote.Region).To(Equal("eu"))
+		Expect(remote.Steps).To(HaveLen(1))
+		Expect(check.Status.Region).To(Equal("eu"))
+	})
+
+	It("re-creates a check deleted in Pingdom", func() {
+		Expect(reconcile()).To(BeTrue())
+		id := check.Status.ID
+		delete(fake.checks, int(id))
+
+		Expect(reconcile()).To(BeTrue())
+		Expect(check.Status.ID).NotTo(Equal(id))
+		Expect(fake.Check(check.Status.ID)).NotTo(BeNil())
+	})
+
+	It("deletes the check by default", func() {
+		deleting(observabilityv1alpha1.DeletionPolicyDelete)
+		id := check.Status.ID
+
+		Expect(reconcile()).To(BeTrue())
+		Expect(fake.Check(id)).To(BeNil())
+		Expect(check.Status.ID).To(BeZero())
+	})
+
+	It("pauses the check with the Pause policy", func() {
+		deleting(observabilityv1alpha1.DeletionPolicyPause)
+
+		Expect(reconcile()).To(BeTrue())
+		Expect(fake.Check(check.Status.ID).Active).To(BeFalse())
+		Expect(check.Status.GetCondition(observabilityv1alpha1.Synced).Reason).To(Equal(ReasonPaused))
+	})
+
+	It("leaves the check with the Retain policy", func() {
+		deleting(observabilityv1alpha1.DeletionPolicyRetain)
+
+		Expect(reconcile()).To(BeFalse())
+		Expect(fake.Check(check.Status.ID).Active).To(BeTrue())
+	})
+
+	It("succeeds deleting a check already gone", func() {
+		deleting(observabilityv1alpha1.DeletionPolicyDelete)
+		delete(fake.checks, int(check.Status.ID))
+
+		Expect(reconcile()).To(BeFalse())
+		Expect(check.Status.ID).To(BeZero())
+	})
+})
@@ -0,0 +1,82 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package transaction implements a ResourceReconciler for Pingdom transaction
+checks (TMS), maintained for TransactionCheck objects.
+
+Transaction checks are only supported by the Pingdom API 3.1.
+*/
+package transaction
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/russellcardullo/go-pingdom/pingdom"
+	"k8s.io/client-go/tools/record"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+	"gitlab.com/mig4/pingdom-operator/controllers/resources"
+)
+
+/*
+Config is a structure holding data needed to create a new ResourceReconciller
+for TransactionCheck objects.
+*/
+type Config struct {
+	Logger   logr.Logger
+	Recorder record.EventRecorder
+	Check    *observabilityv1alpha1.TransactionCheck
+
+	// PdClient is the Pingdom API client for the check's credentials; it's
+	// shared with other reconciles so must not be modified
+	PdClient *pingdom.Client
+
+	// ContactIDs and TeamIDs are identifiers of Pingdom contacts and teams
+	// of the PingdomContacts and PingdomTeams the check alerts
+	ContactIDs []int32
+	TeamIDs    []int32
+}
+
+type transactionReconciler struct {
+	log        logr.Logger
+	recorder   record.EventRecorder
+	pdClient   *pingdom.Client
+	check      *observabilityv1alpha1.TransactionCheck
+	contactIDs []int32
+	teamIDs    []int32
+
+	// remote is the transaction check as read from Pingdom by RefreshState
+	remote  *pdclient.TMSCheck
+	didWork bool
+}
+
+/*
+New returns a new ResourceReconciller for a Pingdom transaction check
+external resource.
+*/
+func New(config *Config) resources.ResourceReconciler {
+	return &transactionReconciler{
+		log: config.Logger.WithName("resource-reconciler").WithValues(
+			"name", config.Check.GetName(),
+		),
+		recorder:   config.Recorder,
+		pdClient:   config.PdClient,
+		check:      config.Check,
+		contactIDs: config.ContactIDs,
+		teamIDs:    config.TeamIDs,
+		didWork:    false,
+	}
+}
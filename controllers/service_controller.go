@@ -0,0 +1,220 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/metrics"
+)
+
+// serviceControllerName labels metrics of the Service controller
+const serviceControllerName = "service"
+
+// ServiceReconciler generates Checks from annotated LoadBalancer Services
+type ServiceReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Recorder record.EventRecorder
+	Scheme   *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=checks,verbs=get;list;watch;create;update;patch;delete
+
+/*
+Reconcile maintains a Check for each port of the LoadBalancer Service
+specified in the given request, if it opted in with the CheckAnnotation,
+checking the address of the load balancer.
+
+Checks are left alone until the load balancer has an address, and deleted
+when the Service is not a LoadBalancer any more.
+*/
+func (r *ServiceReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	log := r.Log.WithValues("resource", "service", "namespacedName", req.NamespacedName)
+
+	var service corev1.Service
+	if err := r.Get(ctx, req.NamespacedName, &service); err != nil {
+		// Checks of deleted Services are deleted by the garbage collector
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !service.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	var desired []observabilityv1alpha1.Check
+	if generateChecks(&service) && service.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		if loadBalancerHost(&service) == "" {
+			// the Service is reconciled again once its status is updated
+			log.V(1).Info("load balancer has no address yet")
+			return ctrl.Result{}, nil
+		}
+		template, err := checkTemplate(&service)
+		if err == nil {
+			desired, err = serviceChecks(&service, template)
+		}
+		if err != nil {
+			// retrying won't help until the annotations change
+			log.Info("invalid Check annotations", "error", err.Error())
+			r.Recorder.Event(&service, corev1.EventTypeWarning, "InvalidAnnotation", err.Error())
+			metrics.RecordReconcileError(serviceControllerName, "InvalidAnnotation")
+			return ctrl.Result{}, nil
+		}
+	}
+
+	generated := &generatedChecks{Client: r.Client, scheme: r.Scheme, kind: "Service"}
+	if err := generated.sync(ctx, &service, desired); IsCheckInvalid(err) {
+		// retrying won't help until the object the Checks are generated from
+		// changes
+		log.Info("generated Checks are invalid", "reason", err.Error())
+		r.Recorder.Event(&service, corev1.EventTypeWarning, "Invalid", err.Error())
+		metrics.RecordReconcileError(serviceControllerName, "Invalid")
+		return ctrl.Result{}, nil
+	} else if IsCheckNameConflict(err) {
+		// retrying won't help until the other Checks are gone
+		log.Info("names of generated Checks are taken", "reason", err.Error())
+		r.Recorder.Event(&service, corev1.EventTypeWarning, "NameConflict", err.Error())
+		metrics.RecordReconcileError(serviceControllerName, "NameConflict")
+		return ctrl.Result{}, nil
+	} else if err != nil {
+		r.Recorder.Eventf(
+			&service, corev1.EventTypeWarning, "ChecksFailed",
+			"Unable to generate Checks: %v", err,
+		)
+		metrics.RecordReconcileError(serviceControllerName, "ChecksFailed")
+		return ctrl.Result{}, err
+	}
+	log.V(1).Info("generated Checks are in sync", "checks", len(desired))
+	return ctrl.Result{}, nil
+}
+
+// loadBalancerHost returns the hostname or IP address of the Service's load
+// balancer, empty if it has none yet.
+func loadBalancerHost(service *corev1.Service) string {
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		if ingress.Hostname != "" {
+			return ingress.Hostname
+		}
+		if ingress.IP != "" {
+			return ingress.IP
+		}
+	}
+	return ""
+}
+
+/*
+serviceChecks returns Checks of each port of the Service, based on given
+template, with the type set by the TypeAnnotation: TCP ports get tcp or http
+Checks. The PortsAnnotation limits the ports checked.
+
+UDP ports aren't checked, as Pingdom requires udp checks to send and expect
+strings specific to the service, which can't be derived from the Service.
+
+Checks are named after the port, not the address, so they are updated in
+place when the load balancer's address changes.
+*/
+func serviceChecks(
+	service *corev1.Service,
+	template *observabilityv1alpha1.CheckSpec,
+) ([]observabilityv1alpha1.Check, error) {
+	annotations := service.GetAnnotations()
+	checkType := observabilityv1alpha1.CheckType(annotations[observabilityv1alpha1.TypeAnnotation])
+	switch checkType {
+	case "":
+		checkType = observabilityv1alpha1.TCP
+	case observabilityv1alpha1.TCP, observabilityv1alpha1.HTTP:
+	case observabilityv1alpha1.UDP:
+		return nil, fmt.Errorf(
+			"invalid %s annotation: udp is not supported, Pingdom requires "+
+				"udp checks to set strings to send and expect",
+			observabilityv1alpha1.TypeAnnotation,
+		)
+	default:
+		return nil, fmt.Errorf(
+			"invalid %s annotation: %q is not one of tcp, http",
+			observabilityv1alpha1.TypeAnnotation, checkType,
+		)
+	}
+	selected := map[string]bool{}
+	for _, port := range strings.Split(annotations[observabilityv1alpha1.PortsAnnotation], ",") {
+		if port = strings.TrimSpace(port); port != "" {
+			selected[port] = true
+		}
+	}
+
+	host := loadBalancerHost(service)
+	checks := []observabilityv1alpha1.Check{}
+	for _, port := range service.Spec.Ports {
+		number := strconv.Itoa(int(port.Port))
+		if port.Protocol != corev1.ProtocolTCP && port.Protocol != "" {
+			continue
+		}
+		if len(selected) > 0 && !selected[number] && (port.Name == "" || !selected[port.Name]) {
+			continue
+		}
+
+		check := observabilityv1alpha1.Check{}
+		check.SetName(generatedCheckName("Service", service.GetName(), string(corev1.ProtocolTCP), number))
+		check.Spec = *template.DeepCopy()
+		name := fmt.Sprintf("%s/%s:%s", service.GetNamespace(), service.GetName(), number)
+		portNumber := port.Port
+		check.Spec.Name = &name
+		check.Spec.Host = host
+		check.Spec.Type = checkType
+		check.Spec.Port = &portNumber
+		if checkType == observabilityv1alpha1.HTTP {
+			url := "/"
+			if value, ok := annotations[observabilityv1alpha1.URLAnnotation]; ok {
+				url = value
+			}
+			encryption := annotations[observabilityv1alpha1.EncryptionAnnotation] == "true"
+			check.Spec.URL = &url
+			check.Spec.Encryption = &encryption
+		} else {
+			// content assertions only apply to HTTP checks
+			check.Spec.ShouldContain = nil
+			check.Spec.ShouldNotContain = nil
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+/*
+SetupWithManager configures this reconciler to be triggered for events
+pertaining to specified resource kinds.
+
+Besides Services it watches the Checks they own, so changes made to them
+directly are reverted.
+*/
+func (r *ServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Service{}).
+		Owns(&observabilityv1alpha1.Check{}).
+		Complete(r)
+}
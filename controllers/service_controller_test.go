@@ -0,0 +1,105 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+)
+
+var _ = Describe("serviceChecks", func() {
+	var service *corev1.Service
+	template := &observabilityv1alpha1.CheckSpec{
+		CheckParameters:   observabilityv1alpha1.CheckParameters{ShouldContain: ptrS("OK")},
+		CredentialsSecret: corev1.LocalObjectReference{Name: "creds"},
+	}
+	summarize := func(checks []observabilityv1alpha1.Check) []string {
+		summary := []string{}
+		for _, check := range checks {
+			summary = append(summary, fmt.Sprintf(
+				"%s://%s:%d", check.Spec.Type, check.Spec.Host, *check.Spec.Port,
+			))
+		}
+		return summary
+	}
+
+	BeforeEach(func() {
+		service = &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "db", Namespace: "default", Annotations: map[string]string{},
+			},
+			Spec: corev1.ServiceSpec{
+				Type: corev1.ServiceTypeLoadBalancer,
+				Ports: []corev1.ServicePort{
+					{Name: "sql", Port: 5, Protocol: corev1.ProtocolTCP},
+					{Name: "admin", Port: 8},
+					{Name: "stats", Port: 9, Protocol: corev1.ProtocolUDP},
+				},
+			},
+			Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{
+				Ingress: []corev1.LoadBalancerIngress{{IP: "192.0.2.1"}},
+			}},
+		}
+	})
+
+	It("generates TCP Checks of TCP ports by default", func() {
+		checks, err := serviceChecks(service, template)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(summarize(checks)).To(Equal([]string{"tcp://192.0.2.1:5", "tcp://192.0.2.1:8"}))
+		Expect(*checks[0].Spec.Name).To(Equal("default/db:5"))
+		Expect(checks[0].Spec.ShouldContain).To(BeNil())
+		Expect(checks[0].Spec.CredentialsSecret.Name).To(Equal("creds"))
+	})
+
+	It("rejects UDP Checks", func() {
+		service.Annotations[observabilityv1alpha1.TypeAnnotation] = "udp"
+		_, err := serviceChecks(service, template)
+		Expect(err).To(MatchError(ContainSubstring("udp is not supported")))
+	})
+
+	It("generates HTTP Checks of selected ports", func() {
+		service.Annotations[observabilityv1alpha1.TypeAnnotation] = "http"
+		service.Annotations[observabilityv1alpha1.PortsAnnotation] = "admin"
+		service.Annotations[observabilityv1alpha1.URLAnnotation] = "/health"
+		service.Annotations[observabilityv1alpha1.EncryptionAnnotation] = "true"
+		checks, err := serviceChecks(service, template)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(summarize(checks)).To(Equal([]string{"http://192.0.2.1:8"}))
+		Expect(*checks[0].Spec.URL).To(Equal("/health"))
+		Expect(*checks[0].Spec.Encryption).To(BeTrue())
+		Expect(*checks[0].Spec.ShouldContain).To(Equal("OK"))
+	})
+
+	It("keeps names of Checks when the address changes", func() {
+		before, _ := serviceChecks(service, template)
+		service.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{Hostname: "lb.example.com"}}
+		after, _ := serviceChecks(service, template)
+		Expect(after[0].GetName()).To(Equal(before[0].GetName()))
+		Expect(after[0].Spec.Host).To(Equal("lb.example.com"))
+	})
+
+	It("rejects unknown types", func() {
+		service.Annotations[observabilityv1alpha1.TypeAnnotation] = "ping"
+		_, err := serviceChecks(service, template)
+		Expect(err).To(HaveOccurred())
+	})
+})
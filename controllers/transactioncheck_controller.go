@@ -0,0 +1,218 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+	"gitlab.com/mig4/pingdom-operator/controllers/metrics"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
+	transactionreconciler "gitlab.com/mig4/pingdom-operator/controllers/resources/transaction"
+)
+
+// How often transaction checks are re-synced, to mirror their status
+const transactionResyncInterval = 5 * time.Minute
+
+// transactionControllerName labels metrics of the TransactionCheck
+// controller
+const transactionControllerName = "transactioncheck"
+
+// TransactionCheckReconciler reconciles a TransactionCheck object
+type TransactionCheckReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Recorder record.EventRecorder
+
+	// PdAPIKey is the operator-wide Pingdom application key, see
+	// CheckReconciler
+	PdAPIKey string
+
+	// PdClients is the cache of Pingdom API clients shared by all
+	// reconcilers
+	PdClients *pdclient.Cache
+}
+
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=transactionchecks,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=transactionchecks/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=pingdomcontacts,verbs=get;list;watch
+// +kubebuilder:rbac:groups=observability.pingdom.mig4.gitlab.io,resources=pingdomteams,verbs=get;list;watch
+
+/*
+Reconcile ensures the Pingdom transaction check of the TransactionCheck
+specified in the given request matches its spec and alerts the Pingdom
+contacts and teams of the PingdomContacts and PingdomTeams it references.
+*/
+func (r *TransactionCheckReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	log := r.Log.WithValues("resource", "transactioncheck", "namespacedName", req.NamespacedName)
+	sync := &externalSync{
+		Client:         r.Client,
+		log:            log,
+		controller:     transactionControllerName,
+		resyncInterval: transactionResyncInterval,
+	}
+
+	var check observabilityv1alpha1.TransactionCheck
+	if err := r.Get(ctx, req.NamespacedName, &check); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	defer func() {
+		sync.updateStatus(ctx, &check, &check.Status, "TransactionCheck", fmt.Sprintf(
+			"Pingdom transaction check status is %s", check.Status.Status,
+		))
+	}()
+
+	deleting := !check.GetDeletionTimestamp().IsZero()
+	if deleting {
+		check.Status.SetCondition(
+			observabilityv1alpha1.Deleting, corev1.ConditionTrue,
+			"Deleting", "TransactionCheck is being deleted",
+		)
+	} else if err := check.Spec.Valid(); err != nil {
+		// retrying won't help until the spec changes
+		log.Info("invalid TransactionCheck spec", "error", err.Error())
+		check.Status.SetCondition(
+			observabilityv1alpha1.Synced, corev1.ConditionFalse,
+			"Invalid", err.Error(),
+		)
+		return ctrl.Result{}, nil
+	}
+
+	pdClient, err := sync.credentialsClient(
+		ctx, &check, &check.Status, check.Spec.CredentialsSecret.Name,
+		check.Spec.AccountRef, r.PdAPIKey, r.PdClients,
+	)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !requireAPIVersion(pdClient, &check.Status, pdclient.APIVersion31, "TransactionCheck") {
+		// retrying won't help until the credentials change
+		return ctrl.Result{}, nil
+	}
+
+	var alertContactIDs, alertTeamIDs []int32
+	if !deleting {
+		alertContactIDs, err = contactIDs(ctx, r.Client, check.GetNamespace(), check.Spec.Contacts)
+		if err == nil {
+			alertTeamIDs, err = teamIDs(ctx, r.Client, check.GetNamespace(), check.Spec.Teams)
+		}
+		if IsContactNotReady(err) {
+			// the check is reconciled again once the contact or team is
+			// ready, as it watches PingdomContacts and PingdomTeams
+			log.Info("contact to alert is not ready", "reason", err.Error())
+			check.Status.SetCondition(
+				observabilityv1alpha1.Synced, corev1.ConditionFalse,
+				"ContactNotReady", err.Error(),
+			)
+			return ctrl.Result{}, nil
+		}
+		if err != nil {
+			metrics.RecordReconcileError(transactionControllerName, "ContactLookupFailed")
+			return ctrl.Result{}, err
+		}
+	}
+
+	return sync.run(
+		ctx, &check, &check.Status, &check.Status.ObservedGeneration,
+		transactionreconciler.New(&transactionreconciler.Config{
+			Logger:     log,
+			Recorder:   r.Recorder,
+			PdClient:   pdClient.Client,
+			Check:      &check,
+			ContactIDs: alertContactIDs,
+			TeamIDs:    alertTeamIDs,
+		}),
+	)
+}
+
+/*
+requestsForAlerting returns a function mapping a PingdomContact or a
+PingdomTeam to reconcile requests for TransactionChecks in its namespace
+which alert it, references are picked by given function.
+*/
+func (r *TransactionCheckReconciler) requestsForAlerting(
+	references func(*observabilityv1alpha1.TransactionCheck) []corev1.LocalObjectReference,
+) handler.ToRequestsFunc {
+	return func(obj handler.MapObject) []reconcile.Request {
+		ctx := context.Background()
+		var checks observabilityv1alpha1.TransactionCheckList
+		if err := r.List(ctx, &checks, client.InNamespace(obj.Meta.GetNamespace())); err != nil {
+			r.Log.Error(err, "unable to list TransactionChecks", "namespace", obj.Meta.GetNamespace())
+			return nil
+		}
+
+		requests := []reconcile.Request{}
+		for i := range checks.Items {
+			check := &checks.Items[i]
+			if hasRef(references(check), obj.Meta.GetName()) {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Namespace: check.GetNamespace(),
+						Name:      check.GetName(),
+					},
+				})
+			}
+		}
+		return requests
+	}
+}
+
+/*
+SetupWithManager configures this reconciler to be triggered for events
+pertaining to specified resource kinds.
+
+Besides TransactionChecks it watches PingdomContacts and PingdomTeams, so
+checks pick up contacts and teams to alert once they are created in Pingdom.
+*/
+func (r *TransactionCheckReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&observabilityv1alpha1.TransactionCheck{}).
+		Watches(
+			&source.Kind{Type: &observabilityv1alpha1.PingdomContact{}},
+			&handler.EnqueueRequestsFromMapFunc{
+				ToRequests: r.requestsForAlerting(
+					func(check *observabilityv1alpha1.TransactionCheck) []corev1.LocalObjectReference {
+						return check.Spec.Contacts
+					},
+				),
+			},
+		).
+		Watches(
+			&source.Kind{Type: &observabilityv1alpha1.PingdomTeam{}},
+			&handler.EnqueueRequestsFromMapFunc{
+				ToRequests: r.requestsForAlerting(
+					func(check *observabilityv1alpha1.TransactionCheck) []corev1.LocalObjectReference {
+						return check.Spec.Teams
+					},
+				),
+			},
+		).
+		Complete(r)
+}
@@ -0,0 +1,99 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
+)
+
+var _ = Describe("TransactionCheckReconciler", func() {
+	var r *TransactionCheckReconciler
+
+	transactionCheck := func(namespace, name string, contacts ...string) *observabilityv1alpha1.TransactionCheck {
+		check := &observabilityv1alpha1.TransactionCheck{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: observabilityv1alpha1.TransactionCheckSpec{
+				Steps: []observabilityv1alpha1.TransactionStep{
+					{Action: observabilityv1alpha1.TransactionGoTo, URL: "https://example.com"},
+				},
+				CredentialsSecret: corev1.LocalObjectReference{Name: "creds"},
+			},
+		}
+		for _, contact := range contacts {
+			check.Spec.Contacts = append(check.Spec.Contacts, corev1.LocalObjectReference{Name: contact})
+		}
+		return check
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(observabilityv1alpha1.AddToScheme(scheme)).To(Succeed())
+		r = &TransactionCheckReconciler{
+			Client: fake.NewFakeClientWithScheme(
+				scheme,
+				transactionCheck("default", "login", "ops"),
+				transactionCheck("default", "search"),
+				transactionCheck("other", "login", "ops"),
+				func() *observabilityv1alpha1.TransactionCheck {
+					check := transactionCheck("default", "invalid")
+					check.Spec.Steps[0].URL = ""
+					return check
+				}(),
+			),
+			Log: zap.Logger(true),
+		}
+	})
+
+	It("requeues TransactionChecks alerting a contact", func() {
+		toRequests := r.requestsForAlerting(
+			func(check *observabilityv1alpha1.TransactionCheck) []corev1.LocalObjectReference {
+				return check.Spec.Contacts
+			},
+		)
+		contact := &observabilityv1alpha1.PingdomContact{
+			ObjectMeta: metav1.ObjectMeta{Name: "ops", Namespace: "default"},
+		}
+		Expect(toRequests(handler.MapObject{Meta: contact, Object: contact})).To(ConsistOf(
+			reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "login"}},
+		))
+	})
+
+	It("reports an invalid spec without retrying", func() {
+		nsName := types.NamespacedName{Namespace: "default", Name: "invalid"}
+		_, err := r.Reconcile(ctrl.Request{NamespacedName: nsName})
+		Expect(err).NotTo(HaveOccurred())
+
+		var check observabilityv1alpha1.TransactionCheck
+		Expect(r.Get(context.Background(), nsName, &check)).To(Succeed())
+		synced := check.Status.GetCondition(observabilityv1alpha1.Synced)
+		Expect(synced).NotTo(BeNil())
+		Expect(synced.Reason).To(Equal("Invalid"))
+	})
+})
@@ -16,11 +16,14 @@ limitations under the License.
 package main
 
 import (
+	"crypto/rand"
 	"flag"
 	"os"
+	"time"
 
 	observabilityv1alpha1 "gitlab.com/mig4/pingdom-operator/api/v1alpha1"
 	"gitlab.com/mig4/pingdom-operator/controllers"
+	"gitlab.com/mig4/pingdom-operator/controllers/pdclient"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
@@ -44,13 +47,76 @@ func init() {
 func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
+	var enableWebhooks bool
+	var enableGatewayAPI bool
+	var pdAppKey string
+	var authChecksumKey string
+	var pdRateLimit float64
+	var pdRateBurst int
+	var pdPollInterval, pdDetailsInterval time.Duration
+	var requeueInterval, requeueAfterChangeInterval, summaryInterval time.Duration
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", true,
+		"Enable admission webhooks. Disable when running locally without serving certificates.")
+	flag.BoolVar(&enableGatewayAPI, "enable-gateway-api", false,
+		"Generate Checks from Gateway API HTTPRoutes. Requires Gateway API CRDs to be installed.")
+	flag.StringVar(&pdAppKey, "pingdom-app-key", "",
+		"Pingdom application key used for all checks using API 2.1, unless overridden by `appKey` in the credentials secret. "+
+			"Defaults to the value of PINGDOM_APP_KEY environment variable.")
+	flag.StringVar(&authChecksumKey, "auth-checksum-key", "",
+		"Secret key of checksums of basic authentication credentials and request headers of HTTP checks "+
+			"stored in their status. Defaults to the value of AUTH_CHECKSUM_KEY environment variable, or a "+
+			"random key which changes on every restart, causing checks with either to be updated.")
+	flag.Float64Var(&pdRateLimit, "pingdom-rate-limit", 2,
+		"Average number of requests per second made to Pingdom API with each set of credentials; 0 disables the limit. "+
+			"Limits reported by Pingdom API are always respected.")
+	flag.IntVar(&pdRateBurst, "pingdom-rate-burst", 10,
+		"Maximum burst of requests made to Pingdom API with each set of credentials.")
+	flag.DurationVar(&pdPollInterval, "pingdom-poll-interval", time.Minute,
+		"How often all checks of each Pingdom account are listed, in a single request, to refresh status of Checks; "+
+			"0 disables polling and each Check is read individually.")
+	flag.DurationVar(&pdDetailsInterval, "pingdom-details-interval", 30*time.Minute,
+		"How often each check is read individually when polling, to detect changes to details not included in the list "+
+			"of checks (e.g. HTTP parameters, contacts) made outside of the operator; 0 only reads them after changes.")
+	flag.DurationVar(&requeueInterval, "requeue-interval", controllers.DefaultRequeueInterval,
+		"Default interval between reconciles of a Check; the check's resolution is used instead if it's longer, "+
+			"Checks can override it with `syncInterval`.")
+	flag.DurationVar(&requeueAfterChangeInterval, "requeue-after-change-interval",
+		controllers.DefaultRequeueAfterChangeInterval,
+		"Interval before the next reconcile of a Check after its Pingdom check was changed.")
+	flag.DurationVar(&summaryInterval, "summary-interval", controllers.DefaultSummaryInterval,
+		"Interval between refreshes of the uptime and outage summary in the status of Checks, "+
+			"which takes two Pingdom API requests per check; 0 disables the summary.")
 	flag.Parse()
 
+	if pdAppKey == "" {
+		pdAppKey = os.Getenv("PINGDOM_APP_KEY")
+	}
+
+	if authChecksumKey == "" {
+		authChecksumKey = os.Getenv("AUTH_CHECKSUM_KEY")
+	}
+
 	ctrl.SetLogger(zap.Logger(true))
 
+	if pdAppKey == "" {
+		setupLog.Info("no Pingdom app key configured, checks using API 2.1 will " +
+			"only work if their credentials secret contains an `appKey`")
+	}
+
+	checksumKey := []byte(authChecksumKey)
+	if authChecksumKey == "" {
+		setupLog.Info("no auth checksum key configured, using a random key; checks " +
+			"with basic authentication or request headers will be updated after every restart")
+		checksumKey = make([]byte, 32)
+		if _, err := rand.Read(checksumKey); err != nil {
+			setupLog.Error(err, "unable to generate auth checksum key")
+			os.Exit(1)
+		}
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:             scheme,
 		MetricsBindAddress: metricsAddr,
@@ -61,14 +127,135 @@ func main() {
 		os.Exit(1)
 	}
 
+	pdClients := pdclient.NewCache(pdclient.CacheConfig{
+		BaseURL:           pdclient.DefaultBaseURL,
+		RequestsPerSecond: pdRateLimit,
+		Burst:             pdRateBurst,
+		PollInterval:      pdPollInterval,
+		DetailsInterval:   pdDetailsInterval,
+		Logger:            ctrl.Log.WithName("pingdom").WithName("poller"),
+	})
+	if err = mgr.Add(pdClients); err != nil {
+		setupLog.Error(err, "unable to add Pingdom poller")
+		os.Exit(1)
+	}
 	if err = (&controllers.CheckReconciler{
-		Client:   mgr.GetClient(),
-		Log:      ctrl.Log.WithName("pingdom").WithName("controller"),
-		PdAPIKey: "g93ih2cttviiska6b29xy63y3f7dqe4g",
+		Client:    mgr.GetClient(),
+		Log:       ctrl.Log.WithName("pingdom").WithName("controller"),
+		Recorder:  mgr.GetEventRecorderFor("check-controller"),
+		PdAPIKey:  pdAppKey,
+		PdClients: pdClients,
+
+		AuthChecksumKey:            checksumKey,
+		RequeueInterval:            requeueInterval,
+		RequeueAfterChangeInterval: requeueAfterChangeInterval,
+		SummaryInterval:            summaryInterval,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Check")
 		os.Exit(1)
 	}
+	if err = (&controllers.PingdomAccountReconciler{
+		Client:    mgr.GetClient(),
+		Log:       ctrl.Log.WithName("pingdom").WithName("PingdomAccount"),
+		PdAPIKey:  pdAppKey,
+		PdClients: pdClients,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "PingdomAccount")
+		os.Exit(1)
+	}
+	if err = (&controllers.ClusterPingdomAccountReconciler{
+		Client:    mgr.GetClient(),
+		Log:       ctrl.Log.WithName("pingdom").WithName("ClusterPingdomAccount"),
+		PdAPIKey:  pdAppKey,
+		PdClients: pdClients,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterPingdomAccount")
+		os.Exit(1)
+	}
+	if err = (&controllers.MaintenanceWindowReconciler{
+		Client:    mgr.GetClient(),
+		Log:       ctrl.Log.WithName("pingdom").WithName("MaintenanceWindow"),
+		Recorder:  mgr.GetEventRecorderFor("maintenancewindow-controller"),
+		PdAPIKey:  pdAppKey,
+		PdClients: pdClients,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "MaintenanceWindow")
+		os.Exit(1)
+	}
+	if err = (&controllers.TransactionCheckReconciler{
+		Client:    mgr.GetClient(),
+		Log:       ctrl.Log.WithName("pingdom").WithName("TransactionCheck"),
+		Recorder:  mgr.GetEventRecorderFor("transactioncheck-controller"),
+		PdAPIKey:  pdAppKey,
+		PdClients: pdClients,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TransactionCheck")
+		os.Exit(1)
+	}
+	if err = (&controllers.PingdomContactReconciler{
+		Client:    mgr.GetClient(),
+		Log:       ctrl.Log.WithName("pingdom").WithName("PingdomContact"),
+		Recorder:  mgr.GetEventRecorderFor("pingdomcontact-controller"),
+		PdAPIKey:  pdAppKey,
+		PdClients: pdClients,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "PingdomContact")
+		os.Exit(1)
+	}
+	if err = (&controllers.PingdomTeamReconciler{
+		Client:    mgr.GetClient(),
+		Log:       ctrl.Log.WithName("pingdom").WithName("PingdomTeam"),
+		Recorder:  mgr.GetEventRecorderFor("pingdomteam-controller"),
+		PdAPIKey:  pdAppKey,
+		PdClients: pdClients,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "PingdomTeam")
+		os.Exit(1)
+	}
+	if err = (&controllers.IngressReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("pingdom").WithName("Ingress"),
+		Recorder: mgr.GetEventRecorderFor("ingress-controller"),
+		Scheme:   mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Ingress")
+		os.Exit(1)
+	}
+	if err = (&controllers.ServiceReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("pingdom").WithName("Service"),
+		Recorder: mgr.GetEventRecorderFor("service-controller"),
+		Scheme:   mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Service")
+		os.Exit(1)
+	}
+	if err = (&controllers.CheckSetReconciler{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("pingdom").WithName("CheckSet"),
+		Recorder: mgr.GetEventRecorderFor("checkset-controller"),
+		Scheme:   mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CheckSet")
+		os.Exit(1)
+	}
+	if enableGatewayAPI {
+		if err = (&controllers.HTTPRouteReconciler{
+			Client:   mgr.GetClient(),
+			Log:      ctrl.Log.WithName("pingdom").WithName("HTTPRoute"),
+			Recorder: mgr.GetEventRecorderFor("httproute-controller"),
+			Scheme:   mgr.GetScheme(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "HTTPRoute")
+			os.Exit(1)
+		}
+	}
+	if enableWebhooks {
+		if err = (&observabilityv1alpha1.Check{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Check")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	setupLog.Info("starting manager")